@@ -7,8 +7,10 @@ import (
 	"github.com/babon21/redis-impl/internal/app/client/gateway"
 	"github.com/babon21/redis-impl/internal/app/client/usecase"
 	"github.com/babon21/redis-impl/internal/pkg/http/middleware"
+	"github.com/babon21/redis-impl/internal/pkg/tlsconfig"
 	"github.com/labstack/echo"
 	"github.com/rs/zerolog/log"
+	"net/http"
 )
 
 func main() {
@@ -18,7 +20,20 @@ func main() {
 	middL := middleware.InitMiddleware()
 	e.Use(middL.AccessLogMiddleware)
 	fmt.Println(conf.Server.ServerUrl)
-	redisGateway := gateway.NewRedisGateway(conf.Server.ServerUrl)
+
+	httpClient := &http.Client{}
+	if conf.TLS.CAFile != "" || conf.TLS.CertFile != "" {
+		reloader, err := tlsconfig.NewReloader(conf.TLS.CertFile, conf.TLS.KeyFile, conf.TLS.CAFile)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		httpClient.Transport = &http.Transport{
+			Proxy:          http.ProxyFromEnvironment,
+			DialTLSContext: reloader.DialTLSContext,
+		}
+	}
+
+	redisGateway := gateway.NewRedisGateway(conf.Server.ServerUrl, httpClient)
 	redisUsecase := usecase.NewRedisUsecase(redisGateway)
 	cacheHttp.NewCacheHandler(e, redisUsecase)
 
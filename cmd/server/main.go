@@ -2,12 +2,19 @@ package main
 
 import (
 	"github.com/babon21/redis-impl/internal/app/server/config"
+	cacheGrpc "github.com/babon21/redis-impl/internal/app/server/delivery/grpc"
 	cacheHttp "github.com/babon21/redis-impl/internal/app/server/delivery/http"
+	"github.com/babon21/redis-impl/internal/app/server/delivery/resp"
 	"github.com/babon21/redis-impl/internal/app/server/repository"
 	"github.com/babon21/redis-impl/internal/app/server/usecase"
 	"github.com/babon21/redis-impl/internal/pkg/http/middleware"
+	"github.com/babon21/redis-impl/internal/pkg/listener"
+	"github.com/babon21/redis-impl/internal/pkg/tlsconfig"
 	"github.com/labstack/echo"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"net"
 )
 
 func main() {
@@ -19,6 +26,58 @@ func main() {
 	redisStore := repository.NewInMemoryRedisStore()
 	redisUsecase := usecase.NewRedisUsecase(redisStore)
 	cacheHttp.NewCacheHandler(e, redisUsecase)
+	cacheHttp.NewCacheHandlerV2(e, redisUsecase)
 
+	respServer := resp.NewServer(redisUsecase)
+	var grpcOptions []grpc.ServerOption
+	if conf.TLS.CertFile != "" {
+		reloader, err := tlsconfig.NewReloader(conf.TLS.CertFile, conf.TLS.KeyFile, conf.TLS.ClientCAFile)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		e.TLSServer.TLSConfig = reloader.ServerConfig()
+		respServer.TLSConfig = reloader.ServerConfig()
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(reloader.ServerConfig())))
+	}
+
+	grpcServer := grpc.NewServer(grpcOptions...)
+	cacheGrpc.NewCacheServer(grpcServer, redisUsecase)
+	go func() {
+		l, err := net.Listen("tcp", ":"+conf.Grpc.Port)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		log.Info().Msgf("grpc server started on %s", l.Addr())
+		log.Fatal().Msg(grpcServer.Serve(l).Error())
+	}()
+
+	go func() {
+		log.Fatal().Msg(respServer.ListenAndServe(":" + conf.Resp.Port).Error())
+	}()
+
+	if conf.Unix.RespSocket != "" {
+		l, err := listener.Unix(conf.Unix.RespSocket, conf.Unix.Perm)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		go func() {
+			log.Fatal().Msg(respServer.Serve(l).Error())
+		}()
+	}
+
+	if conf.Unix.HttpSocket != "" {
+		l, err := listener.Unix(conf.Unix.HttpSocket, conf.Unix.Perm)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+		}
+		go func() {
+			log.Fatal().Msg(e.Server.Serve(l).Error())
+		}()
+	}
+
+	if e.TLSServer.TLSConfig != nil {
+		e.TLSServer.Addr = ":" + conf.Server.Port
+		log.Fatal().Msg(e.StartServer(e.TLSServer).Error())
+	}
 	log.Fatal().Msg(e.Start(":" + conf.Server.Port).Error())
 }
@@ -9,6 +9,13 @@ type Config struct {
 		Port      string
 		ServerUrl string
 	}
+	// TLS configures the connection to the cache server: CAFile verifies the server certificate,
+	// CertFile and KeyFile are presented to the server when it requires client certificates
+	TLS struct {
+		CAFile   string
+		CertFile string
+		KeyFile  string
+	}
 }
 
 func Init() Config {
@@ -16,5 +23,9 @@ func Init() Config {
 	viper.AutomaticEnv()
 	config.Server.Port = viper.GetString("CLIENT_PORT")
 	config.Server.ServerUrl = viper.GetString("SERVER_URL")
+
+	config.TLS.CAFile = viper.GetString("SERVER_TLS_CA_FILE")
+	config.TLS.CertFile = viper.GetString("CLIENT_TLS_CERT_FILE")
+	config.TLS.KeyFile = viper.GetString("CLIENT_TLS_KEY_FILE")
 	return config
 }
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) SetBit(c echo.Context) error {
+	response, err := h.RedisUsecase.SetBit(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetBit(c echo.Context) error {
+	response, err := h.RedisUsecase.GetBit(params.PathParam(c, "key"), c.Param("offset"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CountBits(c echo.Context) error {
+	response, err := h.RedisUsecase.BitCount(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetBitPosition(c echo.Context) error {
+	response, err := h.RedisUsecase.BitPos(params.PathParam(c, "key"), c.Param("bit"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) OperateOnBits(c echo.Context) error {
+	response, err := h.RedisUsecase.BitOp(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) OperateOnBitFields(c echo.Context) error {
+	response, err := h.RedisUsecase.BitField(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) ReserveBloom(c echo.Context) error {
+	response, err := h.RedisUsecase.BFReserve(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AddToBloom(c echo.Context) error {
+	response, err := h.RedisUsecase.BFAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CheckBloom(c echo.Context) error {
+	response, err := h.RedisUsecase.BFExists(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) ReserveCuckoo(c echo.Context) error {
+	response, err := h.RedisUsecase.CFReserve(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AddToCuckoo(c echo.Context) error {
+	response, err := h.RedisUsecase.CFAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CheckCuckoo(c echo.Context) error {
+	response, err := h.RedisUsecase.CFExists(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveFromCuckoo(c echo.Context) error {
+	response, err := h.RedisUsecase.CFDel(params.PathParam(c, "key"), params.PathParam(c, "item"))
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) AddToGeo(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetGeoPositions(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoPos(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetGeoHashes(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoHash(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetGeoDistance(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoDist(params.PathParam(c, "key"), params.PathParam(c, "member1"),
+		params.PathParam(c, "member2"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SearchGeo(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoSearch(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SearchAndStoreGeo(c echo.Context) error {
+	response, err := h.RedisUsecase.GeoSearchStore(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
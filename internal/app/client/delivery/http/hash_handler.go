@@ -0,0 +1,31 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) RemoveFieldsFromMap(c echo.Context) error {
+	response, err := h.RedisUsecase.HDel(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SetFieldInMapIfNotExists(c echo.Context) error {
+	response, err := h.RedisUsecase.HSetNX(params.PathParam(c, "key"), params.PathParam(c, "field"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IncrementFieldInMap(c echo.Context) error {
+	response, err := h.RedisUsecase.HIncrBy(params.PathParam(c, "key"), params.PathParam(c, "field"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetMap(c echo.Context) error {
+	response, err := h.RedisUsecase.HGetAll(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) FieldExistsInMap(c echo.Context) error {
+	response, err := h.RedisUsecase.HExists(params.PathParam(c, "key"), params.PathParam(c, "field"))
+	return returnServerResponse(c, response, err)
+}
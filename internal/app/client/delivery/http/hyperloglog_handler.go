@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) AddToHyperLogLog(c echo.Context) error {
+	response, err := h.RedisUsecase.PFAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CountUnique(c echo.Context) error {
+	response, err := h.RedisUsecase.PFCount(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CountUniqueInKeys(c echo.Context) error {
+	response, err := h.RedisUsecase.PFCountKeys(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) MergeHyperLogLogs(c echo.Context) error {
+	response, err := h.RedisUsecase.PFMerge(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
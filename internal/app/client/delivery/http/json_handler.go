@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) SetJSON(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONSet(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetJSON(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONGet(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) DeleteJSON(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONDel(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetJSONTypes(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONType(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IncrementJSONNumber(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONNumIncrBy(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AppendToJSONArray(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONArrAppend(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetJSONKeys(c echo.Context) error {
+	response, err := h.RedisUsecase.JSONObjKeys(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) PushToListTail(c echo.Context) error {
+	response, err := h.RedisUsecase.RPush(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PushToExistingList(c echo.Context) error {
+	response, err := h.RedisUsecase.LPushX(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PushToExistingListTail(c echo.Context) error {
+	response, err := h.RedisUsecase.RPushX(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PopFromList(c echo.Context) error {
+	response, err := h.RedisUsecase.LPop(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PopFromListTail(c echo.Context) error {
+	response, err := h.RedisUsecase.RPop(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) InsertToList(c echo.Context) error {
+	response, err := h.RedisUsecase.LInsert(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveFromList(c echo.Context) error {
+	response, err := h.RedisUsecase.LRem(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) TrimList(c echo.Context) error {
+	response, err := h.RedisUsecase.LTrim(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) MoveBetweenLists(c echo.Context) error {
+	response, err := h.RedisUsecase.LMove(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+// BlockingPopFromList is held until the server answers, the request context cancels the long poll
+// when the consumer disconnects
+func (h *CacheHandler) BlockingPopFromList(c echo.Context) error {
+	response, err := h.RedisUsecase.BLPop(c.Request().Context(), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) BlockingPopFromListTail(c echo.Context) error {
+	response, err := h.RedisUsecase.BRPop(c.Request().Context(), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) BlockingMoveBetweenLists(c echo.Context) error {
+	response, err := h.RedisUsecase.BLMove(c.Request().Context(), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetListRange(c echo.Context) error {
+	response, err := h.RedisUsecase.LRange(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetListLength(c echo.Context) error {
+	response, err := h.RedisUsecase.LLen(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetListPositions(c echo.Context) error {
+	response, err := h.RedisUsecase.LPos(params.PathParam(c, "key"), params.PathParam(c, "element"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
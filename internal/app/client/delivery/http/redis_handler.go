@@ -2,8 +2,11 @@ package http
 
 import (
 	"github.com/babon21/redis-impl/internal/app/client/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
 	"github.com/labstack/echo"
+	"io"
 	"net/http"
+	"strconv"
 )
 
 // ResponseError represent the response error struct
@@ -17,9 +20,9 @@ type CacheHandler struct {
 }
 
 func (h *CacheHandler) GetString(c echo.Context) error {
-	key := c.Param("key")
+	key := params.PathParam(c, "key")
 
-	response, err := h.RedisUsecase.Get(key)
+	response, err := h.RedisUsecase.Get(key, c.QueryParams())
 	return returnServerResponse(c, response, err)
 }
 
@@ -33,6 +36,11 @@ func (h *CacheHandler) GetValueByFieldInMap(c echo.Context) error {
 	return returnServerResponse(c, response, err)
 }
 
+func (h *CacheHandler) GetValueByFieldInMapByPath(c echo.Context) error {
+	response, err := h.RedisUsecase.HGetByField(params.PathParam(c, "key"), params.PathParam(c, "field"))
+	return returnServerResponse(c, response, err)
+}
+
 func (h *CacheHandler) SetFieldAndValueInMap(c echo.Context) error {
 	response, err := h.RedisUsecase.HSet(c.Request().Body)
 	return returnServerResponse(c, response, err)
@@ -43,6 +51,16 @@ func (h *CacheHandler) GetFromList(c echo.Context) error {
 	return returnServerResponse(c, response, err)
 }
 
+func (h *CacheHandler) GetFromListByPath(c echo.Context) error {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "index must be an integer"}, "  ")
+	}
+
+	response, err := h.RedisUsecase.LGetByIndex(params.PathParam(c, "key"), index)
+	return returnServerResponse(c, response, err)
+}
+
 func (h *CacheHandler) PushToList(c echo.Context) error {
 	response, err := h.RedisUsecase.LPush(c.Request().Body)
 	return returnServerResponse(c, response, err)
@@ -54,7 +72,7 @@ func (h *CacheHandler) SetValueInList(c echo.Context) error {
 }
 
 func (h *CacheHandler) Delete(c echo.Context) error {
-	key := c.Param("key")
+	key := params.PathParam(c, "key")
 	response, err := h.RedisUsecase.Del(key)
 	return returnServerResponse(c, response, err)
 }
@@ -65,10 +83,37 @@ func (h *CacheHandler) ExpireKey(c echo.Context) error {
 }
 
 func (h *CacheHandler) GetKeys(c echo.Context) error {
+	// requests without a body use the query form, the pattern is empty if it's omitted
+	if c.Request().ContentLength == 0 {
+		response, err := h.RedisUsecase.KeysByPattern(c.QueryParam("pattern"))
+		return returnServerResponse(c, response, err)
+	}
+
 	response, err := h.RedisUsecase.Keys(c.Request().Body)
 	return returnServerResponse(c, response, err)
 }
 
+func (h *CacheHandler) ExecuteCommand(c echo.Context) error {
+	response, err := h.RedisUsecase.Command(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) ExecuteBatch(c echo.Context) error {
+	response, err := h.RedisUsecase.Batch(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+// ForwardV2 proxies the v2 API as is, the server is responsible for routing and validation
+func (h *CacheHandler) ForwardV2(c echo.Context) error {
+	var body io.Reader
+	if c.Request().ContentLength != 0 {
+		body = c.Request().Body
+	}
+
+	response, err := h.RedisUsecase.Forward(c.Request().Method, c.Request().URL.RequestURI(), body)
+	return returnServerResponse(c, response, err)
+}
+
 // NewCacheHandler will initialize the cache/ resources endpoint
 func NewCacheHandler(e *echo.Echo, us usecase.RedisUsecase) {
 	handler := &CacheHandler{
@@ -77,17 +122,134 @@ func NewCacheHandler(e *echo.Echo, us usecase.RedisUsecase) {
 
 	e.GET("/cache/string/:key", handler.GetString)
 	e.PUT("/cache/string", handler.SetString)
+	e.POST("/cache/string/:key/getex", handler.GetStringWithExpiration)
+	e.POST("/cache/string/:key/getdel", handler.GetAndDeleteString)
+	e.POST("/cache/strings/mget", handler.GetStrings)
+	e.POST("/cache/strings/mset", handler.SetStrings)
+	e.POST("/cache/strings/msetnx", handler.SetStringsIfNotExist)
+	e.POST("/cache/string/:key/incr", handler.IncrementString)
+	e.POST("/cache/string/:key/incrbyfloat", handler.IncrementStringByFloat)
+	e.POST("/cache/string/:key/append", handler.AppendToString)
+	e.POST("/cache/string/:key/setrange", handler.SetStringRange)
+	e.GET("/cache/string/:key/len", handler.GetStringLength)
+	e.GET("/cache/string/:key/range", handler.GetStringRange)
+
+	e.POST("/cache/bitmap/setbit", handler.SetBit)
+	e.GET("/cache/bitmap/:key/bit/:offset", handler.GetBit)
+	e.GET("/cache/bitmap/:key/count", handler.CountBits)
+	e.GET("/cache/bitmap/:key/pos/:bit", handler.GetBitPosition)
+	e.POST("/cache/bitmap/op", handler.OperateOnBits)
+	e.POST("/cache/bitmap/field", handler.OperateOnBitFields)
 
 	e.GET("/cache/map", handler.GetValueByFieldInMap)
+	e.GET("/cache/map/:key/:field", handler.GetValueByFieldInMapByPath)
 	e.PUT("/cache/map", handler.SetFieldAndValueInMap)
+	e.POST("/cache/map/:key/fields/remove", handler.RemoveFieldsFromMap)
+	e.POST("/cache/map/:key/:field/setnx", handler.SetFieldInMapIfNotExists)
+	e.POST("/cache/map/:key/:field/incr", handler.IncrementFieldInMap)
+	e.GET("/cache/map/:key", handler.GetMap)
+	e.GET("/cache/map/:key/:field/exists", handler.FieldExistsInMap)
 
 	e.GET("/cache/list", handler.GetFromList)
+	e.GET("/cache/list/:key/:index", handler.GetFromListByPath)
 	e.POST("/cache/list", handler.PushToList)
 	e.PATCH("/cache/list", handler.SetValueInList)
+	e.POST("/cache/list/rpush", handler.PushToListTail)
+	e.POST("/cache/list/lpushx", handler.PushToExistingList)
+	e.POST("/cache/list/rpushx", handler.PushToExistingListTail)
+	e.POST("/cache/list/lpop", handler.PopFromList)
+	e.POST("/cache/list/rpop", handler.PopFromListTail)
+	e.POST("/cache/list/insert", handler.InsertToList)
+	e.POST("/cache/list/remove", handler.RemoveFromList)
+	e.POST("/cache/list/trim", handler.TrimList)
+	e.POST("/cache/list/move", handler.MoveBetweenLists)
+	e.POST("/cache/list/blpop", handler.BlockingPopFromList)
+	e.POST("/cache/list/brpop", handler.BlockingPopFromListTail)
+	e.POST("/cache/list/blmove", handler.BlockingMoveBetweenLists)
+	e.GET("/cache/list/:key/range", handler.GetListRange)
+	e.GET("/cache/list/:key/len", handler.GetListLength)
+	e.GET("/cache/list/:key/pos/:element", handler.GetListPositions)
+
+	e.POST("/cache/set", handler.AddToSet)
+	e.POST("/cache/set/remove", handler.RemoveFromSet)
+	e.POST("/cache/set/pop", handler.PopFromSet)
+	e.POST("/cache/set/inter", handler.IntersectSets)
+	e.POST("/cache/set/union", handler.UnionSets)
+	e.POST("/cache/set/diff", handler.DiffSets)
+	e.GET("/cache/set/:key/members", handler.GetSetMembers)
+	e.GET("/cache/set/:key/card", handler.GetSetCardinality)
+	e.GET("/cache/set/:key/random", handler.GetRandomSetMembers)
+	e.GET("/cache/set/:key/members/:member", handler.IsSetMember)
+
+	e.POST("/cache/zset", handler.AddToSortedSet)
+	e.POST("/cache/zset/remove", handler.RemoveFromSortedSet)
+	e.POST("/cache/zset/incr", handler.IncrementScore)
+	e.POST("/cache/zset/popmin", handler.PopMinFromSortedSet)
+	e.POST("/cache/zset/popmax", handler.PopMaxFromSortedSet)
+	e.POST("/cache/zset/union", handler.UnionSortedSets)
+	e.POST("/cache/zset/inter", handler.IntersectSortedSets)
+	e.GET("/cache/zset/:key/card", handler.GetSortedSetCardinality)
+	e.GET("/cache/zset/:key/count", handler.CountInSortedSet)
+	e.GET("/cache/zset/:key/range", handler.GetSortedSetRange)
+	e.GET("/cache/zset/:key/score/:member", handler.GetScore)
+	e.GET("/cache/zset/:key/rank/:member", handler.GetRank)
+
+	e.POST("/cache/hll", handler.AddToHyperLogLog)
+	e.POST("/cache/hll/count", handler.CountUniqueInKeys)
+	e.POST("/cache/hll/merge", handler.MergeHyperLogLogs)
+	e.GET("/cache/hll/:key/count", handler.CountUnique)
+	e.POST("/cache/stream", handler.AddToStream)
+	e.GET("/cache/stream/:key/range", handler.GetStreamRange)
+	e.GET("/cache/stream/:key/len", handler.GetStreamLength)
+	e.POST("/cache/stream/remove", handler.RemoveFromStream)
+	e.POST("/cache/stream/trim", handler.TrimStream)
+	e.POST("/cache/stream/read", handler.ReadStreams)
+	e.POST("/cache/stream/group", handler.CreateGroup)
+	e.PATCH("/cache/stream/group", handler.SetGroupID)
+	e.DELETE("/cache/stream/:key/group/:group", handler.DestroyGroup)
+	e.POST("/cache/stream/group/consumer", handler.CreateConsumer)
+	e.DELETE("/cache/stream/:key/group/:group/consumer/:consumer", handler.RemoveConsumer)
+	e.POST("/cache/stream/readgroup", handler.ReadGroup)
+	e.POST("/cache/stream/ack", handler.Acknowledge)
+	e.GET("/cache/stream/:key/group/:group/pending", handler.GetPending)
+	e.POST("/cache/stream/claim", handler.Claim)
+	e.POST("/cache/stream/autoclaim", handler.AutoClaim)
+	e.POST("/cache/geo", handler.AddToGeo)
+	e.POST("/cache/geo/pos", handler.GetGeoPositions)
+	e.POST("/cache/geo/hash", handler.GetGeoHashes)
+	e.POST("/cache/geo/search", handler.SearchGeo)
+	e.POST("/cache/geo/searchstore", handler.SearchAndStoreGeo)
+	e.GET("/cache/geo/:key/dist/:member1/:member2", handler.GetGeoDistance)
+	e.PUT("/cache/json", handler.SetJSON)
+	e.POST("/cache/json/numincrby", handler.IncrementJSONNumber)
+	e.POST("/cache/json/arrappend", handler.AppendToJSONArray)
+	e.GET("/cache/json/:key/value", handler.GetJSON)
+	e.DELETE("/cache/json/:key/value", handler.DeleteJSON)
+	e.GET("/cache/json/:key/type", handler.GetJSONTypes)
+	e.GET("/cache/json/:key/objkeys", handler.GetJSONKeys)
+	e.PUT("/cache/bloom", handler.ReserveBloom)
+	e.POST("/cache/bloom/add", handler.AddToBloom)
+	e.POST("/cache/bloom/exists", handler.CheckBloom)
+	e.PUT("/cache/cuckoo", handler.ReserveCuckoo)
+	e.POST("/cache/cuckoo/add", handler.AddToCuckoo)
+	e.POST("/cache/cuckoo/exists", handler.CheckCuckoo)
+	e.DELETE("/cache/cuckoo/:key/items/:item", handler.RemoveFromCuckoo)
+	e.PUT("/cache/ts", handler.CreateTimeSeries)
+	e.POST("/cache/ts/add", handler.AddSample)
+	e.POST("/cache/ts/madd", handler.AddSamples)
+	e.POST("/cache/ts/mrange", handler.GetSeriesRange)
+	e.POST("/cache/ts/rules", handler.CreateRule)
+	e.GET("/cache/ts/:key/range", handler.GetSampleRange)
+	e.DELETE("/cache/ts/:key/rules/:destination", handler.DeleteRule)
 
 	e.DELETE("/cache/keys/:key", handler.Delete)
 	e.PATCH("/cache/keys/expire", handler.ExpireKey)
 	e.GET("/cache/keys", handler.GetKeys)
+
+	e.POST("/cache/command", handler.ExecuteCommand)
+	e.POST("/cache/batch", handler.ExecuteBatch)
+
+	e.Any("/v2/*", handler.ForwardV2)
 }
 
 func returnServerResponse(c echo.Context, response *http.Response, err error) error {
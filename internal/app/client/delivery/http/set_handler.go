@@ -0,0 +1,56 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) AddToSet(c echo.Context) error {
+	response, err := h.RedisUsecase.SAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveFromSet(c echo.Context) error {
+	response, err := h.RedisUsecase.SRem(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PopFromSet(c echo.Context) error {
+	response, err := h.RedisUsecase.SPop(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IntersectSets(c echo.Context) error {
+	response, err := h.RedisUsecase.SInter(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) UnionSets(c echo.Context) error {
+	response, err := h.RedisUsecase.SUnion(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) DiffSets(c echo.Context) error {
+	response, err := h.RedisUsecase.SDiff(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSetMembers(c echo.Context) error {
+	response, err := h.RedisUsecase.SMembers(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSetCardinality(c echo.Context) error {
+	response, err := h.RedisUsecase.SCard(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetRandomSetMembers(c echo.Context) error {
+	response, err := h.RedisUsecase.SRandMember(params.PathParam(c, "key"), c.QueryParam("count"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IsSetMember(c echo.Context) error {
+	response, err := h.RedisUsecase.SIsMember(params.PathParam(c, "key"), params.PathParam(c, "member"))
+	return returnServerResponse(c, response, err)
+}
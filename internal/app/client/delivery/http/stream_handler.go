@@ -0,0 +1,87 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) AddToStream(c echo.Context) error {
+	response, err := h.RedisUsecase.XAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStreamRange(c echo.Context) error {
+	response, err := h.RedisUsecase.XRange(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStreamLength(c echo.Context) error {
+	response, err := h.RedisUsecase.XLen(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveFromStream(c echo.Context) error {
+	response, err := h.RedisUsecase.XDel(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) TrimStream(c echo.Context) error {
+	response, err := h.RedisUsecase.XTrim(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) ReadStreams(c echo.Context) error {
+	response, err := h.RedisUsecase.XRead(c.Request().Context(), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CreateGroup(c echo.Context) error {
+	response, err := h.RedisUsecase.XGroupCreate(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SetGroupID(c echo.Context) error {
+	response, err := h.RedisUsecase.XGroupSetID(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) DestroyGroup(c echo.Context) error {
+	response, err := h.RedisUsecase.XGroupDestroy(params.PathParam(c, "key"), params.PathParam(c, "group"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CreateConsumer(c echo.Context) error {
+	response, err := h.RedisUsecase.XGroupCreateConsumer(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveConsumer(c echo.Context) error {
+	response, err := h.RedisUsecase.XGroupDelConsumer(params.PathParam(c, "key"), params.PathParam(c, "group"),
+		params.PathParam(c, "consumer"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) ReadGroup(c echo.Context) error {
+	response, err := h.RedisUsecase.XReadGroup(c.Request().Context(), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) Acknowledge(c echo.Context) error {
+	response, err := h.RedisUsecase.XAck(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetPending(c echo.Context) error {
+	response, err := h.RedisUsecase.XPending(params.PathParam(c, "key"), params.PathParam(c, "group"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) Claim(c echo.Context) error {
+	response, err := h.RedisUsecase.XClaim(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AutoClaim(c echo.Context) error {
+	response, err := h.RedisUsecase.XAutoClaim(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) IncrementString(c echo.Context) error {
+	response, err := h.RedisUsecase.IncrBy(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IncrementStringByFloat(c echo.Context) error {
+	response, err := h.RedisUsecase.IncrByFloat(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AppendToString(c echo.Context) error {
+	response, err := h.RedisUsecase.Append(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SetStringRange(c echo.Context) error {
+	response, err := h.RedisUsecase.SetRange(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStringLength(c echo.Context) error {
+	response, err := h.RedisUsecase.StrLen(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStringRange(c echo.Context) error {
+	response, err := h.RedisUsecase.GetRange(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStringWithExpiration(c echo.Context) error {
+	response, err := h.RedisUsecase.GetEx(params.PathParam(c, "key"), c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetAndDeleteString(c echo.Context) error {
+	response, err := h.RedisUsecase.GetDel(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetStrings(c echo.Context) error {
+	response, err := h.RedisUsecase.MGet(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SetStrings(c echo.Context) error {
+	response, err := h.RedisUsecase.MSet(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) SetStringsIfNotExist(c echo.Context) error {
+	response, err := h.RedisUsecase.MSetNX(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) CreateTimeSeries(c echo.Context) error {
+	response, err := h.RedisUsecase.TSCreate(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AddSample(c echo.Context) error {
+	response, err := h.RedisUsecase.TSAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) AddSamples(c echo.Context) error {
+	response, err := h.RedisUsecase.TSMAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSampleRange(c echo.Context) error {
+	response, err := h.RedisUsecase.TSRange(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSeriesRange(c echo.Context) error {
+	response, err := h.RedisUsecase.TSMRange(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CreateRule(c echo.Context) error {
+	response, err := h.RedisUsecase.TSCreateRule(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) DeleteRule(c echo.Context) error {
+	response, err := h.RedisUsecase.TSDeleteRule(params.PathParam(c, "key"), params.PathParam(c, "destination"))
+	return returnServerResponse(c, response, err)
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/labstack/echo"
+)
+
+func (h *CacheHandler) AddToSortedSet(c echo.Context) error {
+	response, err := h.RedisUsecase.ZAdd(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) RemoveFromSortedSet(c echo.Context) error {
+	response, err := h.RedisUsecase.ZRem(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IncrementScore(c echo.Context) error {
+	response, err := h.RedisUsecase.ZIncrBy(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PopMinFromSortedSet(c echo.Context) error {
+	response, err := h.RedisUsecase.ZPopMin(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) PopMaxFromSortedSet(c echo.Context) error {
+	response, err := h.RedisUsecase.ZPopMax(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) UnionSortedSets(c echo.Context) error {
+	response, err := h.RedisUsecase.ZUnionStore(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) IntersectSortedSets(c echo.Context) error {
+	response, err := h.RedisUsecase.ZInterStore(c.Request().Body)
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSortedSetCardinality(c echo.Context) error {
+	response, err := h.RedisUsecase.ZCard(params.PathParam(c, "key"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) CountInSortedSet(c echo.Context) error {
+	response, err := h.RedisUsecase.ZCount(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetSortedSetRange(c echo.Context) error {
+	response, err := h.RedisUsecase.ZRange(params.PathParam(c, "key"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetScore(c echo.Context) error {
+	response, err := h.RedisUsecase.ZScore(params.PathParam(c, "key"), params.PathParam(c, "member"))
+	return returnServerResponse(c, response, err)
+}
+
+func (h *CacheHandler) GetRank(c echo.Context) error {
+	response, err := h.RedisUsecase.ZRank(params.PathParam(c, "key"), params.PathParam(c, "member"), c.QueryParams())
+	return returnServerResponse(c, response, err)
+}
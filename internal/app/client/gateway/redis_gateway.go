@@ -1,115 +1,545 @@
 package gateway
 
 import (
+	"context"
 	"github.com/babon21/redis-impl/internal/app/client/usecase"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type RedisGatewayImpl struct {
 	redisServerUrl string
+	client         *http.Client
 }
 
-func NewRedisGateway(redisServerUrl string) usecase.RedisGateway {
-	return &RedisGatewayImpl{redisServerUrl: redisServerUrl}
+func NewRedisGateway(redisServerUrl string, client *http.Client) usecase.RedisGateway {
+	return &RedisGatewayImpl{redisServerUrl: redisServerUrl, client: client}
 }
 
 func (r *RedisGatewayImpl) Set(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/string"
-	request, err := http.NewRequest(http.MethodPut, url, body)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/json")
-
-	return http.DefaultClient.Do(request)
+	return r.sendJSON(http.MethodPut, "/cache/string", body)
 }
 
-func (r *RedisGatewayImpl) Get(key string) (*http.Response, error) {
-	return http.Get(r.redisServerUrl + "/cache/string/" + key)
+func (r *RedisGatewayImpl) Get(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/string/"+url.PathEscape(key)+"?"+query.Encode(), nil)
 }
 
 func (r *RedisGatewayImpl) Del(key string) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/keys/" + key
-	request, err := http.NewRequest(http.MethodDelete, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return http.DefaultClient.Do(request)
+	return r.send(http.MethodDelete, "/cache/keys/"+url.PathEscape(key), nil)
 }
 
 func (r *RedisGatewayImpl) Keys(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/keys"
-	request, err := http.NewRequest(http.MethodGet, url, body)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/json")
-	return http.DefaultClient.Do(request)
+	return r.sendJSON(http.MethodGet, "/cache/keys", body)
+}
+
+func (r *RedisGatewayImpl) KeysByPattern(pattern string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/keys?pattern="+url.QueryEscape(pattern), nil)
 }
 
 func (r *RedisGatewayImpl) HGet(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/map"
-	request, err := http.NewRequest(http.MethodGet, url, body)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/json")
-	return http.DefaultClient.Do(request)
+	return r.sendJSON(http.MethodGet, "/cache/map", body)
 }
 
-func (r *RedisGatewayImpl) HSet(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/map"
-	request, err := http.NewRequest(http.MethodPut, url, body)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/json")
+func (r *RedisGatewayImpl) HGetByField(key string, field string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/map/"+url.PathEscape(key)+"/"+url.PathEscape(field), nil)
+}
 
-	return http.DefaultClient.Do(request)
+func (r *RedisGatewayImpl) HSet(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPut, "/cache/map", body)
 }
 
 func (r *RedisGatewayImpl) LGet(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/list"
-	request, err := http.NewRequest(http.MethodGet, url, body)
-	if err != nil {
-		return nil, err
-	}
-	request.Header.Set("Content-Type", "application/json")
+	return r.sendJSON(http.MethodGet, "/cache/list", body)
+}
 
-	return http.DefaultClient.Do(request)
+func (r *RedisGatewayImpl) LGetByIndex(key string, index int) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/list/"+url.PathEscape(key)+"/"+strconv.Itoa(index), nil)
 }
 
 func (r *RedisGatewayImpl) LSet(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/list"
-	request, err := http.NewRequest(http.MethodPatch, url, body)
+	return r.sendJSON(http.MethodPatch, "/cache/list", body)
+}
+
+func (r *RedisGatewayImpl) LPush(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list", body)
+}
+
+func (r *RedisGatewayImpl) Expire(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPatch, "/cache/keys/expire", body)
+}
+
+func (r *RedisGatewayImpl) Command(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/command", body)
+}
+
+func (r *RedisGatewayImpl) Batch(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/batch", body)
+}
+
+func (r *RedisGatewayImpl) SAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set", body)
+}
+
+func (r *RedisGatewayImpl) SRem(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set/remove", body)
+}
+
+func (r *RedisGatewayImpl) SPop(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set/pop", body)
+}
+
+func (r *RedisGatewayImpl) SInter(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set/inter", body)
+}
+
+func (r *RedisGatewayImpl) SUnion(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set/union", body)
+}
+
+func (r *RedisGatewayImpl) SDiff(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/set/diff", body)
+}
+
+func (r *RedisGatewayImpl) SMembers(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/set/"+url.PathEscape(key)+"/members", nil)
+}
+
+func (r *RedisGatewayImpl) SCard(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/set/"+url.PathEscape(key)+"/card", nil)
+}
+
+func (r *RedisGatewayImpl) SRandMember(key string, count string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/set/"+url.PathEscape(key)+"/random?count="+url.QueryEscape(count), nil)
+}
+
+func (r *RedisGatewayImpl) SIsMember(key string, member string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/set/"+url.PathEscape(key)+"/members/"+url.PathEscape(member), nil)
+}
+
+func (r *RedisGatewayImpl) ZAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset", body)
+}
+
+func (r *RedisGatewayImpl) ZRem(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/remove", body)
+}
+
+func (r *RedisGatewayImpl) ZIncrBy(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/incr", body)
+}
+
+func (r *RedisGatewayImpl) ZPopMin(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/popmin", body)
+}
+
+func (r *RedisGatewayImpl) ZPopMax(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/popmax", body)
+}
+
+func (r *RedisGatewayImpl) ZUnionStore(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/union", body)
+}
+
+func (r *RedisGatewayImpl) ZInterStore(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/zset/inter", body)
+}
+
+func (r *RedisGatewayImpl) ZCard(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/zset/"+url.PathEscape(key)+"/card", nil)
+}
+
+func (r *RedisGatewayImpl) ZCount(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/zset/"+url.PathEscape(key)+"/count?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) ZRange(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/zset/"+url.PathEscape(key)+"/range?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) ZScore(key string, member string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/zset/"+url.PathEscape(key)+"/score/"+url.PathEscape(member), nil)
+}
+
+func (r *RedisGatewayImpl) ZRank(key string, member string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/zset/"+url.PathEscape(key)+"/rank/"+url.PathEscape(member)+"?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) RPush(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/rpush", body)
+}
+
+func (r *RedisGatewayImpl) LPushX(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/lpushx", body)
+}
+
+func (r *RedisGatewayImpl) RPushX(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/rpushx", body)
+}
+
+func (r *RedisGatewayImpl) LPop(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/lpop", body)
+}
+
+func (r *RedisGatewayImpl) RPop(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/rpop", body)
+}
+
+func (r *RedisGatewayImpl) LInsert(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/insert", body)
+}
+
+func (r *RedisGatewayImpl) LRem(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/remove", body)
+}
+
+func (r *RedisGatewayImpl) LTrim(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/trim", body)
+}
+
+func (r *RedisGatewayImpl) LMove(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/list/move", body)
+}
+
+func (r *RedisGatewayImpl) LRange(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/list/"+url.PathEscape(key)+"/range?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) LLen(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/list/"+url.PathEscape(key)+"/len", nil)
+}
+
+func (r *RedisGatewayImpl) LPos(key string, element string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/list/"+url.PathEscape(key)+"/pos/"+url.PathEscape(element)+"?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) BLPop(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.sendLongPoll(ctx, "/cache/list/blpop", body)
+}
+
+func (r *RedisGatewayImpl) BRPop(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.sendLongPoll(ctx, "/cache/list/brpop", body)
+}
+
+func (r *RedisGatewayImpl) BLMove(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.sendLongPoll(ctx, "/cache/list/blmove", body)
+}
+
+func (r *RedisGatewayImpl) HDel(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/map/"+url.PathEscape(key)+"/fields/remove", body)
+}
+
+func (r *RedisGatewayImpl) HSetNX(key string, field string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/map/"+url.PathEscape(key)+"/"+url.PathEscape(field)+"/setnx", body)
+}
+
+func (r *RedisGatewayImpl) HIncrBy(key string, field string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/map/"+url.PathEscape(key)+"/"+url.PathEscape(field)+"/incr", body)
+}
+
+func (r *RedisGatewayImpl) HGetAll(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/map/"+url.PathEscape(key)+"?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) HExists(key string, field string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/map/"+url.PathEscape(key)+"/"+url.PathEscape(field)+"/exists", nil)
+}
+
+func (r *RedisGatewayImpl) IncrBy(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/incr", body)
+}
+
+func (r *RedisGatewayImpl) IncrByFloat(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/incrbyfloat", body)
+}
+
+func (r *RedisGatewayImpl) Append(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/append", body)
+}
+
+func (r *RedisGatewayImpl) SetRange(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/setrange", body)
+}
+
+func (r *RedisGatewayImpl) StrLen(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/string/"+url.PathEscape(key)+"/len", nil)
+}
+
+func (r *RedisGatewayImpl) GetRange(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/string/"+url.PathEscape(key)+"/range?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) GetEx(key string, body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/getex", body)
+}
+
+func (r *RedisGatewayImpl) GetDel(key string) (*http.Response, error) {
+	return r.send(http.MethodPost, "/cache/string/"+url.PathEscape(key)+"/getdel", nil)
+}
+
+func (r *RedisGatewayImpl) MGet(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/strings/mget", body)
+}
+
+func (r *RedisGatewayImpl) MSet(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/strings/mset", body)
+}
+
+func (r *RedisGatewayImpl) MSetNX(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/strings/msetnx", body)
+}
+
+func (r *RedisGatewayImpl) SetBit(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/bitmap/setbit", body)
+}
+
+func (r *RedisGatewayImpl) GetBit(key string, offset string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/bitmap/"+url.PathEscape(key)+"/bit/"+url.PathEscape(offset), nil)
+}
+
+func (r *RedisGatewayImpl) BitCount(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/bitmap/"+url.PathEscape(key)+"/count?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) BitPos(key string, bit string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/bitmap/"+url.PathEscape(key)+"/pos/"+url.PathEscape(bit)+"?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) BitOp(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/bitmap/op", body)
+}
+
+func (r *RedisGatewayImpl) BitField(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/bitmap/field", body)
+}
+
+func (r *RedisGatewayImpl) PFAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/hll", body)
+}
+
+func (r *RedisGatewayImpl) PFCount(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/hll/"+url.PathEscape(key)+"/count", nil)
+}
+
+func (r *RedisGatewayImpl) PFCountKeys(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/hll/count", body)
+}
+
+func (r *RedisGatewayImpl) PFMerge(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/hll/merge", body)
+}
+
+func (r *RedisGatewayImpl) XAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream", body)
+}
+
+func (r *RedisGatewayImpl) XRange(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/stream/"+url.PathEscape(key)+"/range?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) XLen(key string) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/stream/"+url.PathEscape(key)+"/len", nil)
+}
+
+func (r *RedisGatewayImpl) XDel(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/remove", body)
+}
+
+func (r *RedisGatewayImpl) XTrim(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/trim", body)
+}
+
+func (r *RedisGatewayImpl) XRead(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.sendLongPoll(ctx, "/cache/stream/read", body)
+}
+
+func (r *RedisGatewayImpl) XGroupCreate(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/group", body)
+}
+
+func (r *RedisGatewayImpl) XGroupSetID(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPatch, "/cache/stream/group", body)
+}
+
+func (r *RedisGatewayImpl) XGroupDestroy(key string, group string) (*http.Response, error) {
+	return r.send(http.MethodDelete, "/cache/stream/"+url.PathEscape(key)+"/group/"+url.PathEscape(group), nil)
+}
+
+func (r *RedisGatewayImpl) XGroupCreateConsumer(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/group/consumer", body)
+}
+
+func (r *RedisGatewayImpl) XGroupDelConsumer(key string, group string, consumer string) (*http.Response, error) {
+	return r.send(http.MethodDelete, "/cache/stream/"+url.PathEscape(key)+"/group/"+url.PathEscape(group)+"/consumer/"+url.PathEscape(consumer), nil)
+}
+
+func (r *RedisGatewayImpl) XReadGroup(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.sendLongPoll(ctx, "/cache/stream/readgroup", body)
+}
+
+func (r *RedisGatewayImpl) XAck(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/ack", body)
+}
+
+func (r *RedisGatewayImpl) XPending(key string, group string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/stream/"+url.PathEscape(key)+"/group/"+url.PathEscape(group)+"/pending?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) XClaim(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/claim", body)
+}
+
+func (r *RedisGatewayImpl) XAutoClaim(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/stream/autoclaim", body)
+}
+
+func (r *RedisGatewayImpl) GeoAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/geo", body)
+}
+
+func (r *RedisGatewayImpl) GeoPos(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/geo/pos", body)
+}
+
+func (r *RedisGatewayImpl) GeoHash(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/geo/hash", body)
+}
+
+func (r *RedisGatewayImpl) GeoDist(key string, member1 string, member2 string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/geo/"+url.PathEscape(key)+"/dist/"+url.PathEscape(member1)+"/"+url.PathEscape(member2)+"?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) GeoSearch(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/geo/search", body)
+}
+
+func (r *RedisGatewayImpl) GeoSearchStore(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/geo/searchstore", body)
+}
+
+func (r *RedisGatewayImpl) JSONSet(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPut, "/cache/json", body)
+}
+
+func (r *RedisGatewayImpl) JSONGet(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/json/"+url.PathEscape(key)+"/value?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) JSONDel(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodDelete, "/cache/json/"+url.PathEscape(key)+"/value?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) JSONType(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/json/"+url.PathEscape(key)+"/type?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) JSONNumIncrBy(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/json/numincrby", body)
+}
+
+func (r *RedisGatewayImpl) JSONArrAppend(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/json/arrappend", body)
+}
+
+func (r *RedisGatewayImpl) JSONObjKeys(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/json/"+url.PathEscape(key)+"/objkeys?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) BFReserve(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPut, "/cache/bloom", body)
+}
+
+func (r *RedisGatewayImpl) BFAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/bloom/add", body)
+}
+
+func (r *RedisGatewayImpl) BFExists(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/bloom/exists", body)
+}
+
+func (r *RedisGatewayImpl) CFReserve(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPut, "/cache/cuckoo", body)
+}
+
+func (r *RedisGatewayImpl) CFAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/cuckoo/add", body)
+}
+
+func (r *RedisGatewayImpl) CFExists(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/cuckoo/exists", body)
+}
+
+func (r *RedisGatewayImpl) CFDel(key string, item string) (*http.Response, error) {
+	return r.send(http.MethodDelete, "/cache/cuckoo/"+url.PathEscape(key)+"/items/"+url.PathEscape(item), nil)
+}
+
+func (r *RedisGatewayImpl) TSCreate(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPut, "/cache/ts", body)
+}
+
+func (r *RedisGatewayImpl) TSAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/ts/add", body)
+}
+
+func (r *RedisGatewayImpl) TSMAdd(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/ts/madd", body)
+}
+
+func (r *RedisGatewayImpl) TSRange(key string, query url.Values) (*http.Response, error) {
+	return r.send(http.MethodGet, "/cache/ts/"+url.PathEscape(key)+"/range?"+query.Encode(), nil)
+}
+
+func (r *RedisGatewayImpl) TSMRange(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/ts/mrange", body)
+}
+
+func (r *RedisGatewayImpl) TSCreateRule(body io.Reader) (*http.Response, error) {
+	return r.sendJSON(http.MethodPost, "/cache/ts/rules", body)
+}
+
+func (r *RedisGatewayImpl) TSDeleteRule(key string, destination string) (*http.Response, error) {
+	return r.send(http.MethodDelete, "/cache/ts/"+url.PathEscape(key)+"/rules/"+url.PathEscape(destination), nil)
+}
+
+// Forward sends the request to the same request URI of the server, it's used for the v2 API
+func (r *RedisGatewayImpl) Forward(method string, requestURI string, body io.Reader) (*http.Response, error) {
+	if body == nil {
+		return r.send(method, requestURI, nil)
+	}
+	return r.sendJSON(method, requestURI, body)
+}
+
+func (r *RedisGatewayImpl) send(method string, path string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(method, r.redisServerUrl+path, body)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Set("Content-Type", "application/json")
 
-	return http.DefaultClient.Do(request)
+	return r.client.Do(request)
 }
 
-func (r *RedisGatewayImpl) LPush(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/list"
-	request, err := http.NewRequest(http.MethodPost, url, body)
+func (r *RedisGatewayImpl) sendJSON(method string, path string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequest(method, r.redisServerUrl+path, body)
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
 
-	return http.DefaultClient.Do(request)
+	return r.client.Do(request)
 }
 
-func (r *RedisGatewayImpl) Expire(body io.Reader) (*http.Response, error) {
-	url := r.redisServerUrl + "/cache/keys/expire"
-	request, err := http.NewRequest(http.MethodPatch, url, body)
+// sendLongPoll sends a request the server holds until the blocking command completes. The client timeout
+// is dropped, the request is bounded by the timeout of the command and canceled with ctx
+// when the caller goes away.
+func (r *RedisGatewayImpl) sendLongPoll(ctx context.Context, path string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.redisServerUrl+path, body)
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json")
 
-	return http.DefaultClient.Do(request)
+	client := *r.client
+	client.Timeout = 0
+	return client.Do(request)
 }
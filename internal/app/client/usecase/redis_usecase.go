@@ -1,24 +1,150 @@
 package usecase
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 type RedisUsecase interface {
 	Set(body io.Reader) (*http.Response, error)
-	Get(key string) (*http.Response, error)
+	Get(key string, query url.Values) (*http.Response, error)
+	GetEx(key string, body io.Reader) (*http.Response, error)
+	GetDel(key string) (*http.Response, error)
+	MGet(body io.Reader) (*http.Response, error)
+	MSet(body io.Reader) (*http.Response, error)
+	MSetNX(body io.Reader) (*http.Response, error)
+	IncrBy(key string, body io.Reader) (*http.Response, error)
+	IncrByFloat(key string, body io.Reader) (*http.Response, error)
+	Append(key string, body io.Reader) (*http.Response, error)
+	SetRange(key string, body io.Reader) (*http.Response, error)
+	StrLen(key string) (*http.Response, error)
+	GetRange(key string, query url.Values) (*http.Response, error)
 	Del(key string) (*http.Response, error)
 	Keys(body io.Reader) (*http.Response, error)
+	KeysByPattern(pattern string) (*http.Response, error)
+
+	SetBit(body io.Reader) (*http.Response, error)
+	GetBit(key string, offset string) (*http.Response, error)
+	BitCount(key string, query url.Values) (*http.Response, error)
+	BitPos(key string, bit string, query url.Values) (*http.Response, error)
+	BitOp(body io.Reader) (*http.Response, error)
+	BitField(body io.Reader) (*http.Response, error)
 
 	HGet(body io.Reader) (*http.Response, error)
+	HGetByField(key string, field string) (*http.Response, error)
 	HSet(body io.Reader) (*http.Response, error)
+	HDel(key string, body io.Reader) (*http.Response, error)
+	HSetNX(key string, field string, body io.Reader) (*http.Response, error)
+	HIncrBy(key string, field string, body io.Reader) (*http.Response, error)
+	HGetAll(key string, query url.Values) (*http.Response, error)
+	HExists(key string, field string) (*http.Response, error)
 
 	LGet(body io.Reader) (*http.Response, error)
+	LGetByIndex(key string, index int) (*http.Response, error)
 	LSet(body io.Reader) (*http.Response, error)
 	LPush(body io.Reader) (*http.Response, error)
 
 	Expire(body io.Reader) (*http.Response, error)
+
+	Command(body io.Reader) (*http.Response, error)
+	Batch(body io.Reader) (*http.Response, error)
+
+	Forward(method string, requestURI string, body io.Reader) (*http.Response, error)
+
+	SAdd(body io.Reader) (*http.Response, error)
+	SRem(body io.Reader) (*http.Response, error)
+	SPop(body io.Reader) (*http.Response, error)
+	SInter(body io.Reader) (*http.Response, error)
+	SUnion(body io.Reader) (*http.Response, error)
+	SDiff(body io.Reader) (*http.Response, error)
+	SMembers(key string) (*http.Response, error)
+	SCard(key string) (*http.Response, error)
+	SRandMember(key string, count string) (*http.Response, error)
+	SIsMember(key string, member string) (*http.Response, error)
+
+	ZAdd(body io.Reader) (*http.Response, error)
+	ZRem(body io.Reader) (*http.Response, error)
+	ZIncrBy(body io.Reader) (*http.Response, error)
+	ZPopMin(body io.Reader) (*http.Response, error)
+	ZPopMax(body io.Reader) (*http.Response, error)
+	ZUnionStore(body io.Reader) (*http.Response, error)
+	ZInterStore(body io.Reader) (*http.Response, error)
+	ZCard(key string) (*http.Response, error)
+	ZCount(key string, query url.Values) (*http.Response, error)
+	ZRange(key string, query url.Values) (*http.Response, error)
+	ZScore(key string, member string) (*http.Response, error)
+	ZRank(key string, member string, query url.Values) (*http.Response, error)
+
+	RPush(body io.Reader) (*http.Response, error)
+	LPushX(body io.Reader) (*http.Response, error)
+	RPushX(body io.Reader) (*http.Response, error)
+	LPop(body io.Reader) (*http.Response, error)
+	RPop(body io.Reader) (*http.Response, error)
+	LInsert(body io.Reader) (*http.Response, error)
+	LRem(body io.Reader) (*http.Response, error)
+	LTrim(body io.Reader) (*http.Response, error)
+	LMove(body io.Reader) (*http.Response, error)
+	BLPop(ctx context.Context, body io.Reader) (*http.Response, error)
+	BRPop(ctx context.Context, body io.Reader) (*http.Response, error)
+	BLMove(ctx context.Context, body io.Reader) (*http.Response, error)
+	LRange(key string, query url.Values) (*http.Response, error)
+	LLen(key string) (*http.Response, error)
+	LPos(key string, element string, query url.Values) (*http.Response, error)
+
+	PFAdd(body io.Reader) (*http.Response, error)
+	PFCount(key string) (*http.Response, error)
+	PFCountKeys(body io.Reader) (*http.Response, error)
+	PFMerge(body io.Reader) (*http.Response, error)
+
+	XAdd(body io.Reader) (*http.Response, error)
+	XRange(key string, query url.Values) (*http.Response, error)
+	XLen(key string) (*http.Response, error)
+	XDel(body io.Reader) (*http.Response, error)
+	XTrim(body io.Reader) (*http.Response, error)
+	XRead(ctx context.Context, body io.Reader) (*http.Response, error)
+	XGroupCreate(body io.Reader) (*http.Response, error)
+	XGroupSetID(body io.Reader) (*http.Response, error)
+	XGroupDestroy(key string, group string) (*http.Response, error)
+	XGroupCreateConsumer(body io.Reader) (*http.Response, error)
+	XGroupDelConsumer(key string, group string, consumer string) (*http.Response, error)
+	XReadGroup(ctx context.Context, body io.Reader) (*http.Response, error)
+	XAck(body io.Reader) (*http.Response, error)
+	XPending(key string, group string, query url.Values) (*http.Response, error)
+	XClaim(body io.Reader) (*http.Response, error)
+	XAutoClaim(body io.Reader) (*http.Response, error)
+
+	GeoAdd(body io.Reader) (*http.Response, error)
+	GeoPos(body io.Reader) (*http.Response, error)
+	GeoHash(body io.Reader) (*http.Response, error)
+	GeoDist(key string, member1 string, member2 string, query url.Values) (*http.Response, error)
+	GeoSearch(body io.Reader) (*http.Response, error)
+	GeoSearchStore(body io.Reader) (*http.Response, error)
+
+	JSONSet(body io.Reader) (*http.Response, error)
+	JSONGet(key string, query url.Values) (*http.Response, error)
+	JSONDel(key string, query url.Values) (*http.Response, error)
+	JSONType(key string, query url.Values) (*http.Response, error)
+	JSONNumIncrBy(body io.Reader) (*http.Response, error)
+	JSONArrAppend(body io.Reader) (*http.Response, error)
+	JSONObjKeys(key string, query url.Values) (*http.Response, error)
+
+	BFReserve(body io.Reader) (*http.Response, error)
+	BFAdd(body io.Reader) (*http.Response, error)
+	BFExists(body io.Reader) (*http.Response, error)
+	CFReserve(body io.Reader) (*http.Response, error)
+	CFAdd(body io.Reader) (*http.Response, error)
+	CFExists(body io.Reader) (*http.Response, error)
+	CFDel(key string, item string) (*http.Response, error)
+
+	TSCreate(body io.Reader) (*http.Response, error)
+	TSAdd(body io.Reader) (*http.Response, error)
+	TSMAdd(body io.Reader) (*http.Response, error)
+	TSRange(key string, query url.Values) (*http.Response, error)
+	TSMRange(body io.Reader) (*http.Response, error)
+	TSCreateRule(body io.Reader) (*http.Response, error)
+	TSDeleteRule(key string, destination string) (*http.Response, error)
 }
 
 type redisUsecase struct {
@@ -33,8 +159,8 @@ func (r *redisUsecase) Set(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.Set(body)
 }
 
-func (r *redisUsecase) Get(key string) (*http.Response, error) {
-	return r.redisGateway.Get(key)
+func (r *redisUsecase) Get(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.Get(key, query)
 }
 
 func (r *redisUsecase) Del(key string) (*http.Response, error) {
@@ -45,10 +171,18 @@ func (r *redisUsecase) Keys(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.Keys(body)
 }
 
+func (r *redisUsecase) KeysByPattern(pattern string) (*http.Response, error) {
+	return r.redisGateway.KeysByPattern(pattern)
+}
+
 func (r *redisUsecase) HGet(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.HGet(body)
 }
 
+func (r *redisUsecase) HGetByField(key string, field string) (*http.Response, error) {
+	return r.redisGateway.HGetByField(key, field)
+}
+
 func (r *redisUsecase) HSet(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.HSet(body)
 }
@@ -57,6 +191,10 @@ func (r *redisUsecase) LGet(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.LGet(body)
 }
 
+func (r *redisUsecase) LGetByIndex(key string, index int) (*http.Response, error) {
+	return r.redisGateway.LGetByIndex(key, index)
+}
+
 func (r *redisUsecase) LSet(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.LSet(body)
 }
@@ -68,3 +206,439 @@ func (r *redisUsecase) LPush(body io.Reader) (*http.Response, error) {
 func (r *redisUsecase) Expire(body io.Reader) (*http.Response, error) {
 	return r.redisGateway.Expire(body)
 }
+
+func (r *redisUsecase) Command(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.Command(body)
+}
+
+func (r *redisUsecase) Batch(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.Batch(body)
+}
+
+func (r *redisUsecase) Forward(method string, requestURI string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.Forward(method, requestURI, body)
+}
+
+func (r *redisUsecase) SAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SAdd(body)
+}
+
+func (r *redisUsecase) SRem(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SRem(body)
+}
+
+func (r *redisUsecase) SPop(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SPop(body)
+}
+
+func (r *redisUsecase) SInter(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SInter(body)
+}
+
+func (r *redisUsecase) SUnion(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SUnion(body)
+}
+
+func (r *redisUsecase) SDiff(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SDiff(body)
+}
+
+func (r *redisUsecase) SMembers(key string) (*http.Response, error) {
+	return r.redisGateway.SMembers(key)
+}
+
+func (r *redisUsecase) SCard(key string) (*http.Response, error) {
+	return r.redisGateway.SCard(key)
+}
+
+func (r *redisUsecase) SRandMember(key string, count string) (*http.Response, error) {
+	return r.redisGateway.SRandMember(key, count)
+}
+
+func (r *redisUsecase) SIsMember(key string, member string) (*http.Response, error) {
+	return r.redisGateway.SIsMember(key, member)
+}
+
+func (r *redisUsecase) ZAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZAdd(body)
+}
+
+func (r *redisUsecase) ZRem(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZRem(body)
+}
+
+func (r *redisUsecase) ZIncrBy(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZIncrBy(body)
+}
+
+func (r *redisUsecase) ZPopMin(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZPopMin(body)
+}
+
+func (r *redisUsecase) ZPopMax(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZPopMax(body)
+}
+
+func (r *redisUsecase) ZUnionStore(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZUnionStore(body)
+}
+
+func (r *redisUsecase) ZInterStore(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.ZInterStore(body)
+}
+
+func (r *redisUsecase) ZCard(key string) (*http.Response, error) {
+	return r.redisGateway.ZCard(key)
+}
+
+func (r *redisUsecase) ZCount(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.ZCount(key, query)
+}
+
+func (r *redisUsecase) ZRange(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.ZRange(key, query)
+}
+
+func (r *redisUsecase) ZScore(key string, member string) (*http.Response, error) {
+	return r.redisGateway.ZScore(key, member)
+}
+
+func (r *redisUsecase) ZRank(key string, member string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.ZRank(key, member, query)
+}
+
+func (r *redisUsecase) RPush(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.RPush(body)
+}
+
+func (r *redisUsecase) LPushX(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LPushX(body)
+}
+
+func (r *redisUsecase) RPushX(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.RPushX(body)
+}
+
+func (r *redisUsecase) LPop(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LPop(body)
+}
+
+func (r *redisUsecase) RPop(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.RPop(body)
+}
+
+func (r *redisUsecase) LInsert(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LInsert(body)
+}
+
+func (r *redisUsecase) LRem(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LRem(body)
+}
+
+func (r *redisUsecase) LTrim(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LTrim(body)
+}
+
+func (r *redisUsecase) LMove(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.LMove(body)
+}
+
+func (r *redisUsecase) BLPop(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BLPop(ctx, body)
+}
+
+func (r *redisUsecase) BRPop(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BRPop(ctx, body)
+}
+
+func (r *redisUsecase) BLMove(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BLMove(ctx, body)
+}
+
+func (r *redisUsecase) LRange(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.LRange(key, query)
+}
+
+func (r *redisUsecase) LLen(key string) (*http.Response, error) {
+	return r.redisGateway.LLen(key)
+}
+
+func (r *redisUsecase) LPos(key string, element string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.LPos(key, element, query)
+}
+
+func (r *redisUsecase) HDel(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.HDel(key, body)
+}
+
+func (r *redisUsecase) HSetNX(key string, field string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.HSetNX(key, field, body)
+}
+
+func (r *redisUsecase) HIncrBy(key string, field string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.HIncrBy(key, field, body)
+}
+
+func (r *redisUsecase) HGetAll(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.HGetAll(key, query)
+}
+
+func (r *redisUsecase) HExists(key string, field string) (*http.Response, error) {
+	return r.redisGateway.HExists(key, field)
+}
+
+func (r *redisUsecase) IncrBy(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.IncrBy(key, body)
+}
+
+func (r *redisUsecase) IncrByFloat(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.IncrByFloat(key, body)
+}
+
+func (r *redisUsecase) Append(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.Append(key, body)
+}
+
+func (r *redisUsecase) SetRange(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SetRange(key, body)
+}
+
+func (r *redisUsecase) StrLen(key string) (*http.Response, error) {
+	return r.redisGateway.StrLen(key)
+}
+
+func (r *redisUsecase) GetRange(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.GetRange(key, query)
+}
+
+func (r *redisUsecase) GetEx(key string, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GetEx(key, body)
+}
+
+func (r *redisUsecase) GetDel(key string) (*http.Response, error) {
+	return r.redisGateway.GetDel(key)
+}
+
+func (r *redisUsecase) MGet(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.MGet(body)
+}
+
+func (r *redisUsecase) MSet(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.MSet(body)
+}
+
+func (r *redisUsecase) MSetNX(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.MSetNX(body)
+}
+
+func (r *redisUsecase) SetBit(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.SetBit(body)
+}
+
+func (r *redisUsecase) GetBit(key string, offset string) (*http.Response, error) {
+	return r.redisGateway.GetBit(key, offset)
+}
+
+func (r *redisUsecase) BitCount(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.BitCount(key, query)
+}
+
+func (r *redisUsecase) BitPos(key string, bit string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.BitPos(key, bit, query)
+}
+
+func (r *redisUsecase) BitOp(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BitOp(body)
+}
+
+func (r *redisUsecase) BitField(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BitField(body)
+}
+
+func (r *redisUsecase) PFAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.PFAdd(body)
+}
+
+func (r *redisUsecase) PFCount(key string) (*http.Response, error) {
+	return r.redisGateway.PFCount(key)
+}
+
+func (r *redisUsecase) PFCountKeys(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.PFCountKeys(body)
+}
+
+func (r *redisUsecase) PFMerge(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.PFMerge(body)
+}
+
+func (r *redisUsecase) XAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XAdd(body)
+}
+
+func (r *redisUsecase) XRange(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.XRange(key, query)
+}
+
+func (r *redisUsecase) XLen(key string) (*http.Response, error) {
+	return r.redisGateway.XLen(key)
+}
+
+func (r *redisUsecase) XDel(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XDel(body)
+}
+
+func (r *redisUsecase) XTrim(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XTrim(body)
+}
+
+func (r *redisUsecase) XRead(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XRead(ctx, body)
+}
+
+func (r *redisUsecase) XGroupCreate(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XGroupCreate(body)
+}
+
+func (r *redisUsecase) XGroupSetID(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XGroupSetID(body)
+}
+
+func (r *redisUsecase) XGroupDestroy(key string, group string) (*http.Response, error) {
+	return r.redisGateway.XGroupDestroy(key, group)
+}
+
+func (r *redisUsecase) XGroupCreateConsumer(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XGroupCreateConsumer(body)
+}
+
+func (r *redisUsecase) XGroupDelConsumer(key string, group string, consumer string) (*http.Response, error) {
+	return r.redisGateway.XGroupDelConsumer(key, group, consumer)
+}
+
+func (r *redisUsecase) XReadGroup(ctx context.Context, body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XReadGroup(ctx, body)
+}
+
+func (r *redisUsecase) XAck(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XAck(body)
+}
+
+func (r *redisUsecase) XPending(key string, group string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.XPending(key, group, query)
+}
+
+func (r *redisUsecase) XClaim(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XClaim(body)
+}
+
+func (r *redisUsecase) XAutoClaim(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.XAutoClaim(body)
+}
+
+func (r *redisUsecase) GeoAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GeoAdd(body)
+}
+
+func (r *redisUsecase) GeoPos(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GeoPos(body)
+}
+
+func (r *redisUsecase) GeoHash(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GeoHash(body)
+}
+
+func (r *redisUsecase) GeoDist(key string, member1 string, member2 string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.GeoDist(key, member1, member2, query)
+}
+
+func (r *redisUsecase) GeoSearch(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GeoSearch(body)
+}
+
+func (r *redisUsecase) GeoSearchStore(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.GeoSearchStore(body)
+}
+
+func (r *redisUsecase) JSONSet(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.JSONSet(body)
+}
+
+func (r *redisUsecase) JSONGet(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.JSONGet(key, query)
+}
+
+func (r *redisUsecase) JSONDel(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.JSONDel(key, query)
+}
+
+func (r *redisUsecase) JSONType(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.JSONType(key, query)
+}
+
+func (r *redisUsecase) JSONNumIncrBy(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.JSONNumIncrBy(body)
+}
+
+func (r *redisUsecase) JSONArrAppend(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.JSONArrAppend(body)
+}
+
+func (r *redisUsecase) JSONObjKeys(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.JSONObjKeys(key, query)
+}
+
+func (r *redisUsecase) BFReserve(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BFReserve(body)
+}
+
+func (r *redisUsecase) BFAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BFAdd(body)
+}
+
+func (r *redisUsecase) BFExists(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.BFExists(body)
+}
+
+func (r *redisUsecase) CFReserve(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.CFReserve(body)
+}
+
+func (r *redisUsecase) CFAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.CFAdd(body)
+}
+
+func (r *redisUsecase) CFExists(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.CFExists(body)
+}
+
+func (r *redisUsecase) CFDel(key string, item string) (*http.Response, error) {
+	return r.redisGateway.CFDel(key, item)
+}
+
+func (r *redisUsecase) TSCreate(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.TSCreate(body)
+}
+
+func (r *redisUsecase) TSAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.TSAdd(body)
+}
+
+func (r *redisUsecase) TSMAdd(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.TSMAdd(body)
+}
+
+func (r *redisUsecase) TSRange(key string, query url.Values) (*http.Response, error) {
+	return r.redisGateway.TSRange(key, query)
+}
+
+func (r *redisUsecase) TSMRange(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.TSMRange(body)
+}
+
+func (r *redisUsecase) TSCreateRule(body io.Reader) (*http.Response, error) {
+	return r.redisGateway.TSCreateRule(body)
+}
+
+func (r *redisUsecase) TSDeleteRule(key string, destination string) (*http.Response, error) {
+	return r.redisGateway.TSDeleteRule(key, destination)
+}
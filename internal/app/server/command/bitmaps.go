@@ -0,0 +1,234 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register("setbit", 4, setbit)
+	register("getbit", 3, getbit)
+	register("bitcount", -2, bitcount)
+	register("bitpos", -3, bitpos)
+	register("bitop", -4, bitop)
+	register("bitfield", -2, bitfield)
+}
+
+func setbit(us usecase.RedisUsecase, args []string) Reply {
+	offset, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return NewError(domain.ErrBitOffset)
+	}
+	bit, err := strconv.Atoi(args[3])
+	if err != nil {
+		return NewError(domain.ErrBitValue)
+	}
+	return integerOrError(us.SetBit(args[1], offset, bit))
+}
+
+func getbit(us usecase.RedisUsecase, args []string) Reply {
+	offset, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return NewError(domain.ErrBitOffset)
+	}
+	return integerOrError(us.GetBit(args[1], offset))
+}
+
+// bitcount key [start end [BYTE|BIT]]
+func bitcount(us usecase.RedisUsecase, args []string) Reply {
+	// the end is required by BITCOUNT
+	if len(args) == 3 || len(args) > 5 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	var rng *usecase.BitRange
+	if len(args) > 2 {
+		var err error
+		if rng, err = ParseBitRange(args[2:]); err != nil {
+			return NewError(err)
+		}
+	}
+
+	count, err := us.BitCount(args[1], rng)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(count)
+}
+
+// bitpos key bit [start [end [BYTE|BIT]]]
+func bitpos(us usecase.RedisUsecase, args []string) Reply {
+	if len(args) > 6 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	bit, err := strconv.Atoi(args[2])
+	if err != nil || (bit != 0 && bit != 1) {
+		return Error("ERR The bit argument must be 1 or 0.")
+	}
+
+	var rng *usecase.BitRange
+	if len(args) > 3 {
+		if rng, err = ParseBitRange(args[3:]); err != nil {
+			return NewError(err)
+		}
+	}
+
+	position, err := us.BitPos(args[1], bit, rng)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(position)
+}
+
+// ParseBitRange parses start [end [BYTE|BIT]]
+func ParseBitRange(args []string) (*usecase.BitRange, error) {
+	start, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return nil, domain.ErrNotInteger
+	}
+	rng := &usecase.BitRange{Start: start}
+
+	if len(args) > 1 {
+		end, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, domain.ErrNotInteger
+		}
+		rng.End = &end
+	}
+
+	if len(args) > 2 {
+		switch strings.ToUpper(args[2]) {
+		case "BYTE":
+		case "BIT":
+			rng.Bit = true
+		default:
+			return nil, domain.ErrSyntax
+		}
+	}
+	return rng, nil
+}
+
+// bitop AND|OR|XOR|NOT destkey key [key ...]
+func bitop(us usecase.RedisUsecase, args []string) Reply {
+	operation, ok := map[string]usecase.BitOperation{
+		"AND": usecase.BitAnd,
+		"OR":  usecase.BitOr,
+		"XOR": usecase.BitXor,
+		"NOT": usecase.BitNot,
+	}[strings.ToUpper(args[1])]
+	if !ok {
+		return NewError(domain.ErrSyntax)
+	}
+	return integerOrError(us.BitOp(operation, args[2], args[3:]))
+}
+
+// bitfield key [GET type offset] [SET type offset value] [INCRBY type offset increment] [OVERFLOW WRAP|SAT|FAIL]
+func bitfield(us usecase.RedisUsecase, args []string) Reply {
+	ops := make([]usecase.BitFieldOp, 0, len(args)/3)
+	overflow := usecase.OverflowWrap
+
+	for i := 2; i < len(args); {
+		subcommand := strings.ToUpper(args[i])
+		if subcommand == "OVERFLOW" {
+			if i+1 >= len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			mode, ok := map[string]usecase.BitOverflow{
+				"WRAP": usecase.OverflowWrap,
+				"SAT":  usecase.OverflowSat,
+				"FAIL": usecase.OverflowFail,
+			}[strings.ToUpper(args[i+1])]
+			if !ok {
+				return Error("ERR Invalid OVERFLOW type specified")
+			}
+			overflow = mode
+			i += 2
+			continue
+		}
+
+		kind, ok := map[string]usecase.BitFieldKind{
+			"GET":    usecase.BitFieldGet,
+			"SET":    usecase.BitFieldSet,
+			"INCRBY": usecase.BitFieldIncrBy,
+		}[subcommand]
+		if !ok {
+			return NewError(domain.ErrSyntax)
+		}
+
+		argc := 3
+		if kind != usecase.BitFieldGet {
+			argc = 4
+		}
+		if i+argc > len(args) {
+			return NewError(domain.ErrSyntax)
+		}
+
+		fieldType, err := ParseBitFieldType(args[i+1])
+		if err != nil {
+			return NewError(err)
+		}
+		offset, err := ParseBitFieldOffset(args[i+2], fieldType)
+		if err != nil {
+			return NewError(err)
+		}
+
+		op := usecase.BitFieldOp{Kind: kind, Type: fieldType, Offset: offset, Overflow: overflow}
+		if kind != usecase.BitFieldGet {
+			if op.Value, err = strconv.ParseInt(args[i+3], 10, 64); err != nil {
+				return errNotInteger
+			}
+		}
+		ops = append(ops, op)
+		i += argc
+	}
+
+	values, err := us.BitField(args[1], ops)
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			result = append(result, Null{})
+		} else {
+			result = append(result, Integer(*value))
+		}
+	}
+	return result
+}
+
+// ParseBitFieldType parses a BITFIELD type such as i16 or u8
+func ParseBitFieldType(value string) (usecase.BitFieldType, error) {
+	if len(value) < 2 || (value[0] != 'i' && value[0] != 'u' && value[0] != 'I' && value[0] != 'U') {
+		return usecase.BitFieldType{}, domain.ErrBitFieldType
+	}
+
+	width, err := strconv.ParseUint(value[1:], 10, 8)
+	signed := value[0] == 'i' || value[0] == 'I'
+	if err != nil || width == 0 || (signed && width > 64) || (!signed && width > 63) {
+		return usecase.BitFieldType{}, domain.ErrBitFieldType
+	}
+	return usecase.BitFieldType{Signed: signed, Width: uint(width)}, nil
+}
+
+// ParseBitFieldOffset parses a BITFIELD bit offset, #N is the offset of the N-th field of the type
+func ParseBitFieldOffset(value string, fieldType usecase.BitFieldType) (int64, error) {
+	multiplier := int64(1)
+	if strings.HasPrefix(value, "#") {
+		multiplier = int64(fieldType.Width)
+		value = value[1:]
+	}
+
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || offset < 0 || offset > maxBitFieldOffset/multiplier {
+		return 0, domain.ErrBitOffset
+	}
+	return offset * multiplier, nil
+}
+
+// maxBitFieldOffset is the last bit of a 512MB string
+const maxBitFieldOffset = 512*1024*1024*8 - 1
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"time"
+)
+
+func init() {
+	registerBlocking("blpop", -3, blpop)
+	registerBlocking("brpop", -3, brpop)
+	registerBlocking("blmove", 6, blmove)
+}
+
+// blpop key [key ...] timeout
+func blpop(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	return blockingPop(ctx, args, us.BLPop)
+}
+
+// brpop key [key ...] timeout
+func brpop(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	return blockingPop(ctx, args, us.BRPop)
+}
+
+// blockingPop replies with the key and the popped element or with nil when the timeout expires
+func blockingPop(ctx context.Context, args []string,
+	pop func(context.Context, []string, time.Duration) (string, string, bool, error)) Reply {
+	timeout, err := parseTimeout(args[len(args)-1])
+	if err != nil {
+		return NewError(err)
+	}
+
+	key, value, ok, err := pop(ctx, args[1:len(args)-1], timeout)
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return NullArray{}
+	}
+	return Array{BulkString(key), BulkString(value)}
+}
+
+// blmove source destination LEFT|RIGHT LEFT|RIGHT timeout
+func blmove(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	from, ok := parseListSide(args[3])
+	if !ok {
+		return NewError(domain.ErrSyntax)
+	}
+	to, ok := parseListSide(args[4])
+	if !ok {
+		return NewError(domain.ErrSyntax)
+	}
+	timeout, err := parseTimeout(args[5])
+	if err != nil {
+		return NewError(err)
+	}
+
+	value, ok, err := us.BLMove(ctx, args[1], args[2], from, to, timeout)
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return NullArray{}
+	}
+	return BulkString(value)
+}
+
+// parseTimeout parses a timeout in seconds with a fractional part, 0 blocks forever
+func parseTimeout(value string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, domain.ErrTimeoutNotFloat
+	}
+	return Timeout(seconds)
+}
+
+// Timeout converts a timeout in seconds to the duration, it must fit into the duration
+func Timeout(seconds float64) (time.Duration, error) {
+	if seconds < 0 {
+		return 0, domain.ErrTimeoutIsNegative
+	}
+	// the float of math.MaxInt64 is rounded up to 2^63, so the bound itself overflows too
+	if seconds >= math.MaxInt64/float64(time.Second) {
+		return 0, domain.ErrTimeoutOutOfRange
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strings"
+)
+
+// Handler executes a command, args[0] is the command name
+type Handler func(us usecase.RedisUsecase, args []string) Reply
+
+// BlockingHandler executes a command which may wait, it must return once ctx is done
+type BlockingHandler func(ctx context.Context, us usecase.RedisUsecase, args []string) Reply
+
+// Command describe an entry of the command table.
+// Arity follows the Redis convention: a positive value is the exact number of arguments
+// including the command name, a negative value is the minimal one.
+type Command struct {
+	Name            string
+	Arity           int
+	Handler         Handler
+	BlockingHandler BlockingHandler
+}
+
+var commands = make(map[string]Command)
+
+func register(name string, arity int, handler Handler) {
+	commands[name] = Command{Name: name, Arity: arity, Handler: handler}
+}
+
+func registerBlocking(name string, arity int, handler BlockingHandler) {
+	commands[name] = Command{Name: name, Arity: arity, BlockingHandler: handler}
+}
+
+// Lookup returns the command registered under the case-insensitive name
+func Lookup(name string) (Command, bool) {
+	cmd, ok := commands[strings.ToLower(name)]
+	return cmd, ok
+}
+
+// Execute looks up the command named by args[0], validates its arity and runs it
+func Execute(us usecase.RedisUsecase, args []string) Reply {
+	return ExecuteContext(context.Background(), us, args)
+}
+
+// ExecuteContext is Execute which stops waiting in blocking commands once ctx is done
+func ExecuteContext(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	if len(args) == 0 {
+		return Error("ERR empty command")
+	}
+
+	cmd, ok := Lookup(args[0])
+	if !ok {
+		return Error(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+	}
+
+	if (cmd.Arity > 0 && len(args) != cmd.Arity) || (cmd.Arity < 0 && len(args) < -cmd.Arity) {
+		return Error(fmt.Sprintf("ERR wrong number of arguments for '%s' command", cmd.Name))
+	}
+
+	if cmd.BlockingHandler != nil {
+		return cmd.BlockingHandler(ctx, us, args)
+	}
+	return cmd.Handler(us, args)
+}
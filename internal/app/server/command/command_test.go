@@ -0,0 +1,326 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/repository"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	type fields struct {
+		setup [][]string
+	}
+	type args struct {
+		args []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   Reply
+	}{
+		{
+			name: "empty command",
+			args: args{args: []string{}},
+			want: Error("ERR empty command"),
+		},
+		{
+			name: "unknown command",
+			args: args{args: []string{"NOPE", "k"}},
+			want: Error("ERR unknown command 'NOPE'"),
+		},
+		{
+			name:   "case insensitive name",
+			fields: fields{setup: [][]string{{"SET", "k", "v"}}},
+			args:   args{args: []string{"gEt", "k"}},
+			want:   BulkString("v"),
+		},
+		{
+			name: "get arity",
+			args: args{args: []string{"GET"}},
+			want: Error("ERR wrong number of arguments for 'get' command"),
+		},
+		{
+			name: "set arity",
+			args: args{args: []string{"SET", "k"}},
+			want: Error("ERR wrong number of arguments for 'set' command"),
+		},
+		{
+			name: "set NX and XX",
+			args: args{args: []string{"SET", "k", "v", "NX", "XX"}},
+			want: Error("ERR syntax error"),
+		},
+		{
+			name: "set KEEPTTL and EX",
+			args: args{args: []string{"SET", "k", "v", "KEEPTTL", "EX", "10"}},
+			want: Error("ERR syntax error"),
+		},
+		{
+			name: "set EX zero",
+			args: args{args: []string{"SET", "k", "v", "EX", "0"}},
+			want: Error("ERR invalid expire time in 'set' command"),
+		},
+		{
+			name: "set PX negative",
+			args: args{args: []string{"SET", "k", "v", "PX", "-1"}},
+			want: Error("ERR invalid expire time in 'set' command"),
+		},
+		{
+			name: "set EX overflows milliseconds",
+			args: args{args: []string{"SET", "k", "v", "EX", "9223372036854775807"}},
+			want: Error("ERR invalid expire time in 'set' command"),
+		},
+		{
+			name: "set EX not integer",
+			args: args{args: []string{"SET", "k", "v", "EX", "ten"}},
+			want: Error("ERR value is not an integer or out of range"),
+		},
+		{
+			name: "set GET missing key",
+			args: args{args: []string{"SET", "k", "v", "GET"}},
+			want: Null{},
+		},
+		{
+			name:   "incrby overflow",
+			fields: fields{setup: [][]string{{"SET", "k", "9223372036854775807"}}},
+			args:   args{args: []string{"INCRBY", "k", "1"}},
+			want:   Error("ERR increment or decrement would overflow"),
+		},
+		{
+			name: "incrby not integer",
+			args: args{args: []string{"INCRBY", "k", "99999999999999999999"}},
+			want: Error("ERR value is not an integer or out of range"),
+		},
+		{
+			name: "zadd arity",
+			args: args{args: []string{"ZADD", "z", "1"}},
+			want: Error("ERR wrong number of arguments for 'zadd' command"),
+		},
+		{
+			name: "zadd NX and XX",
+			args: args{args: []string{"ZADD", "z", "NX", "XX", "1", "a"}},
+			want: Error("ERR XX and NX options at the same time are not compatible"),
+		},
+		{
+			name: "zadd GT and NX",
+			args: args{args: []string{"ZADD", "z", "GT", "NX", "1", "a"}},
+			want: Error("ERR GT, LT, and/or NX options at the same time are not compatible"),
+		},
+		{
+			name: "zadd odd score member pairs",
+			args: args{args: []string{"ZADD", "z", "1", "a", "2"}},
+			want: Error("ERR syntax error"),
+		},
+		{
+			name: "zadd nan score",
+			args: args{args: []string{"ZADD", "z", "nan", "a"}},
+			want: Error("ERR value is not a valid float"),
+		},
+		{
+			name: "zadd INCR with two pairs",
+			args: args{args: []string{"ZADD", "z", "INCR", "1", "a", "2", "b"}},
+			want: Error("ERR INCR option supports a single increment-element pair"),
+		},
+		{
+			name: "xadd arity",
+			args: args{args: []string{"XADD", "s", "*", "f"}},
+			want: Error("ERR wrong number of arguments for 'xadd' command"),
+		},
+		{
+			name: "xadd odd fields",
+			args: args{args: []string{"XADD", "s", "*", "f", "v", "g"}},
+			want: Error("ERR wrong number of arguments for 'xadd' command"),
+		},
+		{
+			name: "xadd zero id",
+			args: args{args: []string{"XADD", "s", "0-0", "f", "v"}},
+			want: Error("ERR The ID specified in XADD must be greater than 0-0"),
+		},
+		{
+			name: "xadd negative MAXLEN",
+			args: args{args: []string{"XADD", "s", "MAXLEN", "-1", "*", "f", "v"}},
+			want: Error("ERR The MAXLEN argument must be >= 0."),
+		},
+		{
+			name: "xadd NOMKSTREAM missing key",
+			args: args{args: []string{"XADD", "s", "NOMKSTREAM", "*", "f", "v"}},
+			want: Null{},
+		},
+		{
+			name:   "xadd exhausted id",
+			fields: fields{setup: [][]string{{"XADD", "s", "18446744073709551615-18446744073709551615", "f", "v"}}},
+			args:   args{args: []string{"XADD", "s", "*", "f", "v"}},
+			want:   Error("ERR The stream has exhausted the last possible ID, unable to add more items"),
+		},
+		{
+			name: "xautoclaim huge COUNT",
+			args: args{args: []string{"XAUTOCLAIM", "s", "g", "c", "0", "0-0", "COUNT", "9223372036854775807"}},
+			want: Error("ERR COUNT must be > 0"),
+		},
+		{
+			name: "bitfield u64 type",
+			args: args{args: []string{"BITFIELD", "k", "GET", "u64", "0"}},
+			want: Error("ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is."),
+		},
+		{
+			name: "bitfield negative offset",
+			args: args{args: []string{"BITFIELD", "k", "GET", "u8", "-1"}},
+			want: Error("ERR bit offset is not an integer or out of range"),
+		},
+		{
+			name: "bitfield huge offset",
+			args: args{args: []string{"BITFIELD", "k", "SET", "u8", "#9223372036854775807", "1"}},
+			want: Error("ERR bit offset is not an integer or out of range"),
+		},
+		{
+			name:   "bitfield overflow fail",
+			fields: fields{setup: [][]string{{"BITFIELD", "k", "SET", "u8", "0", "255"}}},
+			args:   args{args: []string{"BITFIELD", "k", "OVERFLOW", "FAIL", "INCRBY", "u8", "0", "1"}},
+			want:   Array{Null{}},
+		},
+		{
+			name: "ts.add negative timestamp",
+			args: args{args: []string{"TS.ADD", "ts", "-1", "1"}},
+			want: Error("ERR TSDB: invalid timestamp"),
+		},
+		{
+			name: "ts.add bad value",
+			args: args{args: []string{"TS.ADD", "ts", "1", "one"}},
+			want: Error("ERR TSDB: invalid value"),
+		},
+		{
+			name: "ts.create RETENTION without value",
+			args: args{args: []string{"TS.CREATE", "ts", "RETENTION"}},
+			want: Error("ERR syntax error"),
+		},
+		{
+			name: "ts.create negative RETENTION",
+			args: args{args: []string{"TS.CREATE", "ts", "RETENTION", "-1"}},
+			want: Error("ERR TSDB: invalid retention"),
+		},
+		{
+			name: "ts.create odd LABELS",
+			args: args{args: []string{"TS.CREATE", "ts", "LABELS", "a"}},
+			want: Error("ERR syntax error"),
+		},
+		{
+			name: "ts.madd arity",
+			args: args{args: []string{"TS.MADD", "a", "1"}},
+			want: Error("ERR wrong number of arguments for 'ts.madd' command"),
+		},
+		{
+			name:   "ts.range negative COUNT",
+			fields: fields{setup: [][]string{{"TS.ADD", "ts", "1", "1"}}},
+			args:   args{args: []string{"TS.RANGE", "ts", "-", "+", "COUNT", "-1"}},
+			want:   Error("ERR value is not an integer or out of range"),
+		},
+		{
+			name:   "ts.range zero bucket duration",
+			fields: fields{setup: [][]string{{"TS.ADD", "ts", "1", "1"}}},
+			args:   args{args: []string{"TS.RANGE", "ts", "-", "+", "AGGREGATION", "avg", "0"}},
+			want:   Error("ERR TSDB: bucketDuration must be greater than zero"),
+		},
+		{
+			name: "ts.mrange without FILTER expressions",
+			args: args{args: []string{"TS.MRANGE", "-", "+", "FILTER"}},
+			want: Error("ERR wrong number of arguments for 'ts.mrange' command"),
+		},
+		{
+			name: "geoadd longitude out of range",
+			args: args{args: []string{"GEOADD", "g", "181", "0", "m"}},
+			want: Error("ERR invalid longitude,latitude pair"),
+		},
+		{
+			name: "geosearch FROMMEMBER and FROMLONLAT",
+			args: args{args: []string{"GEOSEARCH", "g", "FROMMEMBER", "m", "FROMLONLAT", "0", "0", "BYRADIUS", "1", "km"}},
+			want: Error("ERR exactly one of FROMMEMBER or FROMLONLAT can be specified"),
+		},
+		{
+			name: "geosearch without BY",
+			args: args{args: []string{"GEOSEARCH", "g", "FROMLONLAT", "0", "0", "COUNT", "1"}},
+			want: Error("ERR exactly one of BYRADIUS and BYBOX can be specified"),
+		},
+		{
+			name: "geosearch negative radius",
+			args: args{args: []string{"GEOSEARCH", "g", "FROMLONLAT", "0", "0", "BYRADIUS", "-1", "km"}},
+			want: Error("ERR radius cannot be negative"),
+		},
+		{
+			name: "geosearch bad unit",
+			args: args{args: []string{"GEOSEARCH", "g", "FROMLONLAT", "0", "0", "BYRADIUS", "1", "au"}},
+			want: Error("ERR unsupported unit provided. please use M, KM, FT, MI"),
+		},
+		{
+			name: "geosearch zero COUNT",
+			args: args{args: []string{"GEOSEARCH", "g", "FROMLONLAT", "0", "0", "BYRADIUS", "1", "km", "COUNT", "0"}},
+			want: Error("ERR COUNT must be > 0"),
+		},
+		{
+			name:   "srandmember min count",
+			fields: fields{setup: [][]string{{"SADD", "s", "a"}}},
+			args:   args{args: []string{"SRANDMEMBER", "s", "-9223372036854775808"}},
+			want:   Error("ERR value is out of range"),
+		},
+		{
+			name:   "spop max count",
+			fields: fields{setup: [][]string{{"SADD", "s", "a"}}},
+			args:   args{args: []string{"SPOP", "s", "9223372036854775807"}},
+			want:   Set{BulkString("a")},
+		},
+		{
+			name:   "lpop max count",
+			fields: fields{setup: [][]string{{"RPUSH", "l", "a", "b"}}},
+			args:   args{args: []string{"LPOP", "l", "9223372036854775807"}},
+			want:   Array{BulkString("a"), BulkString("b")},
+		},
+		{
+			name: "blpop negative timeout",
+			args: args{args: []string{"BLPOP", "l", "-1"}},
+			want: Error("ERR timeout is negative"),
+		},
+		{
+			name: "blpop timeout not float",
+			args: args{args: []string{"BLPOP", "l", "soon"}},
+			want: Error("ERR timeout is not a float or out of range"),
+		},
+		{
+			name: "blpop timeout out of range",
+			args: args{args: []string{"BLPOP", "l", "9223372036.854775807"}},
+			want: Error("ERR timeout is out of range"),
+		},
+		{
+			name: "blpop huge timeout",
+			args: args{args: []string{"BLPOP", "l", "1e300"}},
+			want: Error("ERR timeout is out of range"),
+		},
+		{
+			name: "blmove timeout out of range",
+			args: args{args: []string{"BLMOVE", "a", "b", "LEFT", "RIGHT", "9223372037"}},
+			want: Error("ERR timeout is out of range"),
+		},
+		{
+			name: "xread BLOCK out of range",
+			args: args{args: []string{"XREAD", "BLOCK", "9223372036855", "STREAMS", "s", "$"}},
+			want: Error("ERR timeout is out of range"),
+		},
+		{
+			name: "blpop timed out",
+			args: args{args: []string{"BLPOP", "l", "0.01"}},
+			want: NullArray{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us := usecase.NewRedisUsecase(repository.NewInMemoryRedisStore())
+			for _, setup := range tt.fields.setup {
+				if reply, ok := Execute(us, setup).(Error); ok {
+					t.Fatalf("Execute(%v) error = %v", setup, reply)
+				}
+			}
+			if got := Execute(us, tt.args.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Execute() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
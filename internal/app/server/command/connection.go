@@ -0,0 +1,24 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+)
+
+func init() {
+	register("ping", -1, ping)
+	register("echo", 2, echo)
+}
+
+func ping(_ usecase.RedisUsecase, args []string) Reply {
+	if len(args) > 2 {
+		return Error("ERR wrong number of arguments for 'ping' command")
+	}
+	if len(args) == 2 {
+		return BulkString(args[1])
+	}
+	return SimpleString("PONG")
+}
+
+func echo(_ usecase.RedisUsecase, args []string) Reply {
+	return BulkString(args[1])
+}
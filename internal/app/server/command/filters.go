@@ -0,0 +1,174 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register("bf.reserve", -4, bfReserve)
+	register("bf.add", 3, bfAdd)
+	register("bf.madd", -3, bfMAdd)
+	register("bf.exists", 3, bfExists)
+	register("bf.mexists", -3, bfMExists)
+	register("cf.reserve", -3, cfReserve)
+	register("cf.add", 3, cfAdd)
+	register("cf.addnx", 3, cfAddNX)
+	register("cf.del", 3, cfDel)
+	register("cf.exists", 3, cfExists)
+	register("cf.mexists", -3, cfMExists)
+}
+
+// bf.reserve key error_rate capacity [EXPANSION expansion] [NONSCALING]
+func bfReserve(us usecase.RedisUsecase, args []string) Reply {
+	options := usecase.DefaultBloomOptions
+
+	var err error
+	if options.ErrorRate, err = strconv.ParseFloat(args[2], 64); err != nil {
+		return NewError(domain.ErrNotFloat)
+	}
+	if options.Capacity, err = strconv.Atoi(args[3]); err != nil {
+		return NewError(domain.ErrNotInteger)
+	}
+
+	for i := 4; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "EXPANSION":
+			if i+1 == len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			i++
+			if options.Expansion, err = strconv.Atoi(args[i]); err != nil {
+				return NewError(domain.ErrNotInteger)
+			}
+		case "NONSCALING":
+			options.NonScaling = true
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	if err := us.BFReserve(args[1], options); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// bf.add key item
+func bfAdd(us usecase.RedisUsecase, args []string) Reply {
+	added, err := us.BFAdd(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(added[0])
+}
+
+// bf.madd key item [item ...]
+func bfMAdd(us usecase.RedisUsecase, args []string) Reply {
+	return booleansOrError(us.BFAdd(args[1], args[2:]))
+}
+
+// bf.exists key item
+func bfExists(us usecase.RedisUsecase, args []string) Reply {
+	exists, err := us.BFExists(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(exists[0])
+}
+
+// bf.mexists key item [item ...]
+func bfMExists(us usecase.RedisUsecase, args []string) Reply {
+	return booleansOrError(us.BFExists(args[1], args[2:]))
+}
+
+// cf.reserve key capacity [BUCKETSIZE bucketsize] [MAXITERATIONS maxiterations] [EXPANSION expansion]
+func cfReserve(us usecase.RedisUsecase, args []string) Reply {
+	options := usecase.DefaultCuckooOptions
+
+	var err error
+	if options.Capacity, err = strconv.Atoi(args[2]); err != nil {
+		return NewError(domain.ErrNotInteger)
+	}
+
+	for i := 3; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			return NewError(domain.ErrSyntax)
+		}
+
+		var option *int
+		switch strings.ToUpper(args[i]) {
+		case "BUCKETSIZE":
+			option = &options.BucketSize
+		case "MAXITERATIONS":
+			option = &options.MaxIterations
+		case "EXPANSION":
+			option = &options.Expansion
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+		if *option, err = strconv.Atoi(args[i+1]); err != nil {
+			return NewError(domain.ErrNotInteger)
+		}
+	}
+
+	if err := us.CFReserve(args[1], options); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// cf.add key item
+func cfAdd(us usecase.RedisUsecase, args []string) Reply {
+	added, err := us.CFAdd(args[1], args[2], false)
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(added)
+}
+
+// cf.addnx key item
+func cfAddNX(us usecase.RedisUsecase, args []string) Reply {
+	added, err := us.CFAdd(args[1], args[2], true)
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(added)
+}
+
+// cf.del key item
+func cfDel(us usecase.RedisUsecase, args []string) Reply {
+	deleted, err := us.CFDel(args[1], args[2])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(deleted)
+}
+
+// cf.exists key item
+func cfExists(us usecase.RedisUsecase, args []string) Reply {
+	exists, err := us.CFExists(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(exists[0])
+}
+
+// cf.mexists key item [item ...]
+func cfMExists(us usecase.RedisUsecase, args []string) Reply {
+	return booleansOrError(us.CFExists(args[1], args[2:]))
+}
+
+func booleansOrError(values []bool, err error) Reply {
+	if err != nil {
+		return NewError(err)
+	}
+
+	array := make(Array, 0, len(values))
+	for _, value := range values {
+		array = append(array, boolToInteger(value))
+	}
+	return array
+}
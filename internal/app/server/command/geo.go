@@ -0,0 +1,290 @@
+package command
+
+import (
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+	"strings"
+)
+
+// geoUnits are the meters in the distance units
+var geoUnits = map[string]float64{
+	"m":  1,
+	"km": 1000,
+	"ft": 0.3048,
+	"mi": 1609.34,
+}
+
+func init() {
+	register("geoadd", -5, geoadd)
+	register("geopos", -2, geopos)
+	register("geodist", -4, geodist)
+	register("geohash", -2, geohash)
+	register("geosearch", -7, geosearch)
+	register("geosearchstore", -8, geosearchstore)
+}
+
+// ParseGeoUnit returns the meters in the unit m, km, ft or mi
+func ParseGeoUnit(unit string) (float64, error) {
+	meters, ok := geoUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, domain.ErrGeoUnit
+	}
+	return meters, nil
+}
+
+// geoadd key [NX|XX] [CH] longitude latitude member [longitude latitude member ...]
+func geoadd(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.ZAddOptions
+
+	i := 2
+options:
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		case "CH":
+			options.CH = true
+		default:
+			break options
+		}
+	}
+
+	triples := args[i:]
+	if len(triples) == 0 || len(triples)%3 != 0 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	members := make([]usecase.GeoMember, 0, len(triples)/3)
+	for j := 0; j < len(triples); j += 3 {
+		longitude, latitude, err := parseGeoPosition(triples[j], triples[j+1])
+		if err != nil {
+			return NewError(err)
+		}
+		members = append(members, usecase.GeoMember{Member: triples[j+2], Longitude: longitude, Latitude: latitude})
+	}
+	return integerOrError(us.GeoAdd(args[1], members, options))
+}
+
+func parseGeoPosition(longitude string, latitude string) (float64, float64, error) {
+	lon, err := strconv.ParseFloat(longitude, 64)
+	if err != nil {
+		return 0, 0, domain.ErrNotFloat
+	}
+	lat, err := strconv.ParseFloat(latitude, 64)
+	if err != nil {
+		return 0, 0, domain.ErrNotFloat
+	}
+	return lon, lat, nil
+}
+
+func geopos(us usecase.RedisUsecase, args []string) Reply {
+	positions, err := us.GeoPos(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(positions))
+	for _, position := range positions {
+		if position == nil {
+			result = append(result, Null{})
+			continue
+		}
+		result = append(result, Array{Double(position.Longitude), Double(position.Latitude)})
+	}
+	return result
+}
+
+// geodist key member1 member2 [M|KM|FT|MI]
+func geodist(us usecase.RedisUsecase, args []string) Reply {
+	if len(args) > 5 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	unit := 1.0
+	if len(args) == 5 {
+		var err error
+		if unit, err = ParseGeoUnit(args[4]); err != nil {
+			return NewError(err)
+		}
+	}
+
+	distance, ok, err := us.GeoDist(args[1], args[2], args[3], unit)
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return Null{}
+	}
+	return geoDistance(distance)
+}
+
+// geoDistance replies with the distance rounded to 4 decimals like Redis
+func geoDistance(distance float64) BulkString {
+	return BulkString(strconv.FormatFloat(distance, 'f', 4, 64))
+}
+
+func geohash(us usecase.RedisUsecase, args []string) Reply {
+	hashes, err := us.GeoHash(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(hashes))
+	for _, hash := range hashes {
+		if hash == nil {
+			result = append(result, Null{})
+			continue
+		}
+		result = append(result, BulkString(*hash))
+	}
+	return result
+}
+
+// geoSearchReply selects the optional parts of the GEOSEARCH reply
+type geoSearchReply struct {
+	withCoord bool
+	withDist  bool
+	withHash  bool
+}
+
+// geosearch key FROMMEMBER member|FROMLONLAT longitude latitude BYRADIUS radius unit|BYBOX width height unit
+// [ASC|DESC] [COUNT count [ANY]] [WITHCOORD] [WITHDIST] [WITHHASH]
+func geosearch(us usecase.RedisUsecase, args []string) Reply {
+	query, with, _, err := parseGeoSearch(args[2:], false)
+	if err != nil {
+		return NewError(err)
+	}
+
+	locations, err := us.GeoSearch(args[1], query)
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(locations))
+	for _, location := range locations {
+		if !with.withCoord && !with.withDist && !with.withHash {
+			result = append(result, BulkString(location.Member))
+			continue
+		}
+
+		item := Array{BulkString(location.Member)}
+		if with.withDist {
+			item = append(item, geoDistance(location.Distance))
+		}
+		if with.withHash {
+			item = append(item, Integer(location.Hash))
+		}
+		if with.withCoord {
+			item = append(item, Array{Double(location.Position.Longitude), Double(location.Position.Latitude)})
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// geosearchstore destination source ... [STOREDIST] with the GEOSEARCH options except WITH*
+func geosearchstore(us usecase.RedisUsecase, args []string) Reply {
+	query, _, storeDist, err := parseGeoSearch(args[3:], true)
+	if err != nil {
+		return NewError(err)
+	}
+	return integerOrError(us.GeoSearchStore(args[1], args[2], query, storeDist))
+}
+
+func parseGeoSearch(args []string, store bool) (usecase.GeoSearchQuery, geoSearchReply, bool, error) {
+	query := usecase.GeoSearchQuery{Unit: 1}
+	var with geoSearchReply
+	fromLonLat, byRadius, storeDist := false, false, false
+
+	for i := 0; i < len(args); i++ {
+		option := strings.ToUpper(args[i])
+		switch {
+		case option == "FROMMEMBER" && i+1 < len(args):
+			query.FromMember = true
+			query.Member = args[i+1]
+			i++
+		case option == "FROMLONLAT" && i+2 < len(args):
+			longitude, latitude, err := parseGeoPosition(args[i+1], args[i+2])
+			if err != nil {
+				return query, with, false, err
+			}
+			fromLonLat = true
+			query.Longitude, query.Latitude = longitude, latitude
+			i += 2
+		case option == "BYRADIUS" && i+2 < len(args):
+			radius, err := parseGeoDistance(args[i+1], "radius")
+			if err != nil {
+				return query, with, false, err
+			}
+			if query.Unit, err = ParseGeoUnit(args[i+2]); err != nil {
+				return query, with, false, err
+			}
+			byRadius = true
+			query.Radius = radius
+			i += 2
+		case option == "BYBOX" && i+3 < len(args):
+			width, err := parseGeoDistance(args[i+1], "width")
+			if err != nil {
+				return query, with, false, err
+			}
+			height, err := parseGeoDistance(args[i+2], "height")
+			if err != nil {
+				return query, with, false, err
+			}
+			if query.Unit, err = ParseGeoUnit(args[i+3]); err != nil {
+				return query, with, false, err
+			}
+			query.ByBox = true
+			query.Width, query.Height = width, height
+			i += 3
+		case option == "ASC":
+			query.Sort = usecase.GeoSortAsc
+		case option == "DESC":
+			query.Sort = usecase.GeoSortDesc
+		case option == "COUNT" && i+1 < len(args):
+			count, err := strconv.Atoi(args[i+1])
+			if err != nil || count <= 0 {
+				return query, with, false, errors.New("ERR COUNT must be > 0")
+			}
+			query.Count = count
+			i++
+			if i+1 < len(args) && strings.ToUpper(args[i+1]) == "ANY" {
+				query.Any = true
+				i++
+			}
+		case option == "WITHCOORD" && !store:
+			with.withCoord = true
+		case option == "WITHDIST" && !store:
+			with.withDist = true
+		case option == "WITHHASH" && !store:
+			with.withHash = true
+		case option == "STOREDIST" && store:
+			storeDist = true
+		default:
+			return query, with, false, domain.ErrSyntax
+		}
+	}
+
+	if query.FromMember == fromLonLat {
+		return query, with, false, errors.New("ERR exactly one of FROMMEMBER or FROMLONLAT can be specified")
+	}
+	if query.ByBox == byRadius {
+		return query, with, false, errors.New("ERR exactly one of BYRADIUS and BYBOX can be specified")
+	}
+	return query, with, storeDist, nil
+}
+
+func parseGeoDistance(value string, name string) (float64, error) {
+	distance, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, domain.ErrNotFloat
+	}
+	if distance < 0 {
+		return 0, errors.New("ERR " + name + " cannot be negative")
+	}
+	return distance, nil
+}
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"regexp"
+	"strings"
+)
+
+// globToRegexp converts a Redis glob-style pattern (*, ?, [...] and \ escapes)
+// to an anchored regular expression understood by RedisUsecase.Keys
+func globToRegexp(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			builder.WriteString("(?s:.*)")
+		case '?':
+			builder.WriteString("(?s:.)")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			builder.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				builder.WriteString(regexp.QuoteMeta(pattern[i:]))
+				i = len(pattern)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			builder.WriteString("[")
+			if strings.HasPrefix(class, "^") {
+				builder.WriteString("^")
+				class = class[1:]
+			}
+			builder.WriteString(strings.NewReplacer(`\`, `\\`, "[", `\[`).Replace(class))
+			builder.WriteString("]")
+			i += end + 1
+		default:
+			builder.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	builder.WriteString("$")
+	return builder.String()
+}
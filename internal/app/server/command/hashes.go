@@ -0,0 +1,129 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+)
+
+func init() {
+	register("hget", 3, hget)
+	register("hset", -4, hset)
+	register("hsetnx", 4, hsetnx)
+	register("hdel", -3, hdel)
+	register("hgetall", 2, hgetall)
+	register("hkeys", 2, hkeys)
+	register("hvals", 2, hvals)
+	register("hlen", 2, hlen)
+	register("hexists", 3, hexists)
+	register("hmget", -3, hmget)
+	register("hincrby", 4, hincrby)
+}
+
+func hget(us usecase.RedisUsecase, args []string) Reply {
+	value, ok, err := us.HGet(args[1], args[2])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func hset(us usecase.RedisUsecase, args []string) Reply {
+	if len(args)%2 != 0 {
+		return Error("ERR wrong number of arguments for 'hset' command")
+	}
+
+	pairs := make([]usecase.FieldValue, 0, len(args)/2-1)
+	for i := 2; i < len(args); i += 2 {
+		pairs = append(pairs, usecase.FieldValue{Field: args[i], Value: args[i+1]})
+	}
+
+	count, err := us.HSet(args[1], pairs)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(count)
+}
+
+func hsetnx(us usecase.RedisUsecase, args []string) Reply {
+	ok, err := us.HSetNX(args[1], args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(ok)
+}
+
+func hdel(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.HDel(args[1], args[2:]))
+}
+
+// hgetall replies with a map, which is a flat array of fields and values in RESP2
+func hgetall(us usecase.RedisUsecase, args []string) Reply {
+	pairs, err := us.HGetAll(args[1])
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Map, 0, len(pairs))
+	for _, pair := range pairs {
+		result = append(result, MapEntry{Key: BulkString(pair.Field), Value: BulkString(pair.Value)})
+	}
+	return result
+}
+
+func hkeys(us usecase.RedisUsecase, args []string) Reply {
+	fields, err := us.HKeys(args[1])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkStrings(fields)
+}
+
+func hvals(us usecase.RedisUsecase, args []string) Reply {
+	values, err := us.HVals(args[1])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkStrings(values)
+}
+
+func hlen(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.HLen(args[1]))
+}
+
+func hexists(us usecase.RedisUsecase, args []string) Reply {
+	ok, err := us.HExists(args[1], args[2])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(ok)
+}
+
+func hmget(us usecase.RedisUsecase, args []string) Reply {
+	values, err := us.HMGet(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			result = append(result, Null{})
+		} else {
+			result = append(result, BulkString(*value))
+		}
+	}
+	return result
+}
+
+func hincrby(us usecase.RedisUsecase, args []string) Reply {
+	increment, err := strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return errNotInteger
+	}
+
+	value, err := us.HIncrBy(args[1], args[2], increment)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(value)
+}
@@ -0,0 +1,32 @@
+package command
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+func init() {
+	register("pfadd", -2, pfadd)
+	register("pfcount", -2, pfcount)
+	register("pfmerge", -2, pfmerge)
+}
+
+func pfadd(us usecase.RedisUsecase, args []string) Reply {
+	changed, err := us.PFAdd(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(changed)
+}
+
+func pfcount(us usecase.RedisUsecase, args []string) Reply {
+	count, err := us.PFCount(args[1:])
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(count)
+}
+
+func pfmerge(us usecase.RedisUsecase, args []string) Reply {
+	if err := us.PFMerge(args[1], args[2:]); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strings"
+)
+
+func init() {
+	register("json.set", -4, jsonSet)
+	register("json.get", -2, jsonGet)
+	register("json.del", -2, jsonDel)
+	register("json.type", -2, jsonType)
+	register("json.numincrby", 4, jsonNumIncrBy)
+	register("json.arrappend", -4, jsonArrAppend)
+	register("json.objkeys", -2, jsonObjKeys)
+}
+
+// optionalPath returns the path argument at i or the default path if it's missing
+func optionalPath(args []string, i int, defaultPath string) (string, error) {
+	switch {
+	case len(args) <= i:
+		return defaultPath, nil
+	case len(args) == i+1:
+		return args[i], nil
+	default:
+		return "", domain.ErrSyntax
+	}
+}
+
+// json.set key path value [NX|XX]
+func jsonSet(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.JSONSetOptions
+	for _, arg := range args[4:] {
+		switch strings.ToUpper(arg) {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	ok, err := us.JSONSet(args[1], args[2], args[3], options)
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return Null{}
+	}
+	return OK
+}
+
+// json.get key [path ...]
+func jsonGet(us usecase.RedisUsecase, args []string) Reply {
+	value, ok, err := us.JSONGet(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func jsonDel(us usecase.RedisUsecase, args []string) Reply {
+	path, err := optionalPath(args, 2, "$")
+	if err != nil {
+		return NewError(err)
+	}
+	return integerOrError(us.JSONDel(args[1], path))
+}
+
+// json.type key [path] replies with the type of the root by default
+func jsonType(us usecase.RedisUsecase, args []string) Reply {
+	path, err := optionalPath(args, 2, ".")
+	if err != nil {
+		return NewError(err)
+	}
+
+	types, err := us.JSONType(args[1], path)
+	if err != nil {
+		return NewError(err)
+	}
+	if types == nil || !usecase.IsJSONPath(path) && len(types) == 0 {
+		return Null{}
+	}
+
+	if !usecase.IsJSONPath(path) {
+		return SimpleString(types[0])
+	}
+	result := make(Array, 0, len(types))
+	for _, t := range types {
+		result = append(result, SimpleString(t))
+	}
+	return result
+}
+
+// json.numincrby key path value replies with the JSON array of the new values
+// or with the single new value for a legacy path
+func jsonNumIncrBy(us usecase.RedisUsecase, args []string) Reply {
+	values, err := us.JSONNumIncrBy(args[1], args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+
+	if !usecase.IsJSONPath(args[2]) {
+		return BulkString(*values[0])
+	}
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			items = append(items, "null")
+			continue
+		}
+		items = append(items, *value)
+	}
+	return BulkString("[" + strings.Join(items, ",") + "]")
+}
+
+// json.arrappend key path value [value ...]
+func jsonArrAppend(us usecase.RedisUsecase, args []string) Reply {
+	lengths, err := us.JSONArrAppend(args[1], args[2], args[3:])
+	if err != nil {
+		return NewError(err)
+	}
+
+	if !usecase.IsJSONPath(args[2]) {
+		return Integer(*lengths[0])
+	}
+	result := make(Array, 0, len(lengths))
+	for _, length := range lengths {
+		if length == nil {
+			result = append(result, Null{})
+			continue
+		}
+		result = append(result, Integer(*length))
+	}
+	return result
+}
+
+// json.objkeys key [path] replies with the keys of the root by default
+func jsonObjKeys(us usecase.RedisUsecase, args []string) Reply {
+	path, err := optionalPath(args, 2, ".")
+	if err != nil {
+		return NewError(err)
+	}
+
+	keys, err := us.JSONObjKeys(args[1], path)
+	if err != nil {
+		return NewError(err)
+	}
+	if keys == nil || !usecase.IsJSONPath(path) && len(keys) == 0 {
+		return Null{}
+	}
+
+	if !usecase.IsJSONPath(path) {
+		return bulkStrings(keys[0])
+	}
+	result := make(Array, 0, len(keys))
+	for _, objectKeys := range keys {
+		if objectKeys == nil {
+			result = append(result, Null{})
+			continue
+		}
+		result = append(result, bulkStrings(objectKeys))
+	}
+	return result
+}
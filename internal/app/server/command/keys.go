@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+)
+
+func init() {
+	register("del", -2, del)
+	register("keys", 2, keys)
+	register("expire", 3, expire)
+}
+
+func del(us usecase.RedisUsecase, args []string) Reply {
+	var count Integer
+	for _, key := range args[1:] {
+		if us.Del(key) {
+			count++
+		}
+	}
+	return count
+}
+
+func keys(us usecase.RedisUsecase, args []string) Reply {
+	list, err := us.Keys(globToRegexp(args[1]))
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkStrings(list)
+}
+
+func expire(us usecase.RedisUsecase, args []string) Reply {
+	ttl, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+	return boolToInteger(us.Expire(args[1], ttl))
+}
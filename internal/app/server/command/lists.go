@@ -0,0 +1,257 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register("lpush", -3, lpush)
+	register("rpush", -3, rpush)
+	register("lpushx", -3, lpushx)
+	register("rpushx", -3, rpushx)
+	register("lpop", -2, lpop)
+	register("rpop", -2, rpop)
+	register("lrange", 4, lrange)
+	register("llen", 2, llen)
+	register("lindex", 3, lindex)
+	register("lset", 4, lset)
+	register("linsert", 5, linsert)
+	register("lrem", 4, lrem)
+	register("ltrim", 4, ltrim)
+	register("lpos", -3, lpos)
+	register("lmove", 5, lmove)
+}
+
+func lpush(us usecase.RedisUsecase, args []string) Reply {
+	size, err := us.LPush(args[1], args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(size)
+}
+
+func rpush(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.RPush(args[1], args[2:]))
+}
+
+func lpushx(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.LPushX(args[1], args[2:]))
+}
+
+func rpushx(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.RPushX(args[1], args[2:]))
+}
+
+// lpop key [count]
+func lpop(us usecase.RedisUsecase, args []string) Reply {
+	return listPop(args, us.LPop)
+}
+
+// rpop key [count]
+func rpop(us usecase.RedisUsecase, args []string) Reply {
+	return listPop(args, us.RPop)
+}
+
+// listPop replies with a single element without count and with an array otherwise
+func listPop(args []string, pop func(key string, count int) ([]string, error)) Reply {
+	if len(args) > 3 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	count := 1
+	if len(args) == 3 {
+		var err error
+		count, err = strconv.Atoi(args[2])
+		if err != nil || count < 0 {
+			return Error("ERR value is out of range, must be positive")
+		}
+	}
+
+	values, err := pop(args[1], count)
+	if err != nil {
+		return NewError(err)
+	}
+	if values == nil {
+		return Null{}
+	}
+	if len(args) == 2 {
+		return BulkString(values[0])
+	}
+	return bulkStrings(values)
+}
+
+func lrange(us usecase.RedisUsecase, args []string) Reply {
+	start, stop, err := parseIntegers(args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+
+	values, err := us.LRange(args[1], start, stop)
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkStrings(values)
+}
+
+func llen(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.LLen(args[1]))
+}
+
+func lindex(us usecase.RedisUsecase, args []string) Reply {
+	index, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+
+	value, ok, err := us.LIndex(args[1], index)
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func lset(us usecase.RedisUsecase, args []string) Reply {
+	index, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+
+	if err := us.LSet(args[1], index, args[3]); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// linsert key BEFORE|AFTER pivot element
+func linsert(us usecase.RedisUsecase, args []string) Reply {
+	var before bool
+	switch strings.ToUpper(args[2]) {
+	case "BEFORE":
+		before = true
+	case "AFTER":
+		before = false
+	default:
+		return NewError(domain.ErrSyntax)
+	}
+	return integerOrError(us.LInsert(args[1], before, args[3], args[4]))
+}
+
+func lrem(us usecase.RedisUsecase, args []string) Reply {
+	count, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+	return integerOrError(us.LRem(args[1], count, args[3]))
+}
+
+func ltrim(us usecase.RedisUsecase, args []string) Reply {
+	start, stop, err := parseIntegers(args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+
+	if err := us.LTrim(args[1], start, stop); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// lpos key element [RANK rank] [COUNT num-matches] [MAXLEN len]
+func lpos(us usecase.RedisUsecase, args []string) Reply {
+	options := usecase.LPosOptions{Rank: 1}
+	withCount := false
+
+	for i := 3; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return NewError(domain.ErrSyntax)
+		}
+		value, err := strconv.Atoi(args[i+1])
+		if err != nil {
+			return errNotInteger
+		}
+
+		switch strings.ToUpper(args[i]) {
+		case "RANK":
+			options.Rank = value
+		case "COUNT":
+			if value < 0 {
+				return Error("ERR COUNT can't be negative")
+			}
+			options.Count = value
+			withCount = true
+		case "MAXLEN":
+			if value < 0 {
+				return Error("ERR MAXLEN can't be negative")
+			}
+			options.MaxLen = value
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	if !withCount {
+		options.Count = 1
+	}
+
+	positions, err := us.LPos(args[1], args[2], options)
+	if err != nil {
+		return NewError(err)
+	}
+
+	if !withCount {
+		if len(positions) == 0 {
+			return Null{}
+		}
+		return Integer(positions[0])
+	}
+
+	array := make(Array, 0, len(positions))
+	for _, position := range positions {
+		array = append(array, Integer(position))
+	}
+	return array
+}
+
+// lmove source destination LEFT|RIGHT LEFT|RIGHT
+func lmove(us usecase.RedisUsecase, args []string) Reply {
+	from, ok := parseListSide(args[3])
+	if !ok {
+		return NewError(domain.ErrSyntax)
+	}
+	to, ok := parseListSide(args[4])
+	if !ok {
+		return NewError(domain.ErrSyntax)
+	}
+
+	value, ok, err := us.LMove(args[1], args[2], from, to)
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func parseListSide(side string) (usecase.ListSide, bool) {
+	switch strings.ToUpper(side) {
+	case "LEFT":
+		return usecase.ListLeft, true
+	case "RIGHT":
+		return usecase.ListRight, true
+	default:
+		return 0, false
+	}
+}
+
+func parseIntegers(first, second string) (int, int, error) {
+	a, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, 0, domain.ErrNotInteger
+	}
+	b, err := strconv.Atoi(second)
+	if err != nil {
+		return 0, 0, domain.ErrNotInteger
+	}
+	return a, b, nil
+}
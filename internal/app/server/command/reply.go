@@ -0,0 +1,99 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"strings"
+)
+
+// Reply represent a typed command result which is independent of the wire protocol
+type Reply interface{}
+
+// SimpleString is a short non binary-safe status reply, e.g. OK
+type SimpleString string
+
+// BulkString is a binary-safe string reply
+type BulkString string
+
+// Integer is a signed integer reply
+type Integer int64
+
+// Null is the reply for a missing value
+type Null struct{}
+
+// NullArray is the reply for a missing array, e.g. of a timed out blocking command
+type NullArray struct{}
+
+// Array is an ordered collection of replies
+type Array []Reply
+
+// Map is an ordered collection of key-value pairs, e.g. the fields of a hash
+type Map []MapEntry
+
+// MapEntry is a single pair of a Map reply
+type MapEntry struct {
+	Key   Reply
+	Value Reply
+}
+
+// Set is an unordered collection of unique replies
+type Set []Reply
+
+// Double is a floating point number reply
+type Double float64
+
+// Boolean is a true or false reply
+type Boolean bool
+
+// Push is an out-of-band message sent to the client without a request
+type Push []Reply
+
+// Error is an error reply, the message starts with an error kind such as ERR or WRONGTYPE
+type Error string
+
+// OK is the common success status reply
+const OK = SimpleString("OK")
+
+var errNotInteger = NewError(domain.ErrNotInteger)
+
+// errorKinds are the error kinds which are sent to the client as is
+var errorKinds = []string{"ERR ", "WRONGTYPE ", "NOGROUP ", "BUSYGROUP "}
+
+// NewError converts err to an error reply, adding the generic ERR kind if err has none
+func NewError(err error) Error {
+	message := err.Error()
+	for _, kind := range errorKinds {
+		if strings.HasPrefix(message, kind) {
+			return Error(message)
+		}
+	}
+	return Error("ERR " + message)
+}
+
+func bulkOrNull(value string, ok bool) Reply {
+	if !ok {
+		return Null{}
+	}
+	return BulkString(value)
+}
+
+func bulkStrings(values []string) Array {
+	array := make(Array, 0, len(values))
+	for _, value := range values {
+		array = append(array, BulkString(value))
+	}
+	return array
+}
+
+func integerOrError(value int, err error) Reply {
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(value)
+}
+
+func boolToInteger(value bool) Integer {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+)
+
+func init() {
+	register("sadd", -3, sadd)
+	register("srem", -3, srem)
+	register("smembers", 2, smembers)
+	register("sismember", 3, sismember)
+	register("scard", 2, scard)
+	register("spop", -2, spop)
+	register("srandmember", -2, srandmember)
+	register("sinter", -2, sinter)
+	register("sunion", -2, sunion)
+	register("sdiff", -2, sdiff)
+	register("sinterstore", -3, sinterstore)
+	register("sunionstore", -3, sunionstore)
+	register("sdiffstore", -3, sdiffstore)
+}
+
+func sadd(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SAdd(args[1], args[2:]))
+}
+
+func srem(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SRem(args[1], args[2:]))
+}
+
+func smembers(us usecase.RedisUsecase, args []string) Reply {
+	return setOrError(us.SMembers(args[1]))
+}
+
+func sismember(us usecase.RedisUsecase, args []string) Reply {
+	ok, err := us.SIsMember(args[1], args[2])
+	if err != nil {
+		return NewError(err)
+	}
+	return boolToInteger(ok)
+}
+
+func scard(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SCard(args[1]))
+}
+
+// spop replies with a single member or nil without count and with an array otherwise
+func spop(us usecase.RedisUsecase, args []string) Reply {
+	if len(args) > 3 {
+		return Error("ERR syntax error")
+	}
+
+	if len(args) == 2 {
+		members, err := us.SPop(args[1], 1)
+		if err != nil {
+			return NewError(err)
+		}
+		if len(members) == 0 {
+			return Null{}
+		}
+		return BulkString(members[0])
+	}
+
+	count, err := strconv.Atoi(args[2])
+	if err != nil || count < 0 {
+		return Error("ERR value is out of range, must be positive")
+	}
+	return setOrError(us.SPop(args[1], count))
+}
+
+func srandmember(us usecase.RedisUsecase, args []string) Reply {
+	if len(args) > 3 {
+		return Error("ERR syntax error")
+	}
+
+	if len(args) == 2 {
+		members, err := us.SRandMember(args[1], 1)
+		if err != nil {
+			return NewError(err)
+		}
+		if len(members) == 0 {
+			return Null{}
+		}
+		return BulkString(members[0])
+	}
+
+	count, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+
+	members, err := us.SRandMember(args[1], count)
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkStrings(members)
+}
+
+func sinter(us usecase.RedisUsecase, args []string) Reply {
+	return setOrError(us.SInter(args[1:]))
+}
+
+func sunion(us usecase.RedisUsecase, args []string) Reply {
+	return setOrError(us.SUnion(args[1:]))
+}
+
+func sdiff(us usecase.RedisUsecase, args []string) Reply {
+	return setOrError(us.SDiff(args[1:]))
+}
+
+func sinterstore(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SInterStore(args[1], args[2:]))
+}
+
+func sunionstore(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SUnionStore(args[1], args[2:]))
+}
+
+func sdiffstore(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.SDiffStore(args[1], args[2:]))
+}
+
+func setOrError(members []string, err error) Reply {
+	if err != nil {
+		return NewError(err)
+	}
+
+	set := make(Set, 0, len(members))
+	for _, member := range members {
+		set = append(set, BulkString(member))
+	}
+	return set
+}
@@ -0,0 +1,724 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAutoClaimCount is the number of the entries claimed by XAUTOCLAIM without COUNT
+const DefaultAutoClaimCount = 100
+
+// MaxAutoClaimCount is the largest COUNT of XAUTOCLAIM, it scans up to 10 times count entries
+const MaxAutoClaimCount = int(^uint(0)>>1) / 10
+
+func init() {
+	register("xadd", -5, xadd)
+	register("xrange", -4, xrange)
+	register("xrevrange", -4, xrevrange)
+	register("xlen", 2, xlen)
+	register("xdel", -3, xdel)
+	register("xtrim", -4, xtrim)
+	registerBlocking("xread", -4, xread)
+	register("xgroup", -2, xgroup)
+	registerBlocking("xreadgroup", -7, xreadgroup)
+	register("xack", -4, xack)
+	register("xpending", -3, xpending)
+	register("xclaim", -6, xclaim)
+	register("xautoclaim", -6, xautoclaim)
+}
+
+// xadd key [NOMKSTREAM] [MAXLEN|MINID [=|~] threshold [LIMIT count]] *|id field value [field value ...]
+func xadd(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.XAddOptions
+	i := 2
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i])
+		if option == "NOMKSTREAM" {
+			options.NoMkStream = true
+			continue
+		}
+		if option != "MAXLEN" && option != "MINID" {
+			break
+		}
+
+		trim, next, err := parseStreamTrim(args, i)
+		if err != nil {
+			return NewError(err)
+		}
+		options.Trim = trim
+		i = next - 1
+	}
+
+	// the ID is followed by at least one field and value
+	if len(args)-i < 3 || (len(args)-i)%2 == 0 {
+		return Error("ERR wrong number of arguments for 'xadd' command")
+	}
+
+	idOptions, err := ParseXAddID(args[i])
+	if err != nil {
+		return NewError(err)
+	}
+	options.ID, options.AutoID, options.AutoSeq = idOptions.ID, idOptions.AutoID, idOptions.AutoSeq
+
+	fields := make([]usecase.FieldValue, 0, (len(args)-i)/2)
+	for j := i + 1; j < len(args); j += 2 {
+		fields = append(fields, usecase.FieldValue{Field: args[j], Value: args[j+1]})
+	}
+
+	id, ok, err := us.XAdd(args[1], fields, options)
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return Null{}
+	}
+	return BulkString(id.String())
+}
+
+// ParseXAddID parses the ID of XADD: * generates the ID, ms-* generates the sequence
+func ParseXAddID(value string) (usecase.XAddOptions, error) {
+	var options usecase.XAddOptions
+	switch {
+	case value == "*":
+		options.AutoID = true
+	case strings.HasSuffix(value, "-*"):
+		ms, err := strconv.ParseUint(strings.TrimSuffix(value, "-*"), 10, 64)
+		if err != nil {
+			return options, domain.ErrStreamIDInvalid
+		}
+		options.ID.Ms = ms
+		options.AutoSeq = true
+	default:
+		id, err := ParseStreamID(value, 0)
+		if err != nil {
+			return options, err
+		}
+		options.ID = id
+	}
+	return options, nil
+}
+
+// parseStreamTrim parses MAXLEN|MINID [=|~] threshold [LIMIT count] starting at args[i]
+// and returns the index of the next argument
+func parseStreamTrim(args []string, i int) (*usecase.StreamTrim, int, error) {
+	trim := &usecase.StreamTrim{}
+	strategy := strings.ToUpper(args[i])
+	i++
+
+	if i < len(args) && (args[i] == "=" || args[i] == "~") {
+		trim.Approximate = args[i] == "~"
+		i++
+	}
+	if i == len(args) {
+		return nil, 0, domain.ErrSyntax
+	}
+
+	if strategy == "MINID" {
+		id, err := ParseStreamID(args[i], 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		trim.MinID = &id
+	} else {
+		maxLen, err := strconv.ParseInt(args[i], 10, 64)
+		if err != nil {
+			return nil, 0, domain.ErrNotInteger
+		}
+		if maxLen < 0 {
+			return nil, 0, domain.ErrStreamMaxLen
+		}
+		trim.MaxLen = maxLen
+	}
+	i++
+
+	if i+1 < len(args) && strings.ToUpper(args[i]) == "LIMIT" {
+		if !trim.Approximate {
+			return nil, 0, domain.ErrStreamTrimLimit
+		}
+		limit, err := strconv.ParseInt(args[i+1], 10, 64)
+		if err != nil || limit < 0 {
+			return nil, 0, domain.ErrNotInteger
+		}
+		trim.Limit = limit
+		i += 2
+	}
+	return trim, i, nil
+}
+
+// ParseStreamID parses ms-seq, the sequence is defaultSeq if only ms is given
+func ParseStreamID(value string, defaultSeq uint64) (usecase.StreamID, error) {
+	ms, seq := value, ""
+	if i := strings.IndexByte(value, '-'); i >= 0 {
+		ms, seq = value[:i], value[i+1:]
+	}
+
+	var id usecase.StreamID
+	var err error
+	if id.Ms, err = strconv.ParseUint(ms, 10, 64); err != nil {
+		return usecase.StreamID{}, domain.ErrStreamIDInvalid
+	}
+
+	id.Seq = defaultSeq
+	if seq != "" || strings.HasSuffix(value, "-") {
+		if id.Seq, err = strconv.ParseUint(seq, 10, 64); err != nil {
+			return usecase.StreamID{}, domain.ErrStreamIDInvalid
+		}
+	}
+	return id, nil
+}
+
+// ParseStreamInterval parses the bounds of XRANGE: - and + are the smallest and the greatest IDs,
+// ms without the sequence includes the whole millisecond and ( excludes the bound
+func ParseStreamInterval(start string, end string) (usecase.StreamID, usecase.StreamID, error) {
+	first, err := parseStreamBound(start, false)
+	if err != nil {
+		return usecase.StreamID{}, usecase.StreamID{}, err
+	}
+	last, err := parseStreamBound(end, true)
+	if err != nil {
+		return usecase.StreamID{}, usecase.StreamID{}, err
+	}
+	return first, last, nil
+}
+
+func parseStreamBound(value string, end bool) (usecase.StreamID, error) {
+	switch value {
+	case "-":
+		return usecase.StreamID{}, nil
+	case "+":
+		return usecase.StreamID{Ms: math.MaxUint64, Seq: math.MaxUint64}, nil
+	}
+
+	exclusive := strings.HasPrefix(value, "(")
+	defaultSeq := uint64(0)
+	if end {
+		defaultSeq = math.MaxUint64
+	}
+
+	id, err := ParseStreamID(strings.TrimPrefix(value, "("), defaultSeq)
+	if err != nil || !exclusive {
+		return id, err
+	}
+
+	// the exclusive bound is the next or the previous ID
+	switch {
+	case !end && id.Seq < math.MaxUint64:
+		id.Seq++
+	case !end && id.Ms < math.MaxUint64:
+		id = usecase.StreamID{Ms: id.Ms + 1}
+	case end && id.Seq > 0:
+		id.Seq--
+	case end && id.Ms > 0:
+		id = usecase.StreamID{Ms: id.Ms - 1, Seq: math.MaxUint64}
+	default:
+		return usecase.StreamID{}, domain.ErrStreamIDInvalid
+	}
+	return id, nil
+}
+
+// xrange key start end [COUNT count]
+func xrange(us usecase.RedisUsecase, args []string) Reply {
+	return streamRange(args, false, us.XRange)
+}
+
+// xrevrange key end start [COUNT count]
+func xrevrange(us usecase.RedisUsecase, args []string) Reply {
+	return streamRange(args, true, us.XRevRange)
+}
+
+func streamRange(args []string, rev bool,
+	readRange func(string, usecase.StreamID, usecase.StreamID, int) ([]usecase.StreamEntry, error)) Reply {
+	if len(args) != 4 && (len(args) != 6 || strings.ToUpper(args[4]) != "COUNT") {
+		return NewError(domain.ErrSyntax)
+	}
+
+	count := 0
+	if len(args) == 6 {
+		var err error
+		if count, err = strconv.Atoi(args[5]); err != nil {
+			return errNotInteger
+		}
+		if count <= 0 {
+			return Array{}
+		}
+	}
+
+	var entries []usecase.StreamEntry
+	if rev {
+		end, start, err := ParseStreamInterval(args[3], args[2])
+		if err != nil {
+			return NewError(err)
+		}
+		entries, err = readRange(args[1], start, end, count)
+		if err != nil {
+			return NewError(err)
+		}
+	} else {
+		start, end, err := ParseStreamInterval(args[2], args[3])
+		if err != nil {
+			return NewError(err)
+		}
+		entries, err = readRange(args[1], start, end, count)
+		if err != nil {
+			return NewError(err)
+		}
+	}
+	return streamEntries(entries)
+}
+
+func xlen(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.XLen(args[1]))
+}
+
+// xdel key id [id ...]
+func xdel(us usecase.RedisUsecase, args []string) Reply {
+	ids, err := parseStreamIDs(args[2:])
+	if err != nil {
+		return NewError(err)
+	}
+	return integerOrError(us.XDel(args[1], ids))
+}
+
+// xtrim key MAXLEN|MINID [=|~] threshold [LIMIT count]
+func xtrim(us usecase.RedisUsecase, args []string) Reply {
+	strategy := strings.ToUpper(args[2])
+	if strategy != "MAXLEN" && strategy != "MINID" {
+		return NewError(domain.ErrSyntax)
+	}
+
+	trim, next, err := parseStreamTrim(args, 2)
+	if err != nil {
+		return NewError(err)
+	}
+	if next != len(args) {
+		return NewError(domain.ErrSyntax)
+	}
+	return integerOrError(us.XTrim(args[1], *trim))
+}
+
+// xread [COUNT count] [BLOCK milliseconds] STREAMS key [key ...] id [id ...]
+func xread(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	options, streams, err := parseStreamReadOptions(args, 1, false)
+	if err != nil {
+		return NewError(err)
+	}
+
+	reads, err := parseStreamReads(args[streams:], "$", "xread")
+	if err != nil {
+		return NewError(err)
+	}
+
+	result, err := us.XRead(ctx, reads, options)
+	if err != nil {
+		return NewError(err)
+	}
+	return streamsEntries(result)
+}
+
+// xreadgroup GROUP group consumer [COUNT count] [BLOCK milliseconds] [NOACK] STREAMS key [key ...] id [id ...]
+func xreadgroup(ctx context.Context, us usecase.RedisUsecase, args []string) Reply {
+	if strings.ToUpper(args[1]) != "GROUP" {
+		return NewError(domain.ErrSyntax)
+	}
+
+	options, streams, err := parseStreamReadOptions(args, 4, true)
+	if err != nil {
+		return NewError(err)
+	}
+
+	reads, err := parseStreamReads(args[streams:], ">", "xreadgroup")
+	if err != nil {
+		return NewError(err)
+	}
+
+	result, err := us.XReadGroup(ctx, args[2], args[3], reads, options)
+	if err != nil {
+		return NewError(err)
+	}
+	return streamsEntries(result)
+}
+
+// BlockTimeout converts the BLOCK milliseconds of XREAD to the duration, it must fit into the duration
+func BlockTimeout(milliseconds int64) (time.Duration, error) {
+	if milliseconds < 0 {
+		return 0, domain.ErrTimeoutIsNegative
+	}
+	if milliseconds > math.MaxInt64/int64(time.Millisecond) {
+		return 0, domain.ErrTimeoutOutOfRange
+	}
+	return time.Duration(milliseconds) * time.Millisecond, nil
+}
+
+// parseStreamReadOptions parses the options of XREAD and XREADGROUP starting at args[i]
+// and returns the index of the first key after STREAMS
+func parseStreamReadOptions(args []string, i int, group bool) (usecase.XReadOptions, int, error) {
+	var options usecase.XReadOptions
+	for ; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i]); {
+		case option == "STREAMS":
+			return options, i + 1, nil
+		case option == "NOACK" && group:
+			options.NoAck = true
+		case (option == "COUNT" || option == "BLOCK") && i+1 < len(args):
+			value, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return options, 0, domain.ErrNotInteger
+			}
+			i++
+
+			if option == "COUNT" {
+				if value > 0 {
+					options.Count = int(value)
+				}
+				continue
+			}
+			if options.Timeout, err = BlockTimeout(value); err != nil {
+				return options, 0, err
+			}
+			options.Block = true
+		default:
+			return options, 0, domain.ErrSyntax
+		}
+	}
+	return options, 0, domain.ErrSyntax
+}
+
+// parseStreamReads parses the keys and the IDs after STREAMS, newID is the ID of the new entries
+func parseStreamReads(args []string, newID string, command string) ([]usecase.StreamRead, error) {
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, errors.New("ERR Unbalanced '" + command + "' list of streams: for each stream key an ID or '" +
+			newID + "' must be specified.")
+	}
+
+	count := len(args) / 2
+	reads := make([]usecase.StreamRead, 0, count)
+	for i, key := range args[:count] {
+		read := usecase.StreamRead{Key: key, New: args[count+i] == newID}
+		if !read.New {
+			id, err := ParseStreamID(args[count+i], 0)
+			if err != nil {
+				return nil, err
+			}
+			read.ID = id
+		}
+		reads = append(reads, read)
+	}
+	return reads, nil
+}
+
+// xgroup CREATE key group id|$ [MKSTREAM] | SETID key group id|$ | DESTROY key group |
+// CREATECONSUMER key group consumer | DELCONSUMER key group consumer
+func xgroup(us usecase.RedisUsecase, args []string) Reply {
+	subcommand := strings.ToUpper(args[1])
+	arity := map[string]int{"CREATE": 5, "SETID": 5, "DESTROY": 4, "CREATECONSUMER": 5, "DELCONSUMER": 5}[subcommand]
+	if arity == 0 {
+		return Error("ERR unknown subcommand '" + args[1] + "'")
+	}
+	if len(args) != arity && (subcommand != "CREATE" || len(args) != 6 || strings.ToUpper(args[5]) != "MKSTREAM") {
+		return Error("ERR wrong number of arguments for 'xgroup|" + strings.ToLower(subcommand) + "' command")
+	}
+
+	key, group := args[2], args[3]
+	switch subcommand {
+	case "CREATE", "SETID":
+		var id *usecase.StreamID
+		if args[4] != "$" {
+			parsed, err := ParseStreamID(args[4], 0)
+			if err != nil {
+				return NewError(err)
+			}
+			id = &parsed
+		}
+
+		var err error
+		if subcommand == "CREATE" {
+			err = us.XGroupCreate(key, group, id, len(args) == 6)
+		} else {
+			err = us.XGroupSetID(key, group, id)
+		}
+		if err != nil {
+			return NewError(err)
+		}
+		return OK
+	case "DESTROY":
+		destroyed, err := us.XGroupDestroy(key, group)
+		if err != nil {
+			return NewError(err)
+		}
+		return boolToInteger(destroyed)
+	case "CREATECONSUMER":
+		created, err := us.XGroupCreateConsumer(key, group, args[4])
+		if err != nil {
+			return NewError(err)
+		}
+		return boolToInteger(created)
+	default:
+		return integerOrError(us.XGroupDelConsumer(key, group, args[4]))
+	}
+}
+
+// xack key group id [id ...]
+func xack(us usecase.RedisUsecase, args []string) Reply {
+	ids, err := parseStreamIDs(args[3:])
+	if err != nil {
+		return NewError(err)
+	}
+	return integerOrError(us.XAck(args[1], args[2], ids))
+}
+
+// xpending key group [[IDLE min-idle-time] start end count [consumer]]
+func xpending(us usecase.RedisUsecase, args []string) Reply {
+	if len(args) == 3 {
+		summary, err := us.XPendingSummary(args[1], args[2])
+		if err != nil {
+			return NewError(err)
+		}
+		if summary.Count == 0 {
+			return Array{Integer(0), Null{}, Null{}, Null{}}
+		}
+
+		consumers := make(Array, 0, len(summary.Consumers))
+		for _, consumer := range summary.Consumers {
+			consumers = append(consumers, Array{BulkString(consumer.Name), BulkString(strconv.Itoa(consumer.Pending))})
+		}
+		return Array{
+			Integer(summary.Count),
+			BulkString(summary.Smallest.String()),
+			BulkString(summary.Greatest.String()),
+			consumers,
+		}
+	}
+
+	var options usecase.XPendingOptions
+	i := 3
+	if strings.ToUpper(args[i]) == "IDLE" && len(args) > 4 {
+		idle, err := parseMilliseconds(args[4])
+		if err != nil {
+			return NewError(err)
+		}
+		options.MinIdle = idle
+		i = 5
+	}
+	if len(args)-i != 3 && len(args)-i != 4 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	var err error
+	if options.Start, options.End, err = ParseStreamInterval(args[i], args[i+1]); err != nil {
+		return NewError(err)
+	}
+	if options.Count, err = strconv.Atoi(args[i+2]); err != nil {
+		return errNotInteger
+	}
+	if len(args)-i == 4 {
+		options.Consumer = args[i+3]
+	}
+
+	entries, err := us.XPending(args[1], args[2], options)
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, Array{
+			BulkString(entry.ID.String()),
+			BulkString(entry.Consumer),
+			Integer(entry.Idle / time.Millisecond),
+			Integer(entry.Deliveries),
+		})
+	}
+	return result
+}
+
+// xclaim key group consumer min-idle-time id [id ...] [IDLE ms] [TIME unix-time-milliseconds] [RETRYCOUNT count]
+// [FORCE] [JUSTID] [LASTID lastid]
+func xclaim(us usecase.RedisUsecase, args []string) Reply {
+	minIdle, err := parseMilliseconds(args[4])
+	if err != nil {
+		return NewError(err)
+	}
+
+	// the IDs are followed by the options
+	i := 5
+	var ids []usecase.StreamID
+	for ; i < len(args); i++ {
+		id, err := ParseStreamID(args[i], 0)
+		if err != nil {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return NewError(domain.ErrStreamIDInvalid)
+	}
+
+	var options usecase.XClaimOptions
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i])
+		switch {
+		case option == "FORCE":
+			options.Force = true
+			continue
+		case option == "JUSTID":
+			options.JustID = true
+			continue
+		case i+1 == len(args):
+			return NewError(domain.ErrSyntax)
+		}
+
+		i++
+		switch option {
+		case "IDLE":
+			idle, err := parseMilliseconds(args[i])
+			if err != nil {
+				return NewError(err)
+			}
+			options.Idle = &idle
+		case "TIME":
+			ms, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInteger
+			}
+			at := time.Unix(0, ms*int64(time.Millisecond))
+			options.Time = &at
+		case "RETRYCOUNT":
+			count, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInteger
+			}
+			options.RetryCount = &count
+		case "LASTID":
+			id, err := ParseStreamID(args[i], 0)
+			if err != nil {
+				return NewError(err)
+			}
+			options.LastID = &id
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	entries, err := us.XClaim(args[1], args[2], args[3], minIdle, ids, options)
+	if err != nil {
+		return NewError(err)
+	}
+	if options.JustID {
+		return streamEntryIDs(entries)
+	}
+	return streamEntries(entries)
+}
+
+// xautoclaim key group consumer min-idle-time start [COUNT count] [JUSTID]
+func xautoclaim(us usecase.RedisUsecase, args []string) Reply {
+	minIdle, err := parseMilliseconds(args[4])
+	if err != nil {
+		return NewError(err)
+	}
+	start, err := ParseStreamID(args[5], 0)
+	if err != nil {
+		return NewError(err)
+	}
+
+	count, justID := DefaultAutoClaimCount, false
+	for i := 6; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "JUSTID":
+			justID = true
+		case "COUNT":
+			if i+1 == len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			i++
+			if count, err = strconv.Atoi(args[i]); err != nil || count < 1 || count > MaxAutoClaimCount {
+				return Error("ERR COUNT must be > 0")
+			}
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	result, err := us.XAutoClaim(args[1], args[2], args[3], minIdle, start, count, justID)
+	if err != nil {
+		return NewError(err)
+	}
+
+	claimed := streamEntries(result.Claimed)
+	if justID {
+		claimed = streamEntryIDs(result.Claimed)
+	}
+	deleted := make(Array, 0, len(result.Deleted))
+	for _, id := range result.Deleted {
+		deleted = append(deleted, BulkString(id.String()))
+	}
+	return Array{BulkString(result.Next.String()), claimed, deleted}
+}
+
+func parseStreamIDs(args []string) ([]usecase.StreamID, error) {
+	ids := make([]usecase.StreamID, 0, len(args))
+	for _, arg := range args {
+		id, err := ParseStreamID(arg, 0)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseMilliseconds parses a duration in milliseconds, negative durations are 0
+func parseMilliseconds(value string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, domain.ErrNotInteger
+	}
+	if ms < 0 {
+		ms = 0
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// streamEntries replies with the ID and the fields of every entry, the fields of a deleted entry are nil
+func streamEntries(entries []usecase.StreamEntry) Array {
+	result := make(Array, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Fields == nil {
+			result = append(result, Array{BulkString(entry.ID.String()), Null{}})
+			continue
+		}
+
+		fields := make(Array, 0, 2*len(entry.Fields))
+		for _, field := range entry.Fields {
+			fields = append(fields, BulkString(field.Field), BulkString(field.Value))
+		}
+		result = append(result, Array{BulkString(entry.ID.String()), fields})
+	}
+	return result
+}
+
+func streamEntryIDs(entries []usecase.StreamEntry) Array {
+	result := make(Array, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, BulkString(entry.ID.String()))
+	}
+	return result
+}
+
+// streamsEntries replies with the key and the entries of every stream or with nil if there are none
+func streamsEntries(streams []usecase.StreamEntries) Reply {
+	if len(streams) == 0 {
+		return NullArray{}
+	}
+
+	result := make(Array, 0, len(streams))
+	for _, s := range streams {
+		result = append(result, Array{BulkString(s.Key), streamEntries(s.Entries)})
+	}
+	return result
+}
@@ -0,0 +1,306 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	register("get", 2, get)
+	register("set", -3, set)
+	register("setex", 4, setex)
+	register("psetex", 4, psetex)
+	register("getex", -2, getex)
+	register("getdel", 2, getdel)
+	register("mget", -2, mget)
+	register("mset", -3, mset)
+	register("msetnx", -3, msetnx)
+	register("incr", 2, incr)
+	register("decr", 2, decr)
+	register("incrby", 3, incrby)
+	register("decrby", 3, decrby)
+	register("incrbyfloat", 3, incrbyfloat)
+	register("append", 3, appendString)
+	register("strlen", 2, strlen)
+	register("getrange", 4, getrange)
+	register("setrange", 4, setrange)
+}
+
+func get(us usecase.RedisUsecase, args []string) Reply {
+	value, ok, err := us.Get(args[1])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+// set key value [NX|XX] [GET] [EX seconds|PX milliseconds|EXAT timestamp|PXAT milliseconds-timestamp|KEEPTTL]
+func set(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.SetOptions
+	withExpiry := false
+	for i := 3; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i]); option {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		case "GET":
+			options.Get = true
+		case "KEEPTTL":
+			options.KeepTTL = true
+		case "EX", "PX", "EXAT", "PXAT":
+			if withExpiry || i+1 == len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			expiry, err := parseExpiry(option, args[i+1])
+			if err != nil {
+				return expiryError(err, "set")
+			}
+			options.Expiry = expiry
+			withExpiry = true
+			i++
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	if (options.NX && options.XX) || (options.KeepTTL && withExpiry) {
+		return NewError(domain.ErrSyntax)
+	}
+
+	result, err := us.Set(args[1], args[2], options)
+	if err != nil {
+		return NewError(err)
+	}
+	if options.Get {
+		return bulkOrNull(result.Old, result.OldExists)
+	}
+	if !result.Written {
+		return Null{}
+	}
+	return OK
+}
+
+// setex key seconds value
+func setex(us usecase.RedisUsecase, args []string) Reply {
+	return setWithExpiry(us, args, "EX")
+}
+
+// psetex key milliseconds value
+func psetex(us usecase.RedisUsecase, args []string) Reply {
+	return setWithExpiry(us, args, "PX")
+}
+
+func setWithExpiry(us usecase.RedisUsecase, args []string, unit string) Reply {
+	expiry, err := parseExpiry(unit, args[2])
+	if err != nil {
+		return expiryError(err, strings.ToLower(args[0]))
+	}
+
+	if _, err := us.Set(args[1], args[3], usecase.SetOptions{Expiry: expiry}); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// getex key [EX seconds|PX milliseconds|EXAT timestamp|PXAT milliseconds-timestamp|PERSIST]
+func getex(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.GetExOptions
+	switch {
+	case len(args) == 2:
+	case len(args) == 3 && strings.ToUpper(args[2]) == "PERSIST":
+		options.Persist = true
+	case len(args) == 4:
+		expiry, err := parseExpiry(strings.ToUpper(args[2]), args[3])
+		if err != nil {
+			return expiryError(err, "getex")
+		}
+		options.Expiry = expiry
+	default:
+		return NewError(domain.ErrSyntax)
+	}
+
+	value, ok, err := us.GetEx(args[1], options)
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func getdel(us usecase.RedisUsecase, args []string) Reply {
+	value, ok, err := us.GetDel(args[1])
+	if err != nil {
+		return NewError(err)
+	}
+	return bulkOrNull(value, ok)
+}
+
+func mget(us usecase.RedisUsecase, args []string) Reply {
+	values := us.MGet(args[1:])
+
+	result := make(Array, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			result = append(result, Null{})
+		} else {
+			result = append(result, BulkString(*value))
+		}
+	}
+	return result
+}
+
+func mset(us usecase.RedisUsecase, args []string) Reply {
+	pairs, ok := keyValuePairs(args)
+	if !ok {
+		return Error("ERR wrong number of arguments for 'mset' command")
+	}
+
+	us.MSet(pairs)
+	return OK
+}
+
+func msetnx(us usecase.RedisUsecase, args []string) Reply {
+	pairs, ok := keyValuePairs(args)
+	if !ok {
+		return Error("ERR wrong number of arguments for 'msetnx' command")
+	}
+	return boolToInteger(us.MSetNX(pairs))
+}
+
+// keyValuePairs groups the arguments after the command name in pairs, the flag is false if one is incomplete
+func keyValuePairs(args []string) ([]usecase.KeyValue, bool) {
+	if len(args)%2 == 0 {
+		return nil, false
+	}
+
+	pairs := make([]usecase.KeyValue, 0, len(args)/2)
+	for i := 1; i < len(args); i += 2 {
+		pairs = append(pairs, usecase.KeyValue{Key: args[i], Value: args[i+1]})
+	}
+	return pairs, true
+}
+
+func parseExpiry(option string, value string) (time.Time, error) {
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, domain.ErrNotInteger
+	}
+	return Expiry(option, amount, time.Now())
+}
+
+// expiryError names the command in the invalid expire time error as Redis does
+func expiryError(err error, command string) Reply {
+	if err == domain.ErrInvalidExpireTime {
+		return Error("ERR invalid expire time in '" + command + "' command")
+	}
+	return NewError(err)
+}
+
+// Expiry converts the amount of the EX, PX, EXAT or PXAT option to the expiration time,
+// the amount must be positive
+func Expiry(option string, amount int64, now time.Time) (time.Time, error) {
+	if amount <= 0 {
+		return time.Time{}, domain.ErrInvalidExpireTime
+	}
+
+	switch option {
+	case "EX":
+		if amount > math.MaxInt64/int64(time.Second) {
+			return time.Time{}, domain.ErrInvalidExpireTime
+		}
+		return now.Add(time.Duration(amount) * time.Second), nil
+	case "PX":
+		if amount > math.MaxInt64/int64(time.Millisecond) {
+			return time.Time{}, domain.ErrInvalidExpireTime
+		}
+		return now.Add(time.Duration(amount) * time.Millisecond), nil
+	case "EXAT":
+		return time.Unix(amount, 0), nil
+	case "PXAT":
+		return time.Unix(amount/1000, amount%1000*int64(time.Millisecond)), nil
+	default:
+		return time.Time{}, domain.ErrSyntax
+	}
+}
+
+func incr(us usecase.RedisUsecase, args []string) Reply {
+	return incrementBy(us, args[1], 1)
+}
+
+func decr(us usecase.RedisUsecase, args []string) Reply {
+	return incrementBy(us, args[1], -1)
+}
+
+func incrby(us usecase.RedisUsecase, args []string) Reply {
+	increment, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errNotInteger
+	}
+	return incrementBy(us, args[1], increment)
+}
+
+func decrby(us usecase.RedisUsecase, args []string) Reply {
+	decrement, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errNotInteger
+	}
+	if decrement == math.MinInt64 {
+		return Error("ERR decrement would overflow")
+	}
+	return incrementBy(us, args[1], -decrement)
+}
+
+func incrementBy(us usecase.RedisUsecase, key string, increment int64) Reply {
+	value, err := us.IncrBy(key, increment)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(value)
+}
+
+// incrbyfloat replies with a bulk string as Redis does, not with a double
+func incrbyfloat(us usecase.RedisUsecase, args []string) Reply {
+	increment, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || math.IsNaN(increment) || math.IsInf(increment, 0) {
+		return NewError(domain.ErrNotFloat)
+	}
+
+	value, err := us.IncrByFloat(args[1], increment)
+	if err != nil {
+		return NewError(err)
+	}
+	return BulkString(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func appendString(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.Append(args[1], args[2]))
+}
+
+func strlen(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.StrLen(args[1]))
+}
+
+func getrange(us usecase.RedisUsecase, args []string) Reply {
+	start, end, err := parseIntegers(args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+
+	value, err := us.GetRange(args[1], start, end)
+	if err != nil {
+		return NewError(err)
+	}
+	return BulkString(value)
+}
+
+func setrange(us usecase.RedisUsecase, args []string) Reply {
+	offset, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+	return integerOrError(us.SetRange(args[1], offset, args[3]))
+}
@@ -0,0 +1,286 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	register("ts.create", -2, tsCreate)
+	register("ts.add", -4, tsAdd)
+	register("ts.madd", -4, tsMAdd)
+	register("ts.range", -4, tsRange)
+	register("ts.revrange", -4, tsRevRange)
+	register("ts.mrange", -5, tsMRange)
+	register("ts.createrule", 6, tsCreateRule)
+	register("ts.deleterule", 3, tsDeleteRule)
+}
+
+// ParseTSTimestamp parses the timestamp of a sample in milliseconds, * is the current time
+func ParseTSTimestamp(value string) (int64, error) {
+	if value == "*" {
+		return time.Now().UnixNano() / int64(time.Millisecond), nil
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || timestamp < 0 {
+		return 0, domain.ErrTSTimestamp
+	}
+	return timestamp, nil
+}
+
+// ParseTSBound parses the bound of a range: - is the earliest sample and + is the latest one
+func ParseTSBound(value string) (int64, error) {
+	switch value {
+	case "-":
+		return 0, nil
+	case "+":
+		return math.MaxInt64, nil
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, domain.ErrTSTimestamp
+	}
+	return timestamp, nil
+}
+
+// parseTSCreateOptions parses [RETENTION retention] [LABELS label value ...] starting at args[i],
+// LABELS takes the rest of the arguments
+func parseTSCreateOptions(args []string, i int) (usecase.TSCreateOptions, error) {
+	var options usecase.TSCreateOptions
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "RETENTION":
+			if i+1 == len(args) {
+				return options, domain.ErrSyntax
+			}
+			i++
+			retention, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return options, domain.ErrTSRetention
+			}
+			options.Retention = retention
+		case "LABELS":
+			pairs := args[i+1:]
+			if len(pairs) == 0 || len(pairs)%2 != 0 {
+				return options, domain.ErrSyntax
+			}
+			for j := 0; j < len(pairs); j += 2 {
+				options.Labels = append(options.Labels, usecase.TSLabel{Name: pairs[j], Value: pairs[j+1]})
+			}
+			return options, nil
+		default:
+			return options, domain.ErrSyntax
+		}
+	}
+	return options, nil
+}
+
+// ts.create key [RETENTION retention] [LABELS label value ...]
+func tsCreate(us usecase.RedisUsecase, args []string) Reply {
+	options, err := parseTSCreateOptions(args, 2)
+	if err != nil {
+		return NewError(err)
+	}
+
+	if err := us.TSCreate(args[1], options); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+func parseTSSample(timestamp string, value string) (usecase.TSSample, error) {
+	var sample usecase.TSSample
+
+	var err error
+	if sample.Timestamp, err = ParseTSTimestamp(timestamp); err != nil {
+		return sample, err
+	}
+	if sample.Value, err = strconv.ParseFloat(value, 64); err != nil {
+		return sample, domain.ErrTSValue
+	}
+	return sample, nil
+}
+
+// ts.add key timestamp value [RETENTION retention] [LABELS label value ...]
+func tsAdd(us usecase.RedisUsecase, args []string) Reply {
+	sample, err := parseTSSample(args[2], args[3])
+	if err != nil {
+		return NewError(err)
+	}
+	options, err := parseTSCreateOptions(args, 4)
+	if err != nil {
+		return NewError(err)
+	}
+
+	timestamp, err := us.TSAdd(args[1], sample, options)
+	if err != nil {
+		return NewError(err)
+	}
+	return Integer(timestamp)
+}
+
+// ts.madd key timestamp value [key timestamp value ...]
+func tsMAdd(us usecase.RedisUsecase, args []string) Reply {
+	triples := args[1:]
+	if len(triples)%3 != 0 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	samples := make([]usecase.TSKeySample, 0, len(triples)/3)
+	for i := 0; i < len(triples); i += 3 {
+		sample, err := parseTSSample(triples[i+1], triples[i+2])
+		if err != nil {
+			return NewError(err)
+		}
+		samples = append(samples, usecase.TSKeySample{Key: triples[i], TSSample: sample})
+	}
+
+	errs := us.TSMAdd(samples)
+	result := make(Array, 0, len(samples))
+	for i, err := range errs {
+		if err != nil {
+			result = append(result, NewError(err))
+			continue
+		}
+		result = append(result, Integer(samples[i].Timestamp))
+	}
+	return result
+}
+
+// parseTSRange parses from to [WITHLABELS] [COUNT count] [AGGREGATION type bucketDuration] starting at args[i],
+// WITHLABELS is allowed only with mrange. It returns the index of the first argument which isn't an option.
+func parseTSRange(args []string, i int, mrange bool) (int64, int64, usecase.TSRangeOptions, int, error) {
+	var options usecase.TSRangeOptions
+
+	from, err := ParseTSBound(args[i])
+	if err != nil {
+		return 0, 0, options, 0, err
+	}
+	to, err := ParseTSBound(args[i+1])
+	if err != nil {
+		return 0, 0, options, 0, err
+	}
+
+	for i += 2; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WITHLABELS":
+			if !mrange {
+				return 0, 0, options, 0, domain.ErrSyntax
+			}
+			options.WithLabels = true
+		case "COUNT":
+			if i+1 == len(args) {
+				return 0, 0, options, 0, domain.ErrSyntax
+			}
+			i++
+			if options.Count, err = strconv.Atoi(args[i]); err != nil || options.Count < 0 {
+				return 0, 0, options, 0, domain.ErrNotInteger
+			}
+		case "AGGREGATION":
+			if i+2 >= len(args) {
+				return 0, 0, options, 0, domain.ErrSyntax
+			}
+			bucketDuration, err := strconv.ParseInt(args[i+2], 10, 64)
+			if err != nil {
+				return 0, 0, options, 0, domain.ErrTSBucketDuration
+			}
+			options.Aggregation = &usecase.TSAggregation{Type: args[i+1], BucketDuration: bucketDuration}
+			i += 2
+		default:
+			return from, to, options, i, nil
+		}
+	}
+	return from, to, options, i, nil
+}
+
+// ts.range key from to [COUNT count] [AGGREGATION type bucketDuration]
+func tsRange(us usecase.RedisUsecase, args []string) Reply {
+	return tsRangeOf(us.TSRange, args)
+}
+
+// ts.revrange key from to [COUNT count] [AGGREGATION type bucketDuration]
+func tsRevRange(us usecase.RedisUsecase, args []string) Reply {
+	return tsRangeOf(us.TSRevRange, args)
+}
+
+func tsRangeOf(query func(key string, from int64, to int64, options usecase.TSRangeOptions) ([]usecase.TSSample, error), args []string) Reply {
+	from, to, options, i, err := parseTSRange(args, 2, false)
+	if err != nil {
+		return NewError(err)
+	}
+	if i != len(args) {
+		return NewError(domain.ErrSyntax)
+	}
+
+	samples, err := query(args[1], from, to, options)
+	if err != nil {
+		return NewError(err)
+	}
+	return tsSamples(samples)
+}
+
+// tsSamples converts the samples to the [timestamp, value] pairs
+func tsSamples(samples []usecase.TSSample) Array {
+	result := make(Array, 0, len(samples))
+	for _, sample := range samples {
+		result = append(result, Array{Integer(sample.Timestamp), Double(sample.Value)})
+	}
+	return result
+}
+
+// ts.mrange from to [WITHLABELS] [COUNT count] [AGGREGATION type bucketDuration] FILTER filter ...
+func tsMRange(us usecase.RedisUsecase, args []string) Reply {
+	from, to, options, i, err := parseTSRange(args, 1, true)
+	if err != nil {
+		return NewError(err)
+	}
+	if i+1 >= len(args) || strings.ToUpper(args[i]) != "FILTER" {
+		return NewError(domain.ErrSyntax)
+	}
+
+	series, err := us.TSMRange(from, to, args[i+1:], options)
+	if err != nil {
+		return NewError(err)
+	}
+
+	result := make(Array, 0, len(series))
+	for _, s := range series {
+		labels := make(Array, 0, len(s.Labels))
+		for _, label := range s.Labels {
+			labels = append(labels, Array{BulkString(label.Name), BulkString(label.Value)})
+		}
+		result = append(result, Array{BulkString(s.Key), labels, tsSamples(s.Samples)})
+	}
+	return result
+}
+
+// ts.createrule sourceKey destKey AGGREGATION type bucketDuration
+func tsCreateRule(us usecase.RedisUsecase, args []string) Reply {
+	if strings.ToUpper(args[3]) != "AGGREGATION" {
+		return NewError(domain.ErrSyntax)
+	}
+	bucketDuration, err := strconv.ParseInt(args[5], 10, 64)
+	if err != nil {
+		return NewError(domain.ErrTSBucketDuration)
+	}
+
+	aggregation := usecase.TSAggregation{Type: args[4], BucketDuration: bucketDuration}
+	if err := us.TSCreateRule(args[1], args[2], aggregation); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
+
+// ts.deleterule sourceKey destKey
+func tsDeleteRule(us usecase.RedisUsecase, args []string) Reply {
+	if err := us.TSDeleteRule(args[1], args[2]); err != nil {
+		return NewError(err)
+	}
+	return OK
+}
@@ -0,0 +1,344 @@
+package command
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register("zadd", -4, zadd)
+	register("zrem", -3, zrem)
+	register("zscore", 3, zscore)
+	register("zincrby", 4, zincrby)
+	register("zcard", 2, zcard)
+	register("zrank", 3, zrank)
+	register("zrevrank", 3, zrevrank)
+	register("zcount", 4, zcount)
+	register("zrange", -4, zrange)
+	register("zrevrange", -4, zrevrange)
+	register("zrangebyscore", -4, zrangebyscore)
+	register("zrevrangebyscore", -4, zrevrangebyscore)
+	register("zrangebylex", -4, zrangebylex)
+	register("zrevrangebylex", -4, zrevrangebylex)
+	register("zpopmin", -2, zpopmin)
+	register("zpopmax", -2, zpopmax)
+	register("zunionstore", -4, zunionstore)
+	register("zinterstore", -4, zinterstore)
+}
+
+// zadd key [NX|XX] [GT|LT] [CH] [INCR] score member [score member ...]
+func zadd(us usecase.RedisUsecase, args []string) Reply {
+	var options usecase.ZAddOptions
+	incr := false
+
+	i := 2
+options:
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		case "GT":
+			options.GT = true
+		case "LT":
+			options.LT = true
+		case "CH":
+			options.CH = true
+		case "INCR":
+			incr = true
+		default:
+			break options
+		}
+	}
+
+	pairs := args[i:]
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return NewError(domain.ErrSyntax)
+	}
+	if incr && len(pairs) != 2 {
+		return Error("ERR INCR option supports a single increment-element pair")
+	}
+
+	members := make([]usecase.ScoredMember, 0, len(pairs)/2)
+	for j := 0; j < len(pairs); j += 2 {
+		score, err := parseScore(pairs[j])
+		if err != nil {
+			return NewError(err)
+		}
+		members = append(members, usecase.ScoredMember{Member: pairs[j+1], Score: score})
+	}
+
+	if incr {
+		score, ok, err := us.ZAddIncr(args[1], members[0].Member, members[0].Score, options)
+		if err != nil {
+			return NewError(err)
+		}
+		if !ok {
+			return Null{}
+		}
+		return Double(score)
+	}
+	return integerOrError(us.ZAdd(args[1], members, options))
+}
+
+func zrem(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.ZRem(args[1], args[2:]))
+}
+
+func zscore(us usecase.RedisUsecase, args []string) Reply {
+	score, ok, err := us.ZScore(args[1], args[2])
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return Null{}
+	}
+	return Double(score)
+}
+
+func zincrby(us usecase.RedisUsecase, args []string) Reply {
+	increment, err := parseScore(args[2])
+	if err != nil {
+		return NewError(err)
+	}
+
+	score, err := us.ZIncrBy(args[1], increment, args[3])
+	if err != nil {
+		return NewError(err)
+	}
+	return Double(score)
+}
+
+func zcard(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.ZCard(args[1]))
+}
+
+func zrank(us usecase.RedisUsecase, args []string) Reply {
+	return rankOrNull(us.ZRank(args[1], args[2]))
+}
+
+func zrevrank(us usecase.RedisUsecase, args []string) Reply {
+	return rankOrNull(us.ZRevRank(args[1], args[2]))
+}
+
+func rankOrNull(rank int, ok bool, err error) Reply {
+	if err != nil {
+		return NewError(err)
+	}
+	if !ok {
+		return Null{}
+	}
+	return Integer(rank)
+}
+
+func zcount(us usecase.RedisUsecase, args []string) Reply {
+	return integerOrError(us.ZCount(args[1], args[2], args[3]))
+}
+
+// zrange key start stop [BYSCORE|BYLEX] [REV] [LIMIT offset count] [WITHSCORES]
+func zrange(us usecase.RedisUsecase, args []string) Reply {
+	query := usecase.ZRangeQuery{Start: args[2], Stop: args[3]}
+	withScores := false
+
+	for i := 4; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "BYSCORE":
+			query.By = usecase.ZRangeByScore
+		case "BYLEX":
+			query.By = usecase.ZRangeByLex
+		case "REV":
+			query.Rev = true
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			limit, err := parseLimit(args[i+1:])
+			if err != nil {
+				return NewError(err)
+			}
+			query.Limit = limit
+			i += 2
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+
+	if withScores && query.By == usecase.ZRangeByLex {
+		return Error("ERR syntax error, WITHSCORES not supported in combination with BYLEX")
+	}
+	members, err := us.ZRange(args[1], query)
+	return scoredMembersOrError(members, err, withScores)
+}
+
+// zrevrange key start stop [WITHSCORES]
+func zrevrange(us usecase.RedisUsecase, args []string) Reply {
+	return zrangeWithOptions(us, args, usecase.ZRangeByIndex, true)
+}
+
+// zrangebyscore key min max [WITHSCORES] [LIMIT offset count]
+func zrangebyscore(us usecase.RedisUsecase, args []string) Reply {
+	return zrangeWithOptions(us, args, usecase.ZRangeByScore, false)
+}
+
+// zrevrangebyscore key max min [WITHSCORES] [LIMIT offset count]
+func zrevrangebyscore(us usecase.RedisUsecase, args []string) Reply {
+	return zrangeWithOptions(us, args, usecase.ZRangeByScore, true)
+}
+
+// zrangebylex key min max [LIMIT offset count]
+func zrangebylex(us usecase.RedisUsecase, args []string) Reply {
+	return zrangeWithOptions(us, args, usecase.ZRangeByLex, false)
+}
+
+// zrevrangebylex key max min [LIMIT offset count]
+func zrevrangebylex(us usecase.RedisUsecase, args []string) Reply {
+	return zrangeWithOptions(us, args, usecase.ZRangeByLex, true)
+}
+
+// zrangeWithOptions serves the legacy range commands, which accept WITHSCORES
+// (except the lex ones) and LIMIT (except ZREVRANGE)
+func zrangeWithOptions(us usecase.RedisUsecase, args []string, by usecase.ZRangeBy, rev bool) Reply {
+	query := usecase.ZRangeQuery{Start: args[2], Stop: args[3], By: by, Rev: rev}
+	withScores := false
+
+	for i := 4; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i]); {
+		case option == "WITHSCORES" && by != usecase.ZRangeByLex:
+			withScores = true
+		case option == "LIMIT" && by != usecase.ZRangeByIndex:
+			limit, err := parseLimit(args[i+1:])
+			if err != nil {
+				return NewError(err)
+			}
+			query.Limit = limit
+			i += 2
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+	members, err := us.ZRange(args[1], query)
+	return scoredMembersOrError(members, err, withScores)
+}
+
+func parseLimit(args []string) (*usecase.ZRangeLimit, error) {
+	if len(args) < 2 {
+		return nil, domain.ErrSyntax
+	}
+
+	offset, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, domain.ErrNotInteger
+	}
+	count, err := strconv.Atoi(args[1])
+	if err != nil {
+		return nil, domain.ErrNotInteger
+	}
+	return &usecase.ZRangeLimit{Offset: offset, Count: count}, nil
+}
+
+// zpopmin key [count]
+func zpopmin(us usecase.RedisUsecase, args []string) Reply {
+	return zpop(args, us.ZPopMin)
+}
+
+// zpopmax key [count]
+func zpopmax(us usecase.RedisUsecase, args []string) Reply {
+	return zpop(args, us.ZPopMax)
+}
+
+func zpop(args []string, pop func(key string, count int) ([]usecase.ScoredMember, error)) Reply {
+	if len(args) > 3 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	count := 1
+	if len(args) == 3 {
+		var err error
+		count, err = strconv.Atoi(args[2])
+		if err != nil || count < 0 {
+			return Error("ERR value is out of range, must be positive")
+		}
+	}
+	members, err := pop(args[1], count)
+	return scoredMembersOrError(members, err, true)
+}
+
+// zunionstore destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
+func zunionstore(us usecase.RedisUsecase, args []string) Reply {
+	return zstore(args, us.ZUnionStore)
+}
+
+// zinterstore destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
+func zinterstore(us usecase.RedisUsecase, args []string) Reply {
+	return zstore(args, us.ZInterStore)
+}
+
+func zstore(args []string, store func(destination string, keys []string, options usecase.ZStoreOptions) (int, error)) Reply {
+	numKeys, err := strconv.Atoi(args[2])
+	if err != nil {
+		return errNotInteger
+	}
+	if numKeys < 1 {
+		return Error("ERR at least 1 input key is needed for " + strings.ToUpper(args[0]))
+	}
+	if numKeys > len(args)-3 {
+		return NewError(domain.ErrSyntax)
+	}
+
+	keys := args[3 : 3+numKeys]
+	var options usecase.ZStoreOptions
+	for i := 3 + numKeys; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "WEIGHTS":
+			if i+numKeys >= len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			options.Weights = make([]float64, 0, numKeys)
+			for _, arg := range args[i+1 : i+1+numKeys] {
+				weight, err := strconv.ParseFloat(arg, 64)
+				if err != nil || math.IsNaN(weight) {
+					return Error("ERR weight value is not a float")
+				}
+				options.Weights = append(options.Weights, weight)
+			}
+			i += numKeys
+		case "AGGREGATE":
+			if i+1 >= len(args) {
+				return NewError(domain.ErrSyntax)
+			}
+			options.Aggregate = args[i+1]
+			i++
+		default:
+			return NewError(domain.ErrSyntax)
+		}
+	}
+	return integerOrError(store(args[1], keys, options))
+}
+
+// parseScore parses a score, "inf" and "-inf" included
+func parseScore(value string) (float64, error) {
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(score) {
+		return 0, domain.ErrNotFloat
+	}
+	return score, nil
+}
+
+// scoredMembersOrError replies with the members, followed by their scores if withScores is set
+func scoredMembersOrError(members []usecase.ScoredMember, err error, withScores bool) Reply {
+	if err != nil {
+		return NewError(err)
+	}
+
+	array := make(Array, 0, len(members)*2)
+	for _, member := range members {
+		array = append(array, BulkString(member.Member))
+		if withScores {
+			array = append(array, Double(member.Score))
+		}
+	}
+	return array
+}
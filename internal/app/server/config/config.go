@@ -1,18 +1,59 @@
 package config
 
 import (
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
+	"os"
+	"strconv"
 )
 
+const defaultUnixSocketPerm = 0660
+
 type Config struct {
 	Server struct {
 		Port string
 	}
+	Resp struct {
+		Port string
+	}
+	Grpc struct {
+		Port string
+	}
+	// Unix sockets are disabled when their paths are empty
+	Unix struct {
+		HttpSocket string
+		RespSocket string
+		Perm       os.FileMode
+	}
+	// TLS is enabled for the TCP listeners when CertFile is set,
+	// ClientCAFile additionally enables verification of client certificates (mutual TLS)
+	TLS struct {
+		CertFile     string
+		KeyFile      string
+		ClientCAFile string
+	}
 }
 
 func Init() Config {
 	var config Config
 	viper.AutomaticEnv()
 	config.Server.Port = viper.GetString("SERVER_PORT")
+	config.Resp.Port = viper.GetString("RESP_PORT")
+	config.Grpc.Port = viper.GetString("GRPC_PORT")
+
+	config.Unix.HttpSocket = viper.GetString("UNIX_HTTP_SOCKET")
+	config.Unix.RespSocket = viper.GetString("UNIX_RESP_SOCKET")
+	config.Unix.Perm = defaultUnixSocketPerm
+	if perm := viper.GetString("UNIX_SOCKET_PERM"); perm != "" {
+		value, err := strconv.ParseUint(perm, 8, 32)
+		if err != nil {
+			log.Fatal().Msgf("invalid UNIX_SOCKET_PERM %q: %s", perm, err)
+		}
+		config.Unix.Perm = os.FileMode(value)
+	}
+
+	config.TLS.CertFile = viper.GetString("TLS_CERT_FILE")
+	config.TLS.KeyFile = viper.GetString("TLS_KEY_FILE")
+	config.TLS.ClientCAFile = viper.GetString("TLS_CLIENT_CA_FILE")
 	return config
 }
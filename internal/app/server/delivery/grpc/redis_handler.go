@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/grpc/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"regexp/syntax"
+	"strconv"
+)
+
+const (
+	defaultScanBatchSize = 100
+	// maxScanBatchSize bounds the batch which is allocated up front for every message
+	maxScanBatchSize = 10000
+)
+
+// CacheServer represent the grpc handler for redis cache
+type CacheServer struct {
+	api.UnimplementedCacheServiceServer
+	RedisUsecase usecase.RedisUsecase
+}
+
+func (s *CacheServer) GetString(_ context.Context, request *api.GetStringRequest) (*api.ValueResponse, error) {
+	value, ok, err := s.RedisUsecase.Get(request.Key)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if !ok {
+		return nil, status.Error(codes.NotFound, "key is not found")
+	}
+
+	return &api.ValueResponse{Value: value}, nil
+}
+
+func (s *CacheServer) SetString(_ context.Context, request *api.SetStringRequest) (*emptypb.Empty, error) {
+	s.RedisUsecase.Set(request.Key, request.Value, usecase.SetOptions{})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CacheServer) GetValueByFieldInMap(_ context.Context, request *api.GetValueByFieldRequest) (*api.ValueResponse, error) {
+	value, ok, err := s.RedisUsecase.HGet(request.Key, request.Field)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if !ok {
+		return nil, status.Error(codes.NotFound, "key or field is not found")
+	}
+
+	return &api.ValueResponse{Value: value}, nil
+}
+
+func (s *CacheServer) SetFieldAndValueInMap(_ context.Context, request *api.SetFieldAndValueRequest) (*api.SetFieldAndValueResponse, error) {
+	pairs := make([]usecase.FieldValue, 0, len(request.Pairs))
+	for _, pair := range request.Pairs {
+		pairs = append(pairs, usecase.FieldValue{Field: pair.Field, Value: pair.Value})
+	}
+
+	count, err := s.RedisUsecase.HSet(request.Key, pairs)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &api.SetFieldAndValueResponse{Count: int64(count)}, nil
+}
+
+func (s *CacheServer) GetFromList(_ context.Context, request *api.GetFromListRequest) (*api.ValueResponse, error) {
+	value, err := s.RedisUsecase.LGet(request.Key, int(request.Index))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &api.ValueResponse{Value: value}, nil
+}
+
+func (s *CacheServer) PushToList(_ context.Context, request *api.PushToListRequest) (*api.PushToListResponse, error) {
+	size, err := s.RedisUsecase.LPush(request.Key, request.Values)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &api.PushToListResponse{Size: int64(size)}, nil
+}
+
+func (s *CacheServer) SetValueInList(_ context.Context, request *api.SetValueInListRequest) (*emptypb.Empty, error) {
+	err := s.RedisUsecase.LSet(request.Key, int(request.Index), request.Value)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CacheServer) Delete(_ context.Context, request *api.DeleteRequest) (*emptypb.Empty, error) {
+	s.RedisUsecase.Del(request.Key)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CacheServer) ExpireKey(_ context.Context, request *api.ExpireKeyRequest) (*emptypb.Empty, error) {
+	ok := s.RedisUsecase.Expire(request.Key, int(request.Ttl))
+	if !ok {
+		return nil, status.Error(codes.NotFound, "key is not found")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CacheServer) GetKeys(_ context.Context, request *api.KeysRequest) (*api.KeysResponse, error) {
+	list, err := s.RedisUsecase.Keys(request.Pattern)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &api.KeysResponse{Keys: list}, nil
+}
+
+func (s *CacheServer) ScanKeys(request *api.ScanKeysRequest, stream api.CacheService_ScanKeysServer) error {
+	batchSize := int(request.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultScanBatchSize
+	}
+	if batchSize > maxScanBatchSize {
+		return status.Error(codes.InvalidArgument, "batch_size must be at most "+strconv.Itoa(maxScanBatchSize))
+	}
+
+	var sendErr error
+	batch := make([]string, 0, batchSize)
+	err := s.RedisUsecase.ScanKeys(request.Pattern, func(key string) bool {
+		batch = append(batch, key)
+		if len(batch) < batchSize {
+			return true
+		}
+
+		sendErr = stream.Send(&api.KeysResponse{Keys: batch})
+		batch = make([]string, 0, batchSize)
+		return sendErr == nil
+	})
+	if err != nil {
+		return toStatusError(err)
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if len(batch) > 0 {
+		return stream.Send(&api.KeysResponse{Keys: batch})
+	}
+	return nil
+}
+
+func toStatusError(err error) error {
+	var syntaxErr *syntax.Error
+	switch {
+	case errors.Is(err, domain.ErrWrongType):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrNoSuchKey):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrIndexOutOfRange):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.As(err, &syntaxErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// NewCacheServer will register the cache service on the grpc server
+func NewCacheServer(s *grpc.Server, us usecase.RedisUsecase) {
+	server := &CacheServer{
+		RedisUsecase: us,
+	}
+
+	api.RegisterCacheServiceServer(s, server)
+}
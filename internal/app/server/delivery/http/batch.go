@@ -0,0 +1,59 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+)
+
+// executeOperation runs a single batch operation, an error doesn't stop the rest of the batch
+func (h *CacheHandler) executeOperation(operation api.BatchOperation) api.BatchResult {
+	switch operation.Op {
+	case "set":
+		h.RedisUsecase.Set(operation.Key, operation.Value, usecase.SetOptions{})
+		return api.BatchResult{}
+	case "get":
+		value, ok, err := h.RedisUsecase.Get(operation.Key)
+		return valueResult(value, ok, err, "key is not found")
+	case "hset":
+		count, err := h.RedisUsecase.HSet(operation.Key, operation.Pairs)
+		return countResult(count, err)
+	case "hget":
+		value, ok, err := h.RedisUsecase.HGet(operation.Key, operation.Field)
+		return valueResult(value, ok, err, "key or field is not found")
+	case "lpush":
+		size, err := h.RedisUsecase.LPush(operation.Key, operation.Values)
+		return countResult(size, err)
+	case "lget":
+		value, err := h.RedisUsecase.LGet(operation.Key, operation.Index)
+		return valueResult(value, true, err, "")
+	case "lset":
+		err := h.RedisUsecase.LSet(operation.Key, operation.Index, operation.Value)
+		if err != nil {
+			return api.BatchResult{Error: err.Error()}
+		}
+		return api.BatchResult{}
+	case "del":
+		return api.BatchResult{Value: h.RedisUsecase.Del(operation.Key)}
+	case "expire":
+		return api.BatchResult{Value: h.RedisUsecase.Expire(operation.Key, operation.Ttl)}
+	default:
+		return api.BatchResult{Error: "unknown operation '" + operation.Op + "'"}
+	}
+}
+
+func valueResult(value string, ok bool, err error, notFound string) api.BatchResult {
+	if err != nil {
+		return api.BatchResult{Error: err.Error()}
+	}
+	if !ok {
+		return api.BatchResult{Error: notFound}
+	}
+	return api.BatchResult{Value: value}
+}
+
+func countResult(count int, err error) api.BatchResult {
+	if err != nil {
+		return api.BatchResult{Error: err.Error()}
+	}
+	return api.BatchResult{Value: count}
+}
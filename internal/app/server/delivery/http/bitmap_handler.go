@@ -0,0 +1,192 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	bitOperations = map[string]usecase.BitOperation{
+		"and": usecase.BitAnd,
+		"or":  usecase.BitOr,
+		"xor": usecase.BitXor,
+		"not": usecase.BitNot,
+	}
+	bitFieldKinds = map[string]usecase.BitFieldKind{
+		"get":    usecase.BitFieldGet,
+		"set":    usecase.BitFieldSet,
+		"incrby": usecase.BitFieldIncrBy,
+	}
+	bitOverflows = map[string]usecase.BitOverflow{
+		"":     usecase.OverflowWrap,
+		"wrap": usecase.OverflowWrap,
+		"sat":  usecase.OverflowSat,
+		"fail": usecase.OverflowFail,
+	}
+)
+
+func (h *CacheHandler) SetBit(c echo.Context) error {
+	var request api.SetBitRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	previous, err := h.RedisUsecase.SetBit(request.Key, request.Offset, request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.IntegerValueResponse{Value: int64(previous)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetBit(c echo.Context) error {
+	offset, err := strconv.ParseInt(c.Param("offset"), 10, 64)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "offset must be an integer"}, "  ")
+	}
+
+	bit, err := h.RedisUsecase.GetBit(params.PathParam(c, "key"), offset)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.IntegerValueResponse{Value: int64(bit)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// CountBits counts the set bits from ?start= to ?end=, in bytes or in bits with ?unit=bit, the whole string by default
+func (h *CacheHandler) CountBits(c echo.Context) error {
+	rng, err := bitRangeOf(c)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.BitCount(params.PathParam(c, "key"), rng)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: int(count)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetBitPosition returns the first bit equal to :bit in the range of CountBits, -1 if there is none
+func (h *CacheHandler) GetBitPosition(c echo.Context) error {
+	bit, err := strconv.Atoi(c.Param("bit"))
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "bit must be 0 or 1"}, "  ")
+	}
+
+	rng, err := bitRangeOf(c)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	position, err := h.RedisUsecase.BitPos(params.PathParam(c, "key"), bit, rng)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.PositionResponse{Position: position}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// bitRangeOf reads the range from ?start=, ?end= and ?unit=byte|bit, it's nil without start and end
+func bitRangeOf(c echo.Context) (*usecase.BitRange, error) {
+	start, end, unit := c.QueryParam("start"), c.QueryParam("end"), c.QueryParam("unit")
+	if start == "" && end == "" {
+		return nil, nil
+	}
+
+	args := []string{start}
+	if start == "" {
+		args[0] = "0"
+	}
+	if end != "" {
+		args = append(args, end)
+		if unit != "" {
+			args = append(args, unit)
+		}
+	}
+
+	rng, err := command.ParseBitRange(args)
+	if err != nil {
+		return nil, err
+	}
+	rng.Bit = strings.EqualFold(unit, "bit")
+	return rng, nil
+}
+
+func (h *CacheHandler) OperateOnBits(c echo.Context) error {
+	var request api.BitOperationRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	operation, ok := bitOperations[strings.ToLower(request.Operation)]
+	if !ok || len(request.Keys) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "operation must be and, or, xor or not and keys are required"}, "  ")
+	}
+
+	size, err := h.RedisUsecase.BitOp(operation, request.Destination, request.Keys)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SizeResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) OperateOnBitFields(c echo.Context) error {
+	var request api.BitFieldRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	ops := make([]usecase.BitFieldOp, 0, len(request.Operations))
+	for _, operation := range request.Operations {
+		kind, ok := bitFieldKinds[strings.ToLower(operation.Op)]
+		if !ok {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "op must be get, set or incrby"}, "  ")
+		}
+		overflow, ok := bitOverflows[strings.ToLower(operation.Overflow)]
+		if !ok {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "overflow must be wrap, sat or fail"}, "  ")
+		}
+
+		fieldType, err := command.ParseBitFieldType(operation.Type)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+		}
+		offset, err := command.ParseBitFieldOffset(operation.Offset, fieldType)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+		}
+
+		ops = append(ops, usecase.BitFieldOp{
+			Kind:     kind,
+			Type:     fieldType,
+			Offset:   offset,
+			Value:    operation.Value,
+			Overflow: overflow,
+		})
+	}
+
+	values, err := h.RedisUsecase.BitField(request.Key, ops)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.BitFieldResponse{Values: values}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+)
+
+func toCommandReply(reply command.Reply) api.CommandReply {
+	switch r := reply.(type) {
+	case command.SimpleString:
+		return api.CommandReply{Type: "status", Value: string(r)}
+	case command.BulkString:
+		return api.CommandReply{Type: "string", Value: string(r)}
+	case command.Integer:
+		return api.CommandReply{Type: "integer", Value: int64(r)}
+	case command.Double:
+		return api.CommandReply{Type: "double", Value: float64(r)}
+	case command.Boolean:
+		return api.CommandReply{Type: "boolean", Value: bool(r)}
+	case command.Null, command.NullArray:
+		return api.CommandReply{Type: "null"}
+	case command.Error:
+		return api.CommandReply{Type: "error", Value: string(r)}
+	case command.Array:
+		return api.CommandReply{Type: "array", Value: toCommandReplies(r)}
+	case command.Set:
+		return api.CommandReply{Type: "set", Value: toCommandReplies(r)}
+	case command.Push:
+		return api.CommandReply{Type: "push", Value: toCommandReplies(r)}
+	case command.Map:
+		entries := make([]api.CommandMapEntry, 0, len(r))
+		for _, entry := range r {
+			entries = append(entries, api.CommandMapEntry{
+				Key:   toCommandReply(entry.Key),
+				Value: toCommandReply(entry.Value),
+			})
+		}
+		return api.CommandReply{Type: "map", Value: entries}
+	default:
+		return api.CommandReply{Type: "error", Value: "ERR unsupported reply"}
+	}
+}
+
+func toCommandReplies(replies []command.Reply) []api.CommandReply {
+	result := make([]api.CommandReply, 0, len(replies))
+	for _, reply := range replies {
+		result = append(result, toCommandReply(reply))
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+)
+
+func (h *CacheHandler) ReserveBloom(c echo.Context) error {
+	request := api.ReserveBloomRequest{BloomOptions: usecase.DefaultBloomOptions}
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.BFReserve(request.Key, request.BloomOptions)
+	return reserveFilterResponse(c, err)
+}
+
+func reserveFilterResponse(c echo.Context, err error) error {
+	if err == domain.ErrItemExists {
+		return c.JSONPretty(http.StatusConflict, ResponseError{Message: err.Error()}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *CacheHandler) AddToBloom(c echo.Context) error {
+	var request api.FilterItemsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Items) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "items are required"}, "  ")
+	}
+
+	added, err := h.RedisUsecase.BFAdd(request.Key, request.Items)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ItemsAddedResponse{Added: added}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) CheckBloom(c echo.Context) error {
+	return h.checkFilter(c, h.RedisUsecase.BFExists)
+}
+
+func (h *CacheHandler) CheckCuckoo(c echo.Context) error {
+	return h.checkFilter(c, h.RedisUsecase.CFExists)
+}
+
+func (h *CacheHandler) checkFilter(c echo.Context, exists func(key string, items []string) ([]bool, error)) error {
+	var request api.FilterItemsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Items) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "items are required"}, "  ")
+	}
+
+	found, err := exists(request.Key, request.Items)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ItemsExistResponse{Exists: found}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) ReserveCuckoo(c echo.Context) error {
+	request := api.ReserveCuckooRequest{CuckooOptions: usecase.DefaultCuckooOptions}
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.CFReserve(request.Key, request.CuckooOptions)
+	return reserveFilterResponse(c, err)
+}
+
+func (h *CacheHandler) AddToCuckoo(c echo.Context) error {
+	var request api.AddToCuckooRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	added, err := h.RedisUsecase.CFAdd(request.Key, request.Item, request.NX)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	// an item which isn't added because of NX isn't an error
+	status := http.StatusCreated
+	if !added {
+		status = http.StatusOK
+	}
+	return c.JSONPretty(status, api.AddedResponse{Added: added}, "  ")
+}
+
+func (h *CacheHandler) RemoveFromCuckoo(c echo.Context) error {
+	deleted, err := h.RedisUsecase.CFDel(params.PathParam(c, "key"), params.PathParam(c, "item"))
+	if err == domain.ErrCuckooNotFound {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !deleted {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "item is not found"}, "  ")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
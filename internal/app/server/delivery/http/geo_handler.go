@@ -0,0 +1,189 @@
+package http
+
+import (
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strings"
+)
+
+var geoSorts = map[string]usecase.GeoSort{
+	"":     usecase.GeoSortNone,
+	"asc":  usecase.GeoSortAsc,
+	"desc": usecase.GeoSortDesc,
+}
+
+func (h *CacheHandler) AddToGeo(c echo.Context) error {
+	var request api.AddToGeoRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	options := usecase.ZAddOptions{NX: request.NX, XX: request.XX, CH: request.CH}
+	count, err := h.RedisUsecase.GeoAdd(request.Key, request.Members, options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetGeoPositions(c echo.Context) error {
+	var request api.GeoMembersRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	positions, err := h.RedisUsecase.GeoPos(request.Key, request.Members)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.GeoPositionsResponse{Positions: positions}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetGeoHashes(c echo.Context) error {
+	var request api.GeoMembersRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	hashes, err := h.RedisUsecase.GeoHash(request.Key, request.Members)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.GeoHashesResponse{Hashes: hashes}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetGeoDistance returns the distance between the members in ?unit=m|km|ft|mi, meters by default
+func (h *CacheHandler) GetGeoDistance(c echo.Context) error {
+	unit, err := geoUnitOf(c.QueryParam("unit"))
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	distance, ok, err := h.RedisUsecase.GeoDist(params.PathParam(c, "key"), params.PathParam(c, "member1"),
+		params.PathParam(c, "member2"), unit)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key or member is not found"}, "  ")
+	}
+
+	response := api.DistanceResponse{Distance: distance}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func geoUnitOf(unit string) (float64, error) {
+	if unit == "" {
+		return 1, nil
+	}
+	return command.ParseGeoUnit(unit)
+}
+
+func (h *CacheHandler) SearchGeo(c echo.Context) error {
+	var request api.GeoSearchRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	query, err := geoSearchQueryOf(request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	locations, err := h.RedisUsecase.GeoSearch(request.Key, query)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.GeoLocationsResponse{Locations: make([]api.GeoLocation, 0, len(locations))}
+	for _, location := range locations {
+		response.Locations = append(response.Locations, api.GeoLocation{
+			Member:    location.Member,
+			Distance:  location.Distance,
+			Hash:      location.Hash,
+			Longitude: location.Position.Longitude,
+			Latitude:  location.Position.Latitude,
+		})
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) SearchAndStoreGeo(c echo.Context) error {
+	var request api.GeoSearchStoreRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	query, err := geoSearchQueryOf(request.GeoSearchRequest)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.GeoSearchStore(request.Destination, request.Key, query, request.StoreDist)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func geoSearchQueryOf(request api.GeoSearchRequest) (usecase.GeoSearchQuery, error) {
+	var query usecase.GeoSearchQuery
+	if (request.FromMember == nil) == (request.FromLonLat == nil) {
+		return query, errors.New("exactly one of from_member and from_lonlat is required")
+	}
+	if (request.Radius == nil) == (request.Box == nil) {
+		return query, errors.New("exactly one of radius and box is required")
+	}
+
+	if request.FromMember != nil {
+		query.FromMember = true
+		query.Member = *request.FromMember
+	} else {
+		query.Longitude, query.Latitude = request.FromLonLat.Longitude, request.FromLonLat.Latitude
+	}
+
+	if request.Radius != nil {
+		query.Radius = *request.Radius
+	} else {
+		query.ByBox = true
+		query.Width, query.Height = request.Box.Width, request.Box.Height
+	}
+	if query.Radius < 0 || query.Width < 0 || query.Height < 0 {
+		return query, errors.New("radius and box can't be negative")
+	}
+
+	sort, ok := geoSorts[strings.ToLower(request.Sort)]
+	if !ok {
+		return query, errors.New("sort must be asc or desc")
+	}
+	if request.Count < 0 || request.Any && request.Count == 0 {
+		return query, errors.New("count must be positive and any requires count")
+	}
+
+	unit, err := geoUnitOf(request.Unit)
+	if err != nil {
+		return query, err
+	}
+
+	query.Unit, query.Sort, query.Count, query.Any = unit, sort, request.Count, request.Any
+	return query, nil
+}
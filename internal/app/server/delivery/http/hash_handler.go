@@ -0,0 +1,110 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+)
+
+func (h *CacheHandler) RemoveFieldsFromMap(c echo.Context) error {
+	var request api.RemoveFieldsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.HDel(params.PathParam(c, "key"), request.Fields)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) SetFieldInMapIfNotExists(c echo.Context) error {
+	var request api.SetFieldIfNotExistsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	created, err := h.RedisUsecase.HSetNX(params.PathParam(c, "key"), params.PathParam(c, "field"), request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CreatedResponse{Created: created}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IncrementFieldInMap(c echo.Context) error {
+	var request api.IncrementFieldRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	value, err := h.RedisUsecase.HIncrBy(params.PathParam(c, "key"), params.PathParam(c, "field"), request.Increment)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.IntegerValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetMap returns all the fields and values of the hash. The query selects another view:
+// ?field=f1&field=f2 returns the values of the fields, null for the missing ones,
+// ?view=keys, ?view=values and ?view=len return the fields, the values and the number of fields.
+// The views are query parameters because /cache/map/:key/:field is already taken by HGET.
+func (h *CacheHandler) GetMap(c echo.Context) error {
+	key := params.PathParam(c, "key")
+	if fields := c.QueryParams()["field"]; len(fields) > 0 {
+		values, err := h.RedisUsecase.HMGet(key, fields)
+		if err != nil {
+			return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+		}
+		return c.JSONPretty(http.StatusOK, api.OptionalValuesResponse{Values: values}, "  ")
+	}
+
+	var response interface{}
+	var err error
+	switch c.QueryParam("view") {
+	case "":
+		var pairs []usecase.FieldValue
+		pairs, err = h.RedisUsecase.HGetAll(key)
+		response = api.FieldsAndValuesResponse{Pairs: pairs}
+	case "keys":
+		var fields []string
+		fields, err = h.RedisUsecase.HKeys(key)
+		response = api.FieldsResponse{Fields: fields}
+	case "values":
+		var values []string
+		values, err = h.RedisUsecase.HVals(key)
+		response = api.ListValuesResponse{Values: values}
+	case "len":
+		var size int
+		size, err = h.RedisUsecase.HLen(key)
+		response = api.SizeResponse{Size: size}
+	default:
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "view must be keys, values or len"}, "  ")
+	}
+
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) FieldExistsInMap(c echo.Context) error {
+	exists, err := h.RedisUsecase.HExists(params.PathParam(c, "key"), params.PathParam(c, "field"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ExistsResponse{Exists: exists}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+)
+
+func (h *CacheHandler) AddToHyperLogLog(c echo.Context) error {
+	var request api.AddToHyperLogLogRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	changed, err := h.RedisUsecase.PFAdd(request.Key, request.Elements)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ChangedResponse{Changed: changed}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) CountUnique(c echo.Context) error {
+	return h.countUnique(c, []string{params.PathParam(c, "key")})
+}
+
+// CountUniqueInKeys counts the union of the keys of the request
+func (h *CacheHandler) CountUniqueInKeys(c echo.Context) error {
+	var request api.CountUniqueRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Keys) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "keys are required"}, "  ")
+	}
+	return h.countUnique(c, request.Keys)
+}
+
+func (h *CacheHandler) countUnique(c echo.Context, keys []string) error {
+	count, err := h.RedisUsecase.PFCount(keys)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: int(count)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) MergeHyperLogLogs(c echo.Context) error {
+	var request api.MergeHyperLogLogsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.PFMerge(request.Destination, request.Keys)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+)
+
+func (h *CacheHandler) SetJSON(c echo.Context) error {
+	var request api.SetJSONRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Value) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "value is required"}, "  ")
+	}
+	if request.NX && request.XX {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "nx and xx can't be set at the same time"}, "  ")
+	}
+
+	written, err := h.RedisUsecase.JSONSet(request.Key, jsonPathOf(request.Path), string(request.Value), request.JSONSetOptions)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	// a value which isn't written because of NX or XX isn't an error
+	status := http.StatusCreated
+	if !written {
+		status = http.StatusOK
+	}
+	return c.JSONPretty(status, api.WrittenResponse{Written: written}, "  ")
+}
+
+// jsonPathOf returns the path of the request, the root by default
+func jsonPathOf(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return path
+}
+
+// GetJSON returns the document or, with ?path=, the array of the values at the path.
+// With several paths the response is an object with the values of every path.
+func (h *CacheHandler) GetJSON(c echo.Context) error {
+	value, ok, err := h.RedisUsecase.JSONGet(params.PathParam(c, "key"), c.QueryParams()["path"])
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+
+	var response bytes.Buffer
+	if err := json.Indent(&response, []byte(value), "", "  "); err != nil {
+		return c.JSONPretty(http.StatusInternalServerError, ResponseError{Message: err.Error()}, "  ")
+	}
+	return c.JSONBlob(http.StatusOK, response.Bytes())
+}
+
+// DeleteJSON deletes the values at ?path=, the whole document by default
+func (h *CacheHandler) DeleteJSON(c echo.Context) error {
+	count, err := h.RedisUsecase.JSONDel(params.PathParam(c, "key"), jsonPathOf(c.QueryParam("path")))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetJSONTypes(c echo.Context) error {
+	types, err := h.RedisUsecase.JSONType(params.PathParam(c, "key"), jsonPathOf(c.QueryParam("path")))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if types == nil {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+
+	response := api.JSONTypesResponse{Types: types}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IncrementJSONNumber(c echo.Context) error {
+	var request api.IncrementJSONNumberRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	values, err := h.RedisUsecase.JSONNumIncrBy(request.Key, jsonPathOf(request.Path), string(request.Value))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.JSONValuesResponse{Values: make([]json.RawMessage, 0, len(values))}
+	for _, value := range values {
+		if value == nil {
+			response.Values = append(response.Values, nil)
+			continue
+		}
+		response.Values = append(response.Values, json.RawMessage(*value))
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) AppendToJSONArray(c echo.Context) error {
+	var request api.AppendToJSONArrayRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Values) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "values are required"}, "  ")
+	}
+	values := make([]string, 0, len(request.Values))
+	for _, value := range request.Values {
+		values = append(values, string(value))
+	}
+
+	lengths, err := h.RedisUsecase.JSONArrAppend(request.Key, jsonPathOf(request.Path), values)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.LengthsResponse{Lengths: lengths}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetJSONKeys(c echo.Context) error {
+	keys, err := h.RedisUsecase.JSONObjKeys(params.PathParam(c, "key"), jsonPathOf(c.QueryParam("path")))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if keys == nil {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+
+	response := api.JSONKeysResponse{Keys: keys}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (h *CacheHandler) PushToListTail(c echo.Context) error {
+	return h.pushToList(c, h.RedisUsecase.RPush)
+}
+
+func (h *CacheHandler) PushToExistingList(c echo.Context) error {
+	return h.pushToList(c, h.RedisUsecase.LPushX)
+}
+
+func (h *CacheHandler) PushToExistingListTail(c echo.Context) error {
+	return h.pushToList(c, h.RedisUsecase.RPushX)
+}
+
+func (h *CacheHandler) pushToList(c echo.Context, push func(string, []string) (int, error)) error {
+	var request api.PushToListRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	size, err := push(request.Key, request.Values)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.PushToListResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) PopFromList(c echo.Context) error {
+	return h.popFromList(c, h.RedisUsecase.LPop)
+}
+
+func (h *CacheHandler) PopFromListTail(c echo.Context) error {
+	return h.popFromList(c, h.RedisUsecase.RPop)
+}
+
+func (h *CacheHandler) popFromList(c echo.Context, pop func(string, int) ([]string, error)) error {
+	var request api.PopFromListRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Count <= 0 {
+		request.Count = 1
+	}
+
+	values, err := pop(request.Key, request.Count)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if values == nil {
+		values = []string{}
+	}
+	response := api.ListValuesResponse{Values: values}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetListRange returns the elements from ?start= to ?stop= inclusive, the whole list by default
+func (h *CacheHandler) GetListRange(c echo.Context) error {
+	start, stop := 0, -1
+	var err error
+	if value := c.QueryParam("start"); value != "" {
+		if start, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "start must be an integer"}, "  ")
+		}
+	}
+	if value := c.QueryParam("stop"); value != "" {
+		if stop, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "stop must be an integer"}, "  ")
+		}
+	}
+
+	values, err := h.RedisUsecase.LRange(params.PathParam(c, "key"), start, stop)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ListValuesResponse{Values: values}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetListLength(c echo.Context) error {
+	size, err := h.RedisUsecase.LLen(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.PushToListResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) InsertToList(c echo.Context) error {
+	var request api.InsertToListRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	size, err := h.RedisUsecase.LInsert(request.Key, request.Before, request.Pivot, request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if size == -1 {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "pivot is not found"}, "  ")
+	}
+
+	response := api.PushToListResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) RemoveFromList(c echo.Context) error {
+	var request api.RemoveFromListRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.LRem(request.Key, request.Count, request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) TrimList(c echo.Context) error {
+	var request api.TrimListRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.LTrim(request.Key, request.Start, request.Stop)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetListPositions accepts the LPOS options as ?rank=, ?count= and ?maxlen=,
+// all the matches are returned by default
+func (h *CacheHandler) GetListPositions(c echo.Context) error {
+	options := usecase.LPosOptions{Rank: 1}
+	for name, option := range map[string]*int{"rank": &options.Rank, "count": &options.Count, "maxlen": &options.MaxLen} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+
+		var err error
+		if *option, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: name + " must be an integer"}, "  ")
+		}
+	}
+
+	if options.Count < 0 || options.MaxLen < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count and maxlen can't be negative"}, "  ")
+	}
+
+	positions, err := h.RedisUsecase.LPos(params.PathParam(c, "key"), params.PathParam(c, "element"), options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.PositionsResponse{Positions: positions}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) MoveBetweenLists(c echo.Context) error {
+	var request api.MoveBetweenListsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	from, to, ok := parseListSides(request)
+	if !ok {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "from and to must be left or right"}, "  ")
+	}
+
+	value, ok, err := h.RedisUsecase.LMove(request.Source, request.Destination, from, to)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "source is not found"}, "  ")
+	}
+
+	response := api.ValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func parseListSides(request api.MoveBetweenListsRequest) (usecase.ListSide, usecase.ListSide, bool) {
+	sides := map[string]usecase.ListSide{"left": usecase.ListLeft, "right": usecase.ListRight}
+	from, fromOk := sides[request.From]
+	to, toOk := sides[request.To]
+	return from, to, fromOk && toOk
+}
+
+// BlockingPopFromList holds the request until an element is pushed to one of the lists or the timeout expires,
+// the expired request is answered with 204
+func (h *CacheHandler) BlockingPopFromList(c echo.Context) error {
+	return h.blockingPopFromList(c, h.RedisUsecase.BLPop)
+}
+
+func (h *CacheHandler) BlockingPopFromListTail(c echo.Context) error {
+	return h.blockingPopFromList(c, h.RedisUsecase.BRPop)
+}
+
+func (h *CacheHandler) blockingPopFromList(c echo.Context,
+	pop func(context.Context, []string, time.Duration) (string, string, bool, error)) error {
+	var request api.BlockingPopRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Keys) == 0 || request.Timeout < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "keys are required and timeout can't be negative"}, "  ")
+	}
+
+	timeout, err := command.Timeout(request.Timeout)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	key, value, ok, err := pop(c.Request().Context(), request.Keys, timeout)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	response := api.PoppedElementResponse{Key: key, Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// BlockingMoveBetweenLists is MoveBetweenLists which waits like BlockingPopFromList
+func (h *CacheHandler) BlockingMoveBetweenLists(c echo.Context) error {
+	var request api.BlockingMoveRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	from, to, ok := parseListSides(request.MoveBetweenListsRequest)
+	if !ok || request.Timeout < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "from and to must be left or right, timeout can't be negative"}, "  ")
+	}
+
+	timeout, err := command.Timeout(request.Timeout)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	value, ok, err := h.RedisUsecase.BLMove(c.Request().Context(), request.Source, request.Destination, from, to, timeout)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	response := api.ValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
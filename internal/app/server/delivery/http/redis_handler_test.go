@@ -0,0 +1,242 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/babon21/redis-impl/internal/app/server/repository"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestServer() *echo.Echo {
+	e := echo.New()
+	NewCacheHandler(e, usecase.NewRedisUsecase(repository.NewInMemoryRedisStore()))
+	return e
+}
+
+func serve(e *echo.Echo, method string, target string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// The actions are routed under the key, so a key named as an action is still read by GET /cache/string/:key
+func TestNewCacheHandler_StringKeysNamedAsActions(t *testing.T) {
+	type args struct {
+		method string
+		target string
+		body   string
+	}
+	tests := []struct {
+		name          string
+		key           string
+		args          args
+		wantStatus    int
+		wantGetStatus int
+		wantValue     string
+	}{
+		{
+			name:          "incr",
+			key:           "incr",
+			args:          args{method: http.MethodPost, target: "/cache/string/incr/incr", body: `{"increment": 1}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "11",
+		},
+		{
+			name:          "incrbyfloat",
+			key:           "incrbyfloat",
+			args:          args{method: http.MethodPost, target: "/cache/string/incrbyfloat/incrbyfloat", body: `{"increment": 0.5}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10.5",
+		},
+		{
+			name:          "append",
+			key:           "append",
+			args:          args{method: http.MethodPost, target: "/cache/string/append/append", body: `{"value": "0"}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "100",
+		},
+		{
+			name:          "setrange",
+			key:           "setrange",
+			args:          args{method: http.MethodPost, target: "/cache/string/setrange/setrange", body: `{"offset": 1, "value": "5"}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "15",
+		},
+		{
+			name:          "getex",
+			key:           "getex",
+			args:          args{method: http.MethodPost, target: "/cache/string/getex/getex", body: `{"ex": 60}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10",
+		},
+		{
+			name:          "getdel",
+			key:           "getdel",
+			args:          args{method: http.MethodPost, target: "/cache/string/getdel/getdel"},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusNotFound,
+		},
+		{
+			name:          "mget",
+			key:           "mget",
+			args:          args{method: http.MethodPost, target: "/cache/strings/mget", body: `{"keys": ["mget"]}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10",
+		},
+		{
+			name:          "mset",
+			key:           "mset",
+			args:          args{method: http.MethodPost, target: "/cache/strings/mset", body: `{"pairs": [{"key": "mset", "value": "20"}]}`},
+			wantStatus:    http.StatusCreated,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "20",
+		},
+		{
+			name:          "msetnx",
+			key:           "msetnx",
+			args:          args{method: http.MethodPost, target: "/cache/strings/msetnx", body: `{"pairs": [{"key": "msetnx", "value": "20"}]}`},
+			wantStatus:    http.StatusOK,
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestServer()
+			if got := serve(e, http.MethodPut, "/cache/string", `{"key": "`+tt.key+`", "value": "10"}`); got.Code != http.StatusCreated {
+				t.Fatalf("PUT /cache/string status = %d, want %d: %s", got.Code, http.StatusCreated, got.Body)
+			}
+
+			if got := serve(e, tt.args.method, tt.args.target, tt.args.body); got.Code != tt.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d: %s", tt.args.method, tt.args.target, got.Code, tt.wantStatus, got.Body)
+			}
+
+			got := serve(e, http.MethodGet, "/cache/string/"+tt.key, "")
+			if got.Code != tt.wantGetStatus {
+				t.Fatalf("GET status = %d, want %d: %s", got.Code, tt.wantGetStatus, got.Body)
+			}
+			if got.Code != http.StatusOK {
+				return
+			}
+			var response api.ValueResponse
+			if err := json.Unmarshal(got.Body.Bytes(), &response); err != nil {
+				t.Fatalf("GET body %s: %v", got.Body, err)
+			}
+			if response.Value != tt.wantValue {
+				t.Errorf("GET value = %q, want %q", response.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+// The hash actions are routed under the key and the field, so HGET of a key or a field named as an action still works
+func TestNewCacheHandler_MapKeysNamedAsActions(t *testing.T) {
+	type args struct {
+		target string
+		body   string
+	}
+	tests := []struct {
+		name          string
+		key           string
+		field         string
+		args          args
+		wantGetStatus int
+		wantValue     string
+	}{
+		{
+			name:          "remove another field",
+			key:           "remove",
+			field:         "remove",
+			args:          args{target: "/cache/map/remove/fields/remove", body: `{"fields": ["other"]}`},
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10",
+		},
+		{
+			name:          "remove the field",
+			key:           "fields",
+			field:         "fields",
+			args:          args{target: "/cache/map/fields/fields/remove", body: `{"fields": ["fields"]}`},
+			wantGetStatus: http.StatusNotFound,
+		},
+		{
+			name:          "setnx",
+			key:           "setnx",
+			field:         "setnx",
+			args:          args{target: "/cache/map/setnx/setnx/setnx", body: `{"value": "20"}`},
+			wantGetStatus: http.StatusOK,
+			wantValue:     "10",
+		},
+		{
+			name:          "incr",
+			key:           "incr",
+			field:         "incr",
+			args:          args{target: "/cache/map/incr/incr/incr", body: `{"increment": 2}`},
+			wantGetStatus: http.StatusOK,
+			wantValue:     "12",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestServer()
+			body := `{"key": "` + tt.key + `", "pairs": [{"field": "` + tt.field + `", "value": "10"}]}`
+			if got := serve(e, http.MethodPut, "/cache/map", body); got.Code != http.StatusOK {
+				t.Fatalf("PUT /cache/map status = %d, want %d: %s", got.Code, http.StatusOK, got.Body)
+			}
+
+			if got := serve(e, http.MethodPost, tt.args.target, tt.args.body); got.Code != http.StatusOK {
+				t.Fatalf("POST %s status = %d, want %d: %s", tt.args.target, got.Code, http.StatusOK, got.Body)
+			}
+
+			if got := serve(e, http.MethodGet, "/cache/map/"+tt.key, ""); got.Code != http.StatusOK {
+				t.Errorf("GET /cache/map/%s status = %d, want %d: %s", tt.key, got.Code, http.StatusOK, got.Body)
+			}
+
+			got := serve(e, http.MethodGet, "/cache/map/"+tt.key+"/"+tt.field, "")
+			if got.Code != tt.wantGetStatus {
+				t.Fatalf("GET status = %d, want %d: %s", got.Code, tt.wantGetStatus, got.Body)
+			}
+			if got.Code != http.StatusOK {
+				return
+			}
+			var response api.ValueResponse
+			if err := json.Unmarshal(got.Body.Bytes(), &response); err != nil {
+				t.Fatalf("GET body %s: %v", got.Body, err)
+			}
+			if response.Value != tt.wantValue {
+				t.Errorf("GET value = %q, want %q", response.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestNewCacheHandler_BlockingTimeoutOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+	}{
+		{name: "blpop", target: "/cache/list/blpop", body: `{"keys": ["l"], "timeout": 1e300}`},
+		{name: "blmove", target: "/cache/list/blmove", body: `{"source": "a", "destination": "b", "from": "left", "to": "right", "timeout": 9223372037}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serve(newTestServer(), http.MethodPost, tt.target, tt.body)
+			if got.Code != http.StatusBadRequest || !strings.Contains(got.Body.String(), "timeout is out of range") {
+				t.Errorf("POST %s = %d %s, want %d timeout is out of range", tt.target, got.Code, got.Body, http.StatusBadRequest)
+			}
+		})
+	}
+}
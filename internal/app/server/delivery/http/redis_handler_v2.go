@@ -0,0 +1,192 @@
+package http
+
+import (
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"regexp/syntax"
+	"strconv"
+)
+
+// CacheHandlerV2 represent the resource-oriented v2 httphandler for redis cache
+type CacheHandlerV2 struct {
+	RedisUsecase usecase.RedisUsecase
+}
+
+func (h *CacheHandlerV2) GetKeys(c echo.Context) error {
+	list, err := h.RedisUsecase.Keys(c.QueryParam("pattern"))
+	if err != nil {
+		return errorV2(c, err)
+	}
+
+	return c.JSONPretty(http.StatusOK, api.KeysResponse{Keys: list}, "  ")
+}
+
+func (h *CacheHandlerV2) DeleteKey(c echo.Context) error {
+	if !h.RedisUsecase.Del(params.PathParam(c, "key")) {
+		return notFoundV2(c, "key is not found")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandlerV2) SetTtl(c echo.Context) error {
+	var request api.TtlRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequestV2(c, err.Error())
+	}
+
+	if !h.RedisUsecase.Expire(params.PathParam(c, "key"), request.Ttl) {
+		return notFoundV2(c, "key is not found")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandlerV2) GetString(c echo.Context) error {
+	value, ok, err := h.RedisUsecase.Get(params.PathParam(c, "key"))
+	if err != nil {
+		return errorV2(c, err)
+	}
+
+	if !ok {
+		return notFoundV2(c, "key is not found")
+	}
+
+	return c.JSONPretty(http.StatusOK, api.ValueResponse{Value: value}, "  ")
+}
+
+func (h *CacheHandlerV2) SetString(c echo.Context) error {
+	var request api.ValueRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequestV2(c, err.Error())
+	}
+
+	h.RedisUsecase.Set(params.PathParam(c, "key"), request.Value, usecase.SetOptions{})
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandlerV2) GetField(c echo.Context) error {
+	value, ok, err := h.RedisUsecase.HGet(params.PathParam(c, "key"), params.PathParam(c, "field"))
+	if err != nil {
+		return errorV2(c, err)
+	}
+
+	if !ok {
+		return notFoundV2(c, "key or field is not found")
+	}
+
+	return c.JSONPretty(http.StatusOK, api.ValueResponse{Value: value}, "  ")
+}
+
+func (h *CacheHandlerV2) SetField(c echo.Context) error {
+	var request api.ValueRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequestV2(c, err.Error())
+	}
+
+	pairs := []usecase.FieldValue{{Field: params.PathParam(c, "field"), Value: request.Value}}
+	if _, err := h.RedisUsecase.HSet(params.PathParam(c, "key"), pairs); err != nil {
+		return errorV2(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandlerV2) GetItem(c echo.Context) error {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		return badRequestV2(c, "index must be an integer")
+	}
+
+	value, err := h.RedisUsecase.LGet(params.PathParam(c, "key"), index)
+	if err != nil {
+		return errorV2(c, err)
+	}
+
+	return c.JSONPretty(http.StatusOK, api.ValueResponse{Value: value}, "  ")
+}
+
+func (h *CacheHandlerV2) SetItem(c echo.Context) error {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		return badRequestV2(c, "index must be an integer")
+	}
+
+	var request api.ValueRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequestV2(c, err.Error())
+	}
+
+	if err := h.RedisUsecase.LSet(params.PathParam(c, "key"), index, request.Value); err != nil {
+		return errorV2(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandlerV2) PushItems(c echo.Context) error {
+	var request api.ValuesRequest
+	if err := c.Bind(&request); err != nil {
+		return badRequestV2(c, err.Error())
+	}
+
+	size, err := h.RedisUsecase.LPush(params.PathParam(c, "key"), request.Values)
+	if err != nil {
+		return errorV2(c, err)
+	}
+
+	return c.JSONPretty(http.StatusCreated, api.PushToListResponse{Size: size}, "  ")
+}
+
+// errorV2 maps usecase errors to the status code and the error envelope
+func errorV2(c echo.Context, err error) error {
+	var syntaxErr *syntax.Error
+	switch {
+	case errors.Is(err, domain.ErrWrongType):
+		return c.JSONPretty(http.StatusConflict, api.ErrorEnvelope{Error: api.ErrorBody{Code: "WRONGTYPE", Message: err.Error()}}, "  ")
+	case errors.Is(err, domain.ErrNoSuchKey):
+		return notFoundV2(c, err.Error())
+	case errors.Is(err, domain.ErrIndexOutOfRange):
+		return c.JSONPretty(http.StatusNotFound, api.ErrorEnvelope{Error: api.ErrorBody{Code: "OUT_OF_RANGE", Message: err.Error()}}, "  ")
+	case errors.As(err, &syntaxErr):
+		return badRequestV2(c, err.Error())
+	default:
+		return c.JSONPretty(http.StatusInternalServerError, api.ErrorEnvelope{Error: api.ErrorBody{Code: "INTERNAL", Message: err.Error()}}, "  ")
+	}
+}
+
+func notFoundV2(c echo.Context, message string) error {
+	return c.JSONPretty(http.StatusNotFound, api.ErrorEnvelope{Error: api.ErrorBody{Code: "NOT_FOUND", Message: message}}, "  ")
+}
+
+func badRequestV2(c echo.Context, message string) error {
+	return c.JSONPretty(http.StatusBadRequest, api.ErrorEnvelope{Error: api.ErrorBody{Code: "BAD_REQUEST", Message: message}}, "  ")
+}
+
+// NewCacheHandlerV2 will initialize the v2/ resources endpoint
+func NewCacheHandlerV2(e *echo.Echo, us usecase.RedisUsecase) {
+	handler := &CacheHandlerV2{
+		RedisUsecase: us,
+	}
+
+	g := e.Group("/v2")
+
+	g.GET("/keys", handler.GetKeys)
+	g.DELETE("/keys/:key", handler.DeleteKey)
+	g.PUT("/keys/:key/ttl", handler.SetTtl)
+
+	g.GET("/strings/:key", handler.GetString)
+	g.PUT("/strings/:key", handler.SetString)
+
+	g.GET("/hashes/:key/fields/:field", handler.GetField)
+	g.PUT("/hashes/:key/fields/:field", handler.SetField)
+
+	g.POST("/lists/:key/items", handler.PushItems)
+	g.GET("/lists/:key/items/:index", handler.GetItem)
+	g.PUT("/lists/:key/items/:index", handler.SetItem)
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+)
+
+func (h *CacheHandler) AddToSet(c echo.Context) error {
+	var request api.SetMembersRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.SAdd(request.Key, request.Members)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) RemoveFromSet(c echo.Context) error {
+	var request api.SetMembersRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.SRem(request.Key, request.Members)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetSetMembers(c echo.Context) error {
+	members, err := h.RedisUsecase.SMembers(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.MembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IsSetMember(c echo.Context) error {
+	ok, err := h.RedisUsecase.SIsMember(params.PathParam(c, "key"), params.PathParam(c, "member"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.IsMemberResponse{IsMember: ok}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetSetCardinality(c echo.Context) error {
+	count, err := h.RedisUsecase.SCard(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) PopFromSet(c echo.Context) error {
+	var request api.PopFromSetRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Count <= 0 {
+		request.Count = 1
+	}
+
+	members, err := h.RedisUsecase.SPop(request.Key, request.Count)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.MembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetRandomSetMembers(c echo.Context) error {
+	count := 1
+	if value := c.QueryParam("count"); value != "" {
+		var err error
+		if count, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be an integer"}, "  ")
+		}
+	}
+
+	members, err := h.RedisUsecase.SRandMember(params.PathParam(c, "key"), count)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.MembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IntersectSets(c echo.Context) error {
+	return h.combineSets(c, h.RedisUsecase.SInter, h.RedisUsecase.SInterStore)
+}
+
+func (h *CacheHandler) UnionSets(c echo.Context) error {
+	return h.combineSets(c, h.RedisUsecase.SUnion, h.RedisUsecase.SUnionStore)
+}
+
+func (h *CacheHandler) DiffSets(c echo.Context) error {
+	return h.combineSets(c, h.RedisUsecase.SDiff, h.RedisUsecase.SDiffStore)
+}
+
+func (h *CacheHandler) combineSets(c echo.Context, combine func([]string) ([]string, error),
+	combineAndStore func(string, []string) (int, error)) error {
+	var request api.SetOperationRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Destination != "" {
+		count, err := combineAndStore(request.Destination, request.Keys)
+		if err != nil {
+			return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+		}
+
+		response := api.CountResponse{Count: count}
+		return c.JSONPretty(http.StatusOK, response, "  ")
+	}
+
+	members, err := combine(request.Keys)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.MembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
@@ -0,0 +1,562 @@
+package http
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (h *CacheHandler) AddToStream(c echo.Context) error {
+	var request api.AddToStreamRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.ID == "" {
+		request.ID = "*"
+	}
+	options, err := command.ParseXAddID(request.ID)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	if len(request.Fields) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "fields are required"}, "  ")
+	}
+	options.NoMkStream = request.NoMkStream
+	if options.Trim, err = streamTrimOf(request.StreamTrim); err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	id, ok, err := h.RedisUsecase.XAdd(request.Key, request.Fields, options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "stream not found"}, "  ")
+	}
+
+	response := api.StreamIDResponse{ID: id.String()}
+	return c.JSONPretty(http.StatusCreated, response, "  ")
+}
+
+// streamTrimOf converts the trim of the request, it's nil if neither maxlen nor minid is set
+func streamTrimOf(request api.StreamTrim) (*usecase.StreamTrim, error) {
+	if request.MaxLen == nil && request.MinID == "" {
+		return nil, nil
+	}
+	if request.MaxLen != nil && request.MinID != "" {
+		return nil, domain.ErrSyntax
+	}
+	if request.Limit != 0 && !request.Approximate {
+		return nil, domain.ErrStreamTrimLimit
+	}
+	if request.Limit < 0 {
+		return nil, domain.ErrNotInteger
+	}
+
+	trim := &usecase.StreamTrim{Approximate: request.Approximate, Limit: request.Limit}
+	if request.MaxLen != nil {
+		if *request.MaxLen < 0 {
+			return nil, domain.ErrStreamMaxLen
+		}
+		trim.MaxLen = *request.MaxLen
+		return trim, nil
+	}
+
+	id, err := command.ParseStreamID(request.MinID, 0)
+	if err != nil {
+		return nil, err
+	}
+	trim.MinID = &id
+	return trim, nil
+}
+
+// GetStreamRange returns the entries from ?start= (- by default) to ?end= (+ by default), at most ?count= of them,
+// in the reverse order with ?rev=true
+func (h *CacheHandler) GetStreamRange(c echo.Context) error {
+	start, end := c.QueryParam("start"), c.QueryParam("end")
+	if start == "" {
+		start = "-"
+	}
+	if end == "" {
+		end = "+"
+	}
+	first, last, err := command.ParseStreamInterval(start, end)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := optionalIntQueryParam(c, "count")
+	if err != nil || count < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be a non-negative integer"}, "  ")
+	}
+
+	var entries []usecase.StreamEntry
+	if c.QueryParam("rev") == "true" {
+		entries, err = h.RedisUsecase.XRevRange(params.PathParam(c, "key"), last, first, count)
+	} else {
+		entries, err = h.RedisUsecase.XRange(params.PathParam(c, "key"), first, last, count)
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.StreamEntriesResponse{Entries: streamEntriesOf(entries)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func optionalIntQueryParam(c echo.Context, name string) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func (h *CacheHandler) GetStreamLength(c echo.Context) error {
+	length, err := h.RedisUsecase.XLen(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SizeResponse{Size: length}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) RemoveFromStream(c echo.Context) error {
+	var request api.StreamIDsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	ids, err := streamIDsOf(request.IDs)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.XDel(request.Key, ids)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) TrimStream(c echo.Context) error {
+	var request api.TrimStreamRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	trim, err := streamTrimOf(request.StreamTrim)
+	if err == nil && trim == nil {
+		err = domain.ErrSyntax
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.XTrim(request.Key, *trim)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// ReadStreams reads the entries after the IDs of the request, with block it waits for new entries
+// and returns 204 No Content on timeout
+func (h *CacheHandler) ReadStreams(c echo.Context) error {
+	var request api.ReadStreamsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return h.readStreams(c, request, "$", func(ctx context.Context, reads []usecase.StreamRead, options usecase.XReadOptions) ([]usecase.StreamEntries, error) {
+		return h.RedisUsecase.XRead(ctx, reads, options)
+	})
+}
+
+// ReadGroup reads the streams on behalf of the consumer of the group, like ReadStreams
+func (h *CacheHandler) ReadGroup(c echo.Context) error {
+	var request api.ReadGroupRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Group == "" || request.Consumer == "" {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "group and consumer are required"}, "  ")
+	}
+	return h.readStreams(c, request.ReadStreamsRequest, ">", func(ctx context.Context, reads []usecase.StreamRead, options usecase.XReadOptions) ([]usecase.StreamEntries, error) {
+		options.NoAck = request.NoAck
+		return h.RedisUsecase.XReadGroup(ctx, request.Group, request.Consumer, reads, options)
+	})
+}
+
+func (h *CacheHandler) readStreams(c echo.Context, request api.ReadStreamsRequest, newID string,
+	read func(context.Context, []usecase.StreamRead, usecase.XReadOptions) ([]usecase.StreamEntries, error)) error {
+	if len(request.Streams) == 0 || request.Count < 0 || request.Block != nil && *request.Block < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "streams are required, count and block can't be negative"}, "  ")
+	}
+
+	reads := make([]usecase.StreamRead, 0, len(request.Streams))
+	for _, stream := range request.Streams {
+		streamRead := usecase.StreamRead{Key: stream.Key, New: stream.ID == newID}
+		if !streamRead.New {
+			id, err := command.ParseStreamID(stream.ID, 0)
+			if err != nil {
+				return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+			}
+			streamRead.ID = id
+		}
+		reads = append(reads, streamRead)
+	}
+
+	options := usecase.XReadOptions{Count: request.Count}
+	if request.Block != nil {
+		timeout, err := command.BlockTimeout(*request.Block)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+		}
+		options.Block = true
+		options.Timeout = timeout
+	}
+
+	streams, err := read(c.Request().Context(), reads, options)
+	if err != nil {
+		if err == domain.ErrNoGroup {
+			return c.JSONPretty(http.StatusNotFound, ResponseError{Message: err.Error()}, "  ")
+		}
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(streams) == 0 && options.Block {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	response := api.StreamsResponse{Streams: make([]api.KeyStreamEntries, 0, len(streams))}
+	for _, stream := range streams {
+		response.Streams = append(response.Streams, api.KeyStreamEntries{Key: stream.Key, Entries: streamEntriesOf(stream.Entries)})
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) CreateGroup(c echo.Context) error {
+	var request api.CreateGroupRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	id, err := groupIDOf(request.ID)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.XGroupCreate(request.Key, request.Group, id, request.MkStream)
+	if err == domain.ErrBusyGroup {
+		return c.JSONPretty(http.StatusConflict, ResponseError{Message: err.Error()}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *CacheHandler) SetGroupID(c echo.Context) error {
+	var request api.SetGroupIDRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	id, err := groupIDOf(request.ID)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.XGroupSetID(request.Key, request.Group, id)
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// groupIDOf parses the ID of a group, $ (the last entry of the stream) is nil
+func groupIDOf(value string) (*usecase.StreamID, error) {
+	if value == "" || value == "$" {
+		return nil, nil
+	}
+
+	id, err := command.ParseStreamID(value, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// groupError responds with 404 Not Found if the stream or the group doesn't exist
+func groupError(c echo.Context, err error) error {
+	if err == domain.ErrNoGroup {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: err.Error()}, "  ")
+	}
+	return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+}
+
+func (h *CacheHandler) DestroyGroup(c echo.Context) error {
+	ok, err := h.RedisUsecase.XGroupDestroy(params.PathParam(c, "key"), params.PathParam(c, "group"))
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "group not found"}, "  ")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CacheHandler) CreateConsumer(c echo.Context) error {
+	var request api.CreateConsumerRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	created, err := h.RedisUsecase.XGroupCreateConsumer(request.Key, request.Group, request.Consumer)
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	response := api.CreatedResponse{Created: created}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// RemoveConsumer removes the consumer and returns the number of its pending entries
+func (h *CacheHandler) RemoveConsumer(c echo.Context) error {
+	pending, err := h.RedisUsecase.XGroupDelConsumer(params.PathParam(c, "key"), params.PathParam(c, "group"),
+		params.PathParam(c, "consumer"))
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	response := api.CountResponse{Count: pending}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) Acknowledge(c echo.Context) error {
+	var request api.AckRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	ids, err := streamIDsOf(request.IDs)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.XAck(request.Key, request.Group, ids)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetPending returns the summary of the pending entries of the group, or the pending entries
+// from ?start= to ?end= if ?count= is set, filtered by ?idle= (milliseconds) and ?consumer=
+func (h *CacheHandler) GetPending(c echo.Context) error {
+	key, group := params.PathParam(c, "key"), params.PathParam(c, "group")
+	if c.QueryParam("count") == "" {
+		summary, err := h.RedisUsecase.XPendingSummary(key, group)
+		if err != nil {
+			return groupError(c, err)
+		}
+
+		response := api.PendingSummaryResponse{Count: summary.Count, Consumers: summary.Consumers}
+		if summary.Count > 0 {
+			response.Smallest, response.Greatest = summary.Smallest.String(), summary.Greatest.String()
+		}
+		if response.Consumers == nil {
+			response.Consumers = []usecase.ConsumerPending{}
+		}
+		return c.JSONPretty(http.StatusOK, response, "  ")
+	}
+
+	start, end := c.QueryParam("start"), c.QueryParam("end")
+	if start == "" {
+		start = "-"
+	}
+	if end == "" {
+		end = "+"
+	}
+	first, last, err := command.ParseStreamInterval(start, end)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := strconv.Atoi(c.QueryParam("count"))
+	if err != nil || count < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be a non-negative integer"}, "  ")
+	}
+	idle, err := optionalIntQueryParam(c, "idle")
+	if err != nil || idle < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "idle must be a non-negative integer"}, "  ")
+	}
+
+	entries, err := h.RedisUsecase.XPending(key, group, usecase.XPendingOptions{
+		Start:    first,
+		End:      last,
+		Count:    count,
+		MinIdle:  time.Duration(idle) * time.Millisecond,
+		Consumer: c.QueryParam("consumer"),
+	})
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	response := api.PendingEntriesResponse{Entries: make([]api.PendingEntry, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, api.PendingEntry{
+			ID:         entry.ID.String(),
+			Consumer:   entry.Consumer,
+			Idle:       int64(entry.Idle / time.Millisecond),
+			Deliveries: entry.Deliveries,
+		})
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) Claim(c echo.Context) error {
+	var request api.ClaimRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	ids, err := streamIDsOf(request.IDs)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	options := usecase.XClaimOptions{RetryCount: request.RetryCount, Force: request.Force, JustID: request.JustID}
+	if request.Idle != nil {
+		idle := time.Duration(*request.Idle) * time.Millisecond
+		options.Idle = &idle
+	}
+	if request.Time != nil {
+		at := time.Unix(0, *request.Time*int64(time.Millisecond))
+		options.Time = &at
+	}
+	if request.LastID != "" {
+		lastID, err := command.ParseStreamID(request.LastID, 0)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+		}
+		options.LastID = &lastID
+	}
+
+	minIdle := time.Duration(request.MinIdle) * time.Millisecond
+	entries, err := h.RedisUsecase.XClaim(request.Key, request.Group, request.Consumer, minIdle, ids, options)
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	if request.JustID {
+		return c.JSONPretty(http.StatusOK, api.StreamIDsResponse{IDs: streamEntryIDsOf(entries)}, "  ")
+	}
+	response := api.StreamEntriesResponse{Entries: streamEntriesOf(entries)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) AutoClaim(c echo.Context) error {
+	var request api.AutoClaimRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Start == "" {
+		request.Start = "0"
+	}
+	start, err := command.ParseStreamID(request.Start, 0)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	if request.Count == 0 {
+		request.Count = command.DefaultAutoClaimCount
+	}
+	if request.Count < 0 || request.Count > command.MaxAutoClaimCount {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be positive and at most " + strconv.Itoa(command.MaxAutoClaimCount)}, "  ")
+	}
+
+	minIdle := time.Duration(request.MinIdle) * time.Millisecond
+	result, err := h.RedisUsecase.XAutoClaim(request.Key, request.Group, request.Consumer, minIdle, start, request.Count, request.JustID)
+	if err != nil {
+		return groupError(c, err)
+	}
+
+	response := api.AutoClaimResponse{
+		Next:    result.Next.String(),
+		Deleted: make([]string, 0, len(result.Deleted)),
+	}
+	if request.JustID {
+		response.IDs = streamEntryIDsOf(result.Claimed)
+	} else {
+		response.Entries = streamEntriesOf(result.Claimed)
+	}
+	for _, id := range result.Deleted {
+		response.Deleted = append(response.Deleted, id.String())
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func streamIDsOf(values []string) ([]usecase.StreamID, error) {
+	if len(values) == 0 {
+		return nil, domain.ErrSyntax
+	}
+
+	ids := make([]usecase.StreamID, 0, len(values))
+	for _, value := range values {
+		id, err := command.ParseStreamID(value, 0)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func streamEntriesOf(entries []usecase.StreamEntry) []api.StreamEntry {
+	result := make([]api.StreamEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, api.StreamEntry{ID: entry.ID.String(), Fields: entry.Fields})
+	}
+	return result
+}
+
+func streamEntryIDsOf(entries []usecase.StreamEntry) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry.ID.String())
+	}
+	return result
+}
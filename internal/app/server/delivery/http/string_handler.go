@@ -0,0 +1,256 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (h *CacheHandler) IncrementString(c echo.Context) error {
+	var request api.IncrementRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	value, err := h.RedisUsecase.IncrBy(params.PathParam(c, "key"), request.Increment)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.IntegerValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IncrementStringByFloat(c echo.Context) error {
+	var request api.IncrementByFloatRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	value, err := h.RedisUsecase.IncrByFloat(params.PathParam(c, "key"), request.Increment)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.FloatValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) AppendToString(c echo.Context) error {
+	var request api.AppendRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	size, err := h.RedisUsecase.Append(params.PathParam(c, "key"), request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SizeResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) SetStringRange(c echo.Context) error {
+	var request api.SetRangeRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	size, err := h.RedisUsecase.SetRange(params.PathParam(c, "key"), request.Offset, request.Value)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SizeResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetStringLength(c echo.Context) error {
+	size, err := h.RedisUsecase.StrLen(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SizeResponse{Size: size}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetStringRange returns the substring from ?start= to ?end= inclusive, the whole string by default
+func (h *CacheHandler) GetStringRange(c echo.Context) error {
+	start, end := 0, -1
+	var err error
+	if value := c.QueryParam("start"); value != "" {
+		if start, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "start must be an integer"}, "  ")
+		}
+	}
+	if value := c.QueryParam("end"); value != "" {
+		if end, err = strconv.Atoi(value); err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "end must be an integer"}, "  ")
+		}
+	}
+
+	value, err := h.RedisUsecase.GetRange(params.PathParam(c, "key"), start, end)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetStrings returns the values of the keys in the request order, null for a missing key
+// or a key holding another type
+func (h *CacheHandler) GetStrings(c echo.Context) error {
+	var request api.GetStringsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Keys) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "keys are required"}, "  ")
+	}
+
+	response := api.OptionalValuesResponse{Values: h.RedisUsecase.MGet(request.Keys)}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) SetStrings(c echo.Context) error {
+	var request api.SetStringsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Pairs) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "pairs are required"}, "  ")
+	}
+
+	h.RedisUsecase.MSet(request.Pairs)
+	return c.NoContent(http.StatusCreated)
+}
+
+// SetStringsIfNotExist sets all the keys only if none of them exists, like PUT /cache/string with nx
+func (h *CacheHandler) SetStringsIfNotExist(c echo.Context) error {
+	var request api.SetStringsRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Pairs) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "pairs are required"}, "  ")
+	}
+
+	response := api.SetStringResponse{Written: h.RedisUsecase.MSetNX(request.Pairs)}
+	status := http.StatusCreated
+	if !response.Written {
+		status = http.StatusOK
+	}
+	return c.JSONPretty(status, response, "  ")
+}
+
+// GetStringWithExpiration is GETEX, the expiration is updated only if it's set in the request
+func (h *CacheHandler) GetStringWithExpiration(c echo.Context) error {
+	var request api.GetStringRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	expiry, withExpiry, err := expiryOf(request.Expiration)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	if withExpiry && request.Persist {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "persist can't be set with an expiration"}, "  ")
+	}
+
+	value, ok, err := h.RedisUsecase.GetEx(params.PathParam(c, "key"), usecase.GetExOptions{Expiry: expiry, Persist: request.Persist})
+	return h.stringResponse(c, value, ok, err)
+}
+
+func (h *CacheHandler) GetAndDeleteString(c echo.Context) error {
+	value, ok, err := h.RedisUsecase.GetDel(params.PathParam(c, "key"))
+	return h.stringResponse(c, value, ok, err)
+}
+
+func (h *CacheHandler) stringResponse(c echo.Context, value string, ok bool, err error) error {
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+
+	response := api.ValueResponse{Value: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// expiryOf converts the expiration options of a request to the expiration time,
+// the returned flag is false if none of them is set
+func expiryOf(expiration api.Expiration) (time.Time, bool, error) {
+	options := map[string]*int64{"EX": expiration.Ex, "PX": expiration.Px, "EXAT": expiration.ExAt, "PXAT": expiration.PxAt}
+
+	var expiry time.Time
+	withExpiry := false
+	for option, amount := range options {
+		if amount == nil {
+			continue
+		}
+		if withExpiry {
+			return time.Time{}, false, errors.New("only one of ex, px, exat and pxat can be set")
+		}
+
+		var err error
+		if expiry, err = command.Expiry(option, *amount, time.Now()); err != nil {
+			return time.Time{}, false, err
+		}
+		withExpiry = true
+	}
+	return expiry, withExpiry, nil
+}
+
+var errUnknownEncoding = errors.New("encoding must be base64")
+
+// encodeValue encodes a binary value for a JSON response, the value is returned as is without an encoding
+func encodeValue(value, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return value, nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(value)), nil
+	default:
+		return "", errUnknownEncoding
+	}
+}
+
+// decodeValue decodes a value of a request encoded by encodeValue
+func decodeValue(value, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return value, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", errors.New("value isn't valid base64")
+		}
+		return string(decoded), nil
+	default:
+		return "", errUnknownEncoding
+	}
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+func (h *CacheHandler) CreateTimeSeries(c echo.Context) error {
+	var request api.CreateTimeSeriesRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	options := usecase.TSCreateOptions{Retention: request.Retention, Labels: tsLabelsOf(request.Labels)}
+	err = h.RedisUsecase.TSCreate(request.Key, options)
+	if err == domain.ErrTSKeyExists {
+		return c.JSONPretty(http.StatusConflict, ResponseError{Message: err.Error()}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// tsLabelsOf converts the labels of the request sorted by the name, JSON objects have no order
+func tsLabelsOf(labels map[string]string) []usecase.TSLabel {
+	result := make([]usecase.TSLabel, 0, len(labels))
+	for name, value := range labels {
+		result = append(result, usecase.TSLabel{Name: name, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// tsTimestampOf returns the timestamp of the request, the current time by default
+func tsTimestampOf(timestamp *int64) (int64, error) {
+	if timestamp == nil {
+		return command.ParseTSTimestamp("*")
+	}
+	if *timestamp < 0 {
+		return 0, domain.ErrTSTimestamp
+	}
+	return *timestamp, nil
+}
+
+func (h *CacheHandler) AddSample(c echo.Context) error {
+	var request api.AddSampleRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	sample := usecase.TSSample{Value: request.Value}
+	if sample.Timestamp, err = tsTimestampOf(request.Timestamp); err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	options := usecase.TSCreateOptions{Retention: request.Retention, Labels: tsLabelsOf(request.Labels)}
+	timestamp, err := h.RedisUsecase.TSAdd(request.Key, sample, options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.TimestampResponse{Timestamp: timestamp}
+	return c.JSONPretty(http.StatusCreated, response, "  ")
+}
+
+// AddSamples adds every sample on its own, the errors are returned in the results
+func (h *CacheHandler) AddSamples(c echo.Context) error {
+	var request api.AddSamplesRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if len(request.Samples) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "samples are required"}, "  ")
+	}
+
+	samples := make([]usecase.TSKeySample, 0, len(request.Samples))
+	for _, sample := range request.Samples {
+		timestamp, err := tsTimestampOf(sample.Timestamp)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+		}
+		samples = append(samples, usecase.TSKeySample{Key: sample.Key, TSSample: usecase.TSSample{Timestamp: timestamp, Value: sample.Value}})
+	}
+
+	errs := h.RedisUsecase.TSMAdd(samples)
+	response := api.SampleResultsResponse{Results: make([]api.SampleResult, 0, len(samples))}
+	for i, err := range errs {
+		if err != nil {
+			response.Results = append(response.Results, api.SampleResult{Error: err.Error()})
+			continue
+		}
+		response.Results = append(response.Results, api.SampleResult{Timestamp: &samples[i].Timestamp})
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetSampleRange returns the samples from ?from= (- by default) to ?to= (+ by default), at most ?count= of them,
+// in the reverse order with ?rev=true. With ?aggregation= and ?bucket_duration= the samples are aggregated.
+func (h *CacheHandler) GetSampleRange(c echo.Context) error {
+	from, to := c.QueryParam("from"), c.QueryParam("to")
+	if from == "" {
+		from = "-"
+	}
+	if to == "" {
+		to = "+"
+	}
+	first, err := command.ParseTSBound(from)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+	last, err := command.ParseTSBound(to)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	var options usecase.TSRangeOptions
+	options.Count, err = optionalIntQueryParam(c, "count")
+	if err != nil || options.Count < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be a non-negative integer"}, "  ")
+	}
+	if aggregation := c.QueryParam("aggregation"); aggregation != "" {
+		bucketDuration, err := strconv.ParseInt(c.QueryParam("bucket_duration"), 10, 64)
+		if err != nil {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: domain.ErrTSBucketDuration.Error()}, "  ")
+		}
+		options.Aggregation = &usecase.TSAggregation{Type: aggregation, BucketDuration: bucketDuration}
+	}
+
+	query := h.RedisUsecase.TSRange
+	if c.QueryParam("rev") == "true" {
+		query = h.RedisUsecase.TSRevRange
+	}
+	samples, err := query(params.PathParam(c, "key"), first, last, options)
+	if err == domain.ErrTSKeyNotFound {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SamplesResponse{Samples: samples}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetSeriesRange(c echo.Context) error {
+	var request api.RangeSeriesRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Count < 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be a non-negative integer"}, "  ")
+	}
+	from, to := int64(0), int64(math.MaxInt64)
+	if request.From != nil {
+		from = *request.From
+	}
+	if request.To != nil {
+		to = *request.To
+	}
+
+	options := usecase.TSRangeOptions{Count: request.Count, Aggregation: request.Aggregation, WithLabels: request.WithLabels}
+	series, err := h.RedisUsecase.TSMRange(from, to, request.Filters, options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.SeriesResponse{Series: make([]api.Series, 0, len(series))}
+	for _, s := range series {
+		item := api.Series{Key: s.Key, Samples: s.Samples}
+		if request.WithLabels {
+			item.Labels = make(map[string]string, len(s.Labels))
+			for _, label := range s.Labels {
+				item.Labels[label.Name] = label.Value
+			}
+		}
+		response.Series = append(response.Series, item)
+	}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) CreateRule(c echo.Context) error {
+	var request api.CreateRuleRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	err = h.RedisUsecase.TSCreateRule(request.Source, request.Destination, request.Aggregation)
+	if err == domain.ErrTSKeyNotFound {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key is not found"}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+func (h *CacheHandler) DeleteRule(c echo.Context) error {
+	err := h.RedisUsecase.TSDeleteRule(params.PathParam(c, "key"), params.PathParam(c, "destination"))
+	if err == domain.ErrTSKeyNotFound || err == domain.ErrTSRuleNotFound {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: err.Error()}, "  ")
+	}
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
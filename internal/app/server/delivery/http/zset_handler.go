@@ -0,0 +1,247 @@
+package http
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/babon21/redis-impl/internal/pkg/http/params"
+	"github.com/babon21/redis-impl/internal/pkg/server/delivery/http/api"
+	"github.com/labstack/echo"
+	"net/http"
+	"strconv"
+)
+
+func (h *CacheHandler) AddToSortedSet(c echo.Context) error {
+	var request api.AddToSortedSetRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Incr {
+		if len(request.Members) != 1 {
+			return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "incr supports a single member"}, "  ")
+		}
+
+		member := request.Members[0]
+		score, ok, err := h.RedisUsecase.ZAddIncr(request.Key, member.Member, member.Score, request.ZAddOptions)
+		if err != nil {
+			return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+		}
+
+		response := api.ScoreResponse{}
+		if ok {
+			response.Score = &score
+		}
+		return c.JSONPretty(http.StatusOK, response, "  ")
+	}
+
+	count, err := h.RedisUsecase.ZAdd(request.Key, request.Members, request.ZAddOptions)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) RemoveFromSortedSet(c echo.Context) error {
+	var request api.SetMembersRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	count, err := h.RedisUsecase.ZRem(request.Key, request.Members)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) IncrementScore(c echo.Context) error {
+	var request api.IncrementScoreRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	score, err := h.RedisUsecase.ZIncrBy(request.Key, request.Increment, request.Member)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ScoreResponse{Score: &score}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) PopMinFromSortedSet(c echo.Context) error {
+	return h.popFromSortedSet(c, h.RedisUsecase.ZPopMin)
+}
+
+func (h *CacheHandler) PopMaxFromSortedSet(c echo.Context) error {
+	return h.popFromSortedSet(c, h.RedisUsecase.ZPopMax)
+}
+
+func (h *CacheHandler) popFromSortedSet(c echo.Context, pop func(string, int) ([]usecase.ScoredMember, error)) error {
+	var request api.PopFromSetRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Count <= 0 {
+		request.Count = 1
+	}
+
+	members, err := pop(request.Key, request.Count)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ScoredMembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) UnionSortedSets(c echo.Context) error {
+	return h.storeSortedSets(c, h.RedisUsecase.ZUnionStore)
+}
+
+func (h *CacheHandler) IntersectSortedSets(c echo.Context) error {
+	return h.storeSortedSets(c, h.RedisUsecase.ZInterStore)
+}
+
+func (h *CacheHandler) storeSortedSets(c echo.Context, store func(string, []string, usecase.ZStoreOptions) (int, error)) error {
+	var request api.SortedSetOperationRequest
+	err := c.Bind(&request)
+	if err != nil {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if request.Destination == "" || len(request.Keys) == 0 {
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "destination and keys are required"}, "  ")
+	}
+
+	options := usecase.ZStoreOptions{Weights: request.Weights, Aggregate: request.Aggregate}
+	count, err := store(request.Destination, request.Keys, options)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetSortedSetCardinality(c echo.Context) error {
+	count, err := h.RedisUsecase.ZCard(params.PathParam(c, "key"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+func (h *CacheHandler) GetScore(c echo.Context) error {
+	score, ok, err := h.RedisUsecase.ZScore(params.PathParam(c, "key"), params.PathParam(c, "member"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key or member is not found"}, "  ")
+	}
+
+	response := api.ScoreResponse{Score: &score}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetRank returns the rank from the lowest score or from the highest one with ?rev=true
+func (h *CacheHandler) GetRank(c echo.Context) error {
+	rank := h.RedisUsecase.ZRank
+	if c.QueryParam("rev") == "true" {
+		rank = h.RedisUsecase.ZRevRank
+	}
+
+	value, ok, err := rank(params.PathParam(c, "key"), params.PathParam(c, "member"))
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	if !ok {
+		return c.JSONPretty(http.StatusNotFound, ResponseError{Message: "key or member is not found"}, "  ")
+	}
+
+	response := api.RankResponse{Rank: value}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// CountInSortedSet counts the members with scores between ?min= and ?max=, the whole set by default
+func (h *CacheHandler) CountInSortedSet(c echo.Context) error {
+	min, max := c.QueryParam("min"), c.QueryParam("max")
+	if min == "" {
+		min = "-inf"
+	}
+	if max == "" {
+		max = "+inf"
+	}
+
+	count, err := h.RedisUsecase.ZCount(params.PathParam(c, "key"), min, max)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.CountResponse{Count: count}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
+
+// GetSortedSetRange accepts the ZRANGE arguments as query parameters:
+// start, stop, by (index, score or lex), rev, offset and count
+func (h *CacheHandler) GetSortedSetRange(c echo.Context) error {
+	query := usecase.ZRangeQuery{
+		Start: c.QueryParam("start"),
+		Stop:  c.QueryParam("stop"),
+		Rev:   c.QueryParam("rev") == "true",
+	}
+
+	switch c.QueryParam("by") {
+	case "", "index":
+		query.By = usecase.ZRangeByIndex
+		if query.Start == "" {
+			query.Start = "0"
+		}
+		if query.Stop == "" {
+			query.Stop = "-1"
+		}
+	case "score":
+		query.By = usecase.ZRangeByScore
+	case "lex":
+		query.By = usecase.ZRangeByLex
+	default:
+		return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "by must be index, score or lex"}, "  ")
+	}
+
+	if c.QueryParam("offset") != "" || c.QueryParam("count") != "" {
+		limit := usecase.ZRangeLimit{Count: -1}
+		var err error
+		if value := c.QueryParam("offset"); value != "" {
+			if limit.Offset, err = strconv.Atoi(value); err != nil {
+				return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "offset must be an integer"}, "  ")
+			}
+		}
+		if value := c.QueryParam("count"); value != "" {
+			if limit.Count, err = strconv.Atoi(value); err != nil {
+				return c.JSONPretty(http.StatusBadRequest, ResponseError{Message: "count must be an integer"}, "  ")
+			}
+		}
+		query.Limit = &limit
+	}
+
+	members, err := h.RedisUsecase.ZRange(params.PathParam(c, "key"), query)
+	if err != nil {
+		return c.JSONPretty(http.StatusUnprocessableEntity, ResponseError{Message: err.Error()}, "  ")
+	}
+
+	response := api.ScoredMembersResponse{Members: members}
+	return c.JSONPretty(http.StatusOK, response, "  ")
+}
@@ -0,0 +1,109 @@
+package resp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// splitArgs splits an inline request the way redis-cli does: arguments are separated by spaces,
+// double-quoted arguments support escape sequences (\n, \r, \t, \b, \a, \xHH, \", \\)
+// and single-quoted arguments are taken literally except for \'
+func splitArgs(line string) ([]string, error) {
+	args := make([]string, 0, 4)
+	i := 0
+
+	for {
+		for i < len(line) && isSpace(line[i]) {
+			i++
+		}
+		if i == len(line) {
+			return args, nil
+		}
+
+		var arg strings.Builder
+		inDoubleQuotes, inSingleQuotes, done := false, false, false
+
+		for !done {
+			if i == len(line) {
+				if inDoubleQuotes || inSingleQuotes {
+					return nil, ErrUnbalancedQuotes
+				}
+				break
+			}
+
+			ch := line[i]
+			switch {
+			case inDoubleQuotes:
+				switch {
+				case ch == '\\' && i+3 < len(line) && line[i+1] == 'x' && isHexDigit(line[i+2]) && isHexDigit(line[i+3]):
+					value, _ := strconv.ParseUint(line[i+2:i+4], 16, 8)
+					arg.WriteByte(byte(value))
+					i += 3
+				case ch == '\\' && i+1 < len(line):
+					i++
+					arg.WriteByte(unescape(line[i]))
+				case ch == '"':
+					// the closing quote must be followed by a space or nothing at all
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, ErrUnbalancedQuotes
+					}
+					done = true
+				default:
+					arg.WriteByte(ch)
+				}
+			case inSingleQuotes:
+				switch {
+				case ch == '\\' && i+1 < len(line) && line[i+1] == '\'':
+					i++
+					arg.WriteByte('\'')
+				case ch == '\'':
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, ErrUnbalancedQuotes
+					}
+					done = true
+				default:
+					arg.WriteByte(ch)
+				}
+			default:
+				switch {
+				case isSpace(ch):
+					done = true
+				case ch == '"':
+					inDoubleQuotes = true
+				case ch == '\'':
+					inSingleQuotes = true
+				default:
+					arg.WriteByte(ch)
+				}
+			}
+			i++
+		}
+
+		args = append(args, arg.String())
+	}
+}
+
+func unescape(ch byte) byte {
+	switch ch {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'b':
+		return '\b'
+	case 'a':
+		return '\a'
+	default:
+		return ch
+	}
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\v' || ch == '\f'
+}
+
+func isHexDigit(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
@@ -0,0 +1,65 @@
+package resp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_splitArgs(t *testing.T) {
+	type args struct {
+		line string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "split by spaces",
+			args:    args{line: "  SET  mykey myval "},
+			want:    []string{"SET", "mykey", "myval"},
+			wantErr: false,
+		},
+		{
+			name:    "empty line",
+			args:    args{line: ""},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "double quotes with escapes",
+			args:    args{line: `SET "my key" "a\tb\x41\""`},
+			want:    []string{"SET", "my key", "a\tbA\""},
+			wantErr: false,
+		},
+		{
+			name:    "single quotes are literal",
+			args:    args{line: `SET k 'a\nb\'c'`},
+			want:    []string{"SET", "k", `a\nb'c`},
+			wantErr: false,
+		},
+		{
+			name:    "unbalanced quotes",
+			args:    args{line: `GET "mykey`},
+			wantErr: true,
+		},
+		{
+			name:    "closing quote followed by a character",
+			args:    args{line: `GET "my"key`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitArgs(tt.args.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("splitArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitArgs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+)
+
+const (
+	maxMultiBulkLength = 1024 * 1024
+	maxBulkLength      = 512 * 1024 * 1024
+	maxInlineLength    = 64 * 1024
+)
+
+var (
+	// ErrProtocol will throw if a client sends a malformed request
+	ErrProtocol = errors.New("ERR Protocol error")
+	// ErrUnbalancedQuotes will throw if an inline request has unbalanced quotes
+	ErrUnbalancedQuotes = errors.New("ERR Protocol error: unbalanced quotes in request")
+)
+
+// Reader parses client requests encoded either with the RESP multi-bulk format
+// or as inline commands, i.e. space-separated arguments terminated by a newline
+type Reader struct {
+	reader *bufio.Reader
+	// waiting is set while a goroutine started by NotifyClose waits for the next request
+	waiting chan error
+}
+
+// NewReader will create a request reader over r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{reader: bufio.NewReaderSize(r, maxInlineLength)}
+}
+
+// Buffered returns the number of bytes of pipelined requests which are already read from the connection
+func (r *Reader) Buffered() int {
+	if r.waiting != nil {
+		return 0
+	}
+	return r.reader.Buffered()
+}
+
+// NotifyClose waits for the next request in the background and calls cancel if the connection
+// is closed or fails before it arrives, so a blocked command can give up on a gone client.
+// The following ReadCommand waits for the background read to finish.
+func (r *Reader) NotifyClose(cancel func()) {
+	r.waiting = make(chan error, 1)
+	go func(waiting chan<- error) {
+		_, err := r.reader.Peek(1)
+		if err != nil {
+			cancel()
+		}
+		waiting <- err
+	}(r.waiting)
+}
+
+// ReadCommand reads a single request and returns its arguments.
+// An empty inline request results in empty arguments.
+func (r *Reader) ReadCommand() ([]string, error) {
+	if r.waiting != nil {
+		err := <-r.waiting
+		r.waiting = nil
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prefix, err := r.reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix[0] != '*' {
+		return r.readInlineCommand()
+	}
+
+	line, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(string(line[1:]))
+	if err != nil || count > maxMultiBulkLength {
+		return nil, ErrProtocol
+	}
+	if count <= 0 {
+		return []string{}, nil
+	}
+
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		arg, err := r.readBulkString()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func (r *Reader) readBulkString() (string, error) {
+	line, err := r.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) == 0 || line[0] != '$' {
+		return "", ErrProtocol
+	}
+
+	length, err := strconv.Atoi(string(line[1:]))
+	if err != nil || length < 0 || length > maxBulkLength {
+		return "", ErrProtocol
+	}
+
+	// the buffer grows as the payload arrives, so the declared length alone doesn't allocate memory
+	var buf bytes.Buffer
+	if length+2 <= maxInlineLength {
+		buf.Grow(length + 2)
+	} else {
+		buf.Grow(maxInlineLength)
+	}
+	if _, err := io.CopyN(&buf, r.reader, int64(length+2)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+
+	payload := buf.Bytes()
+	if payload[length] != '\r' || payload[length+1] != '\n' {
+		return "", ErrProtocol
+	}
+	return string(payload[:length]), nil
+}
+
+func (r *Reader) readInlineCommand() ([]string, error) {
+	line, err := r.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, ErrProtocol
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line = line[:len(line)-1]
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return splitArgs(string(line))
+}
+
+func (r *Reader) readLine() ([]byte, error) {
+	line, err := r.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, ErrProtocol
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, ErrProtocol
+	}
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,76 @@
+package resp
+
+import (
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestReader_ReadCommand(t *testing.T) {
+	type args struct {
+		request string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "multi-bulk request",
+			args: args{request: "*2\r\n$3\r\nGET\r\n$3\r\nkey\r\n"},
+			want: []string{"GET", "key"},
+		},
+		{
+			name: "empty bulk string",
+			args: args{request: "*2\r\n$3\r\nGET\r\n$0\r\n\r\n"},
+			want: []string{"GET", ""},
+		},
+		{
+			name: "binary bulk string",
+			args: args{request: "*1\r\n$4\r\na\r\nb\r\n"},
+			want: []string{"a\r\nb"},
+		},
+		{
+			name:    "bulk string without the terminator",
+			args:    args{request: "*1\r\n$3\r\nGETXX"},
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "bulk string over the limit",
+			args:    args{request: "*1\r\n$536870913\r\n"},
+			wantErr: ErrProtocol,
+		},
+		{
+			name:    "truncated bulk string",
+			args:    args{request: "*1\r\n$536870912\r\nabc"},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReader(strings.NewReader(tt.args.request)).ReadCommand()
+			if err != tt.wantErr {
+				t.Fatalf("ReadCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCommand() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReader_ReadCommandDoesNotTrustTheDeclaredLength(t *testing.T) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := NewReader(strings.NewReader("*1\r\n$536870912\r\nabc")).ReadCommand(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadCommand() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	runtime.ReadMemStats(&after)
+
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1024*1024 {
+		t.Errorf("ReadCommand() allocated %d bytes for a 3-byte payload", allocated)
+	}
+}
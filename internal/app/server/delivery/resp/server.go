@@ -0,0 +1,124 @@
+package resp
+
+import (
+	"context"
+	"crypto/tls"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"github.com/rs/zerolog/log"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+// serverVersion is the Redis version reported to clients by HELLO
+const serverVersion = "6.2.0"
+
+// Server serves the Redis wire protocol on top of RedisUsecase
+type Server struct {
+	RedisUsecase usecase.RedisUsecase
+	// TLSConfig enables TLS for the listener created by ListenAndServe
+	TLSConfig *tls.Config
+	lastID    int64
+}
+
+// NewServer will create a RESP server backed by the usecase
+func NewServer(us usecase.RedisUsecase) *Server {
+	return &Server{RedisUsecase: us}
+}
+
+// ListenAndServe listens on the TCP address and serves the accepted connections
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	if s.TLSConfig != nil {
+		listener = tls.NewListener(listener, s.TLSConfig)
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on the listener and handles each one in its own goroutine
+func (s *Server) Serve(listener net.Listener) error {
+	defer listener.Close()
+	log.Info().Msgf("resp server started on %s", listener.Addr())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConnection(conn)
+	}
+}
+
+func (s *Server) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	reader := NewReader(conn)
+	writer := NewWriter(conn)
+	session := &session{id: atomic.AddInt64(&s.lastID, 1), protocol: Protocol2}
+
+	for {
+		args, err := reader.ReadCommand()
+		if err == ErrProtocol || err == ErrUnbalancedQuotes {
+			_ = writer.WriteReply(command.NewError(err))
+			_ = writer.Flush()
+			return
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Error().Msgf("resp connection %s: %s", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		quit := strings.EqualFold(args[0], "quit")
+		var reply command.Reply
+		switch {
+		case quit:
+			reply = command.OK
+		case strings.EqualFold(args[0], "hello"):
+			reply = session.hello(args)
+			writer.SetProtocol(session.protocol)
+		default:
+			reply = s.execute(reader, args)
+		}
+
+		if err := writer.WriteReply(reply); err != nil {
+			log.Error().Msgf("resp connection %s: %s", conn.RemoteAddr(), err)
+			return
+		}
+		// pipelined requests are answered in order and flushed once the whole batch is processed
+		if reader.Buffered() == 0 || quit {
+			if err := writer.Flush(); err != nil {
+				return
+			}
+		}
+
+		if quit {
+			return
+		}
+	}
+}
+
+// execute runs the command, a blocking command stops waiting if the client disconnects
+func (s *Server) execute(reader *Reader, args []string) command.Reply {
+	cmd, ok := command.Lookup(args[0])
+	// with pipelined requests the connection can't be watched without reading them
+	if !ok || cmd.BlockingHandler == nil || reader.Buffered() > 0 {
+		return command.Execute(s.RedisUsecase, args)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader.NotifyClose(cancel)
+	return command.ExecuteContext(ctx, s.RedisUsecase, args)
+}
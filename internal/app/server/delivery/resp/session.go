@@ -0,0 +1,56 @@
+package resp
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"strconv"
+	"strings"
+)
+
+// session holds the per-connection state negotiated by the client
+type session struct {
+	id       int64
+	protocol int
+	name     string
+}
+
+// hello implements HELLO [protover [AUTH username password] [SETNAME clientname]]
+func (s *session) hello(args []string) command.Reply {
+	protocol := s.protocol
+	if len(args) > 1 {
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return command.Error("ERR Protocol version is not an integer or out of range")
+		}
+		if version != Protocol2 && version != Protocol3 {
+			return command.Error("NOPROTO unsupported protocol version")
+		}
+		protocol = version
+	}
+
+	name := s.name
+	for i := 2; i < len(args); i++ {
+		switch option := strings.ToLower(args[i]); {
+		case option == "auth" && i+2 < len(args):
+			// there are no users configured, every client is authenticated as default
+			i += 2
+		case option == "setname" && i+1 < len(args):
+			name = args[i+1]
+			i++
+		default:
+			return command.Error("ERR Syntax error in HELLO option '" + args[i] + "'")
+		}
+	}
+
+	s.protocol = protocol
+	s.name = name
+
+	return command.Map{
+		{Key: command.BulkString("server"), Value: command.BulkString("redis")},
+		{Key: command.BulkString("version"), Value: command.BulkString(serverVersion)},
+		{Key: command.BulkString("proto"), Value: command.Integer(s.protocol)},
+		{Key: command.BulkString("id"), Value: command.Integer(s.id)},
+		{Key: command.BulkString("mode"), Value: command.BulkString("standalone")},
+		{Key: command.BulkString("role"), Value: command.BulkString("master")},
+		{Key: command.BulkString("modules"), Value: command.Array{}},
+	}
+}
@@ -0,0 +1,165 @@
+package resp
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Protocol2 is the RESP2 protocol version used by default
+	Protocol2 = 2
+	// Protocol3 is the RESP3 protocol version negotiated with HELLO 3
+	Protocol3 = 3
+)
+
+// Writer encodes command replies with the protocol version negotiated by the session.
+// RESP3-only types are downgraded to their RESP2 equivalents for RESP2 sessions.
+type Writer struct {
+	writer   *bufio.Writer
+	protocol int
+}
+
+// NewWriter will create a RESP2 reply writer over w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{writer: bufio.NewWriter(w), protocol: Protocol2}
+}
+
+// SetProtocol switches the encoding of the following replies
+func (w *Writer) SetProtocol(protocol int) {
+	w.protocol = protocol
+}
+
+// WriteReply encodes the reply, nested replies are encoded recursively
+func (w *Writer) WriteReply(reply command.Reply) error {
+	switch r := reply.(type) {
+	case command.SimpleString:
+		return w.writeLine('+', string(r))
+	case command.Error:
+		return w.writeLine('-', strings.NewReplacer("\r", " ", "\n", " ").Replace(string(r)))
+	case command.Integer:
+		return w.writeLine(':', strconv.FormatInt(int64(r), 10))
+	case command.BulkString:
+		return w.writeBulkString(string(r))
+	case command.Null:
+		if w.protocol == Protocol3 {
+			return w.writeLine('_', "")
+		}
+		return w.writeLine('$', "-1")
+	case command.NullArray:
+		if w.protocol == Protocol3 {
+			return w.writeLine('_', "")
+		}
+		return w.writeLine('*', "-1")
+	case command.Double:
+		if w.protocol == Protocol3 {
+			return w.writeLine(',', formatDouble(float64(r)))
+		}
+		return w.writeBulkString(formatDouble(float64(r)))
+	case command.Boolean:
+		if w.protocol == Protocol3 {
+			if r {
+				return w.writeLine('#', "t")
+			}
+			return w.writeLine('#', "f")
+		}
+		if r {
+			return w.writeLine(':', "1")
+		}
+		return w.writeLine(':', "0")
+	case command.Array:
+		return w.writeAggregate('*', r)
+	case command.Set:
+		if w.protocol == Protocol3 {
+			return w.writeAggregate('~', r)
+		}
+		return w.writeAggregate('*', r)
+	case command.Push:
+		if w.protocol == Protocol3 {
+			return w.writeAggregate('>', r)
+		}
+		return w.writeAggregate('*', r)
+	case command.Map:
+		return w.writeMap(r)
+	default:
+		return fmt.Errorf("unsupported reply type %T", reply)
+	}
+}
+
+// Flush writes the buffered replies to the connection
+func (w *Writer) Flush() error {
+	return w.writer.Flush()
+}
+
+func (w *Writer) writeAggregate(prefix byte, items []command.Reply) error {
+	if err := w.writeLine(prefix, strconv.Itoa(len(items))); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := w.WriteReply(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer) writeMap(entries command.Map) error {
+	var err error
+	if w.protocol == Protocol3 {
+		err = w.writeLine('%', strconv.Itoa(len(entries)))
+	} else {
+		err = w.writeLine('*', strconv.Itoa(len(entries)*2))
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := w.WriteReply(entry.Key); err != nil {
+			return err
+		}
+		if err := w.WriteReply(entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer) writeBulkString(value string) error {
+	if err := w.writeLine('$', strconv.Itoa(len(value))); err != nil {
+		return err
+	}
+	if _, err := w.writer.WriteString(value); err != nil {
+		return err
+	}
+	_, err := w.writer.WriteString("\r\n")
+	return err
+}
+
+func (w *Writer) writeLine(prefix byte, line string) error {
+	if err := w.writer.WriteByte(prefix); err != nil {
+		return err
+	}
+	if _, err := w.writer.WriteString(line); err != nil {
+		return err
+	}
+	_, err := w.writer.WriteString("\r\n")
+	return err
+}
+
+func formatDouble(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	case math.IsNaN(value):
+		return "nan"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,56 @@
+package resp
+
+import (
+	"bytes"
+	"github.com/babon21/redis-impl/internal/app/server/command"
+	"testing"
+)
+
+func TestWriter_WriteReply(t *testing.T) {
+	type args struct {
+		protocol int
+		reply    command.Reply
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "null in RESP2",
+			args: args{protocol: Protocol2, reply: command.Null{}},
+			want: "$-1\r\n",
+		},
+		{
+			name: "null array in RESP2",
+			args: args{protocol: Protocol2, reply: command.NullArray{}},
+			want: "*-1\r\n",
+		},
+		{
+			name: "null array in RESP3",
+			args: args{protocol: Protocol3, reply: command.NullArray{}},
+			want: "_\r\n",
+		},
+		{
+			name: "nested null array",
+			args: args{protocol: Protocol2, reply: command.Array{command.BulkString("a"), command.NullArray{}}},
+			want: "*2\r\n$1\r\na\r\n*-1\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			w := NewWriter(&buffer)
+			w.SetProtocol(tt.args.protocol)
+			if err := w.WriteReply(tt.args.reply); err != nil {
+				t.Fatalf("WriteReply() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buffer.String(); got != tt.want {
+				t.Errorf("WriteReply() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
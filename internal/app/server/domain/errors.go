@@ -6,8 +6,77 @@ var (
 	// ErrInternalServerError will throw if any the Internal Server Error happen
 	ErrInternalServerError = errors.New("Internal Server Error")
 	// ErrNotFound will throw if the requested item is not exists
-	ErrNotFound        = errors.New("No item with the specified ID found")
-	ErrWrongType       = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
-	ErrIndexOutOfRange = errors.New("ERR index out of range")
-	ErrNoSuchKey       = errors.New("ERR no such key")
+	ErrNotFound          = errors.New("No item with the specified ID found")
+	ErrWrongType         = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	ErrIndexOutOfRange   = errors.New("ERR index out of range")
+	ErrNoSuchKey         = errors.New("ERR no such key")
+	ErrNotInteger        = errors.New("ERR value is not an integer or out of range")
+	ErrValueOutOfRange   = errors.New("ERR value is out of range")
+	ErrHashNotInteger    = errors.New("ERR hash value is not an integer")
+	ErrOverflow          = errors.New("ERR increment or decrement would overflow")
+	ErrIncrNaNOrInfinity = errors.New("ERR increment would produce NaN or Infinity")
+	ErrOffsetOutOfRange  = errors.New("ERR offset is out of range")
+	ErrStringTooLong     = errors.New("ERR string exceeds maximum allowed size (proto-max-bulk-len)")
+	ErrInvalidExpireTime = errors.New("ERR invalid expire time")
+	ErrBitOffset         = errors.New("ERR bit offset is not an integer or out of range")
+	ErrBitValue          = errors.New("ERR bit is not an integer or out of range")
+	ErrBitOpNot          = errors.New("ERR BITOP NOT must be called with a single source key.")
+	ErrBitFieldType      = errors.New("ERR Invalid bitfield type. Use something like i16 u8. Note that u64 is not supported but i64 is.")
+	ErrNotFloat          = errors.New("ERR value is not a valid float")
+	ErrSyntax            = errors.New("ERR syntax error")
+	ErrScoreIsNaN        = errors.New("ERR resulting score is not a number (NaN)")
+	ErrMinMaxNotFloat    = errors.New("ERR min or max is not a float")
+	ErrMinMaxNotLex      = errors.New("ERR min or max not valid string range item")
+	ErrLimitWithIndex    = errors.New("ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	ErrXXAndNX           = errors.New("ERR XX and NX options at the same time are not compatible")
+	ErrGTLTAndNX         = errors.New("ERR GT, LT, and/or NX options at the same time are not compatible")
+	ErrTimeoutNotFloat   = errors.New("ERR timeout is not a float or out of range")
+	ErrTimeoutIsNegative = errors.New("ERR timeout is negative")
+	ErrTimeoutOutOfRange = errors.New("ERR timeout is out of range")
+	ErrStreamIDTooSmall  = errors.New("ERR The ID specified in XADD is equal or smaller than the target stream top item")
+	ErrStreamIDZero      = errors.New("ERR The ID specified in XADD must be greater than 0-0")
+	ErrStreamIDInvalid   = errors.New("ERR Invalid stream ID specified as stream command argument")
+	ErrStreamExhausted   = errors.New("ERR The stream has exhausted the last possible ID, unable to add more items")
+	ErrStreamMaxLen      = errors.New("ERR The MAXLEN argument must be >= 0.")
+	ErrStreamTrimLimit   = errors.New("ERR syntax error, LIMIT cannot be used without the special ~ option")
+	ErrStreamKeyRequired = errors.New("ERR The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+	ErrBusyGroup         = errors.New("BUSYGROUP Consumer Group name already exists")
+	ErrNoGroup           = errors.New("NOGROUP No such key or consumer group")
+	ErrGeoPosition       = errors.New("ERR invalid longitude,latitude pair")
+	ErrGeoMemberNotFound = errors.New("ERR could not decode requested zset member")
+	ErrGeoUnit           = errors.New("ERR unsupported unit provided. please use M, KM, FT, MI")
+	ErrJSONInvalid       = errors.New("ERR invalid JSON")
+	ErrJSONPath          = errors.New("ERR invalid JSONPath")
+	ErrJSONPathNotFound  = errors.New("ERR Path does not exist")
+	ErrJSONNewAtRoot     = errors.New("ERR new objects must be created at the root")
+	ErrJSONNotNumber     = errors.New("ERR wrong type of path value - expected a number")
+	ErrJSONNotArray      = errors.New("ERR wrong type of path value - expected an array")
+	ErrJSONNotObject     = errors.New("ERR wrong type of path value - expected an object")
+	ErrItemExists        = errors.New("ERR item exists")
+	ErrFilterErrorRate   = errors.New("ERR (0 < error rate range < 1)")
+	ErrFilterCapacity    = errors.New("ERR (capacity should be larger than 0)")
+	ErrFilterTooLarge    = errors.New("ERR filter is too large")
+	ErrBloomExpansion    = errors.New("ERR expansion should be between 1 and 32768")
+	ErrBloomFull         = errors.New("ERR non scaling filter is full")
+	ErrCuckooBucketSize  = errors.New("ERR Bucket size must be between 1 and 255")
+	ErrCuckooIterations  = errors.New("ERR MAXITERATIONS must be between 1 and 65535")
+	ErrCuckooExpansion   = errors.New("ERR EXPANSION must be between 0 and 32768")
+	ErrCuckooFull        = errors.New("ERR Filter is full")
+	ErrCuckooNotFound    = errors.New("ERR not found")
+	ErrTSKeyExists       = errors.New("ERR TSDB: key already exists")
+	ErrTSKeyNotFound     = errors.New("ERR TSDB: the key does not exist")
+	ErrTSTimestamp       = errors.New("ERR TSDB: invalid timestamp")
+	ErrTSValue           = errors.New("ERR TSDB: invalid value")
+	ErrTSRetention       = errors.New("ERR TSDB: invalid retention")
+	ErrTSDuplicate       = errors.New("ERR TSDB: Error at upsert, update is not supported when DUPLICATE_POLICY is set to BLOCK mode")
+	ErrTSTooOld          = errors.New("ERR TSDB: Timestamp is older than retention")
+	ErrTSAggregation     = errors.New("ERR TSDB: Unknown aggregation type")
+	ErrTSBucketDuration  = errors.New("ERR TSDB: bucketDuration must be greater than zero")
+	ErrTSFilter          = errors.New("ERR TSDB: failed parsing labels")
+	ErrTSFilterMatcher   = errors.New("ERR TSDB: please provide at least one matcher")
+	ErrTSSameKey         = errors.New("ERR TSDB: the source key and destination key should be different")
+	ErrTSRuleSource      = errors.New("ERR TSDB: the source key already has a source rule")
+	ErrTSRuleDestination = errors.New("ERR TSDB: the destination key already has a src rule")
+	ErrTSRuleNotFound    = errors.New("ERR TSDB: compaction rule does not exist")
+	ErrRankIsZero        = errors.New("ERR RANK can't be zero: use 1 to start from the first match, 2 from the second ... or use negative to start from the end of the list")
 )
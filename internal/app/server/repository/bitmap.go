@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math/big"
+	"math/bits"
+)
+
+// maxBitOffset is the last bit of a string of maxStringLength bytes
+const maxBitOffset = maxStringLength*8 - 1
+
+// loadBitmap returns the bytes of the string stored at key, nil if the key doesn't exist.
+// The returned slice must not be modified unless it's returned by writableBitmap.
+func (r *InMemoryRedis) loadBitmap(key string) ([]byte, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	switch v := val.value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, domain.ErrWrongType
+	}
+}
+
+// writableBitmap returns the bitmap stored at key grown with zero bytes to at least length bytes,
+// the key is created if it doesn't exist and keeps its expiration otherwise
+func (r *InMemoryRedis) writableBitmap(key string, length int64) ([]byte, error) {
+	val, _ := r.load(key)
+	bitmap, err := r.loadBitmap(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(bitmap)) < length {
+		bitmap = append(bitmap, make([]byte, length-int64(len(bitmap)))...)
+	}
+
+	r.store.Store(key, storeValue{
+		value:  bitmap,
+		expiry: val.expiry,
+	})
+	return bitmap, nil
+}
+
+// bitAt returns the bit at the offset, bit 0 is the most significant bit of the first byte
+func bitAt(bitmap []byte, offset int64) int {
+	i := offset / 8
+	if i >= int64(len(bitmap)) {
+		return 0
+	}
+	return int(bitmap[i]>>(7-uint(offset%8))) & 1
+}
+
+func setBitAt(bitmap []byte, offset int64, value int) {
+	mask := byte(1) << (7 - uint(offset%8))
+	if value == 1 {
+		bitmap[offset/8] |= mask
+	} else {
+		bitmap[offset/8] &^= mask
+	}
+}
+
+// SetBit sets the bit at the offset and returns the previous bit, the string grows as needed
+func (r *InMemoryRedis) SetBit(key string, offset int64, value int) (int, error) {
+	if offset < 0 || offset > maxBitOffset {
+		return 0, domain.ErrBitOffset
+	}
+	if value != 0 && value != 1 {
+		return 0, domain.ErrBitValue
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bitmap, err := r.writableBitmap(key, offset/8+1)
+	if err != nil {
+		return 0, err
+	}
+
+	previous := bitAt(bitmap, offset)
+	setBitAt(bitmap, offset, value)
+	return previous, nil
+}
+
+// GetBit returns the bit at the offset, bits past the end of the string are 0
+func (r *InMemoryRedis) GetBit(key string, offset int64) (int, error) {
+	if offset < 0 || offset > maxBitOffset {
+		return 0, domain.ErrBitOffset
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bitmap, err := r.loadBitmap(key)
+	if err != nil {
+		return 0, err
+	}
+	return bitAt(bitmap, offset), nil
+}
+
+// bitRange converts the range to the first and the last bit of the bitmap,
+// the returned flag is false if the range is empty
+func bitRange(bitmap []byte, rng *usecase.BitRange) (int64, int64, bool) {
+	length := int64(len(bitmap))
+	if rng == nil {
+		return 0, length*8 - 1, length > 0
+	}
+
+	if rng.Bit {
+		length *= 8
+	}
+
+	start, end := rng.Start, length-1
+	if rng.End != nil {
+		end = *rng.End
+	}
+
+	if start < 0 {
+		start += length
+	}
+	if end < 0 {
+		end += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = 0
+	}
+	if end >= length {
+		end = length - 1
+	}
+	if start > end {
+		return 0, 0, false
+	}
+
+	if !rng.Bit {
+		return start * 8, end*8 + 7, true
+	}
+	return start, end, true
+}
+
+// BitCount counts the set bits in the range, the whole string if the range is nil
+func (r *InMemoryRedis) BitCount(key string, rng *usecase.BitRange) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bitmap, err := r.loadBitmap(key)
+	if err != nil {
+		return 0, err
+	}
+
+	first, last, ok := bitRange(bitmap, rng)
+	if !ok {
+		return 0, nil
+	}
+
+	var count int64
+	offset := first
+	for ; offset <= last && offset%8 != 0; offset++ {
+		count += int64(bitAt(bitmap, offset))
+	}
+	for ; offset+7 <= last; offset += 8 {
+		count += int64(bits.OnesCount8(bitmap[offset/8]))
+	}
+	for ; offset <= last; offset++ {
+		count += int64(bitAt(bitmap, offset))
+	}
+	return count, nil
+}
+
+// BitPos returns the position of the first bit equal to the bit in the range, -1 if there is none.
+// Looking for 0 in a range without the end finds the first bit past the string, as it's padded with zeros.
+func (r *InMemoryRedis) BitPos(key string, bit int, rng *usecase.BitRange) (int64, error) {
+	if bit != 0 && bit != 1 {
+		return 0, domain.ErrBitValue
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bitmap, err := r.loadBitmap(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if bitmap == nil {
+		if bit == 1 {
+			return -1, nil
+		}
+		return 0, nil
+	}
+
+	first, last, ok := bitRange(bitmap, rng)
+	if !ok {
+		return -1, nil
+	}
+
+	// skip the whole bytes which can't contain the bit
+	skip := byte(0)
+	if bit == 0 {
+		skip = 0xff
+	}
+	for offset := first; offset <= last; offset++ {
+		if offset%8 == 0 && offset+7 <= last && bitmap[offset/8] == skip {
+			offset += 7
+			continue
+		}
+		if bitAt(bitmap, offset) == bit {
+			return offset, nil
+		}
+	}
+
+	if bit == 0 && (rng == nil || rng.End == nil) {
+		return last + 1, nil
+	}
+	return -1, nil
+}
+
+// BitOp stores the result of the operation on the strings at keys at the destination and returns its length,
+// the shorter strings are padded with zero bytes and an empty result deletes the destination
+func (r *InMemoryRedis) BitOp(operation usecase.BitOperation, destination string, keys []string) (int, error) {
+	if operation == usecase.BitNot && len(keys) != 1 {
+		return 0, domain.ErrBitOpNot
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := make([][]byte, 0, len(keys))
+	length := 0
+	for _, key := range keys {
+		bitmap, err := r.loadBitmap(key)
+		if err != nil {
+			return 0, err
+		}
+		sources = append(sources, bitmap)
+		if len(bitmap) > length {
+			length = len(bitmap)
+		}
+	}
+
+	if length == 0 {
+		r.del(destination)
+		return 0, nil
+	}
+
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = byteAt(sources[0], i)
+		if operation == usecase.BitNot {
+			result[i] = ^result[i]
+		}
+
+		for _, source := range sources[1:] {
+			switch operation {
+			case usecase.BitAnd:
+				result[i] &= byteAt(source, i)
+			case usecase.BitOr:
+				result[i] |= byteAt(source, i)
+			case usecase.BitXor:
+				result[i] ^= byteAt(source, i)
+			}
+		}
+	}
+
+	r.store.Store(destination, storeValue{
+		value: result,
+	})
+	return length, nil
+}
+
+func byteAt(bitmap []byte, i int) byte {
+	if i < len(bitmap) {
+		return bitmap[i]
+	}
+	return 0
+}
+
+// BitField executes the operations in order and returns a value per operation:
+// the field for GET, the previous value for SET and the new value for INCRBY,
+// nil for SET and INCRBY which overflow with OverflowFail
+func (r *InMemoryRedis) BitField(key string, ops []usecase.BitFieldOp) ([]*int64, error) {
+	var length int64
+	for _, op := range ops {
+		if op.Type.Width == 0 || op.Type.Width > 64 || (!op.Type.Signed && op.Type.Width > 63) {
+			return nil, domain.ErrBitFieldType
+		}
+		if op.Offset < 0 || op.Offset > maxBitOffset-int64(op.Type.Width)+1 {
+			return nil, domain.ErrBitOffset
+		}
+		if end := (op.Offset + int64(op.Type.Width) + 7) / 8; op.Kind != usecase.BitFieldGet && end > length {
+			length = end
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// only writes create the key or grow the string
+	var bitmap []byte
+	var err error
+	if length > 0 {
+		bitmap, err = r.writableBitmap(key, length)
+	} else {
+		bitmap, err = r.loadBitmap(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*int64, len(ops))
+	for i, op := range ops {
+		current := getField(bitmap, op)
+		if op.Kind == usecase.BitFieldGet {
+			result[i] = &current
+			continue
+		}
+
+		value := big.NewInt(op.Value)
+		if op.Kind == usecase.BitFieldIncrBy {
+			value.Add(value, big.NewInt(current))
+		}
+
+		fitted, ok := fitField(value, op.Type, op.Overflow)
+		if !ok {
+			continue
+		}
+		setField(bitmap, op, fitted)
+
+		if op.Kind == usecase.BitFieldSet {
+			result[i] = &current
+		} else {
+			result[i] = &fitted
+		}
+	}
+	return result, nil
+}
+
+func getField(bitmap []byte, op usecase.BitFieldOp) int64 {
+	var value uint64
+	for i := int64(0); i < int64(op.Type.Width); i++ {
+		value = value<<1 | uint64(bitAt(bitmap, op.Offset+i))
+	}
+
+	// sign-extend the negative values
+	if op.Type.Signed && op.Type.Width < 64 && value&(1<<(op.Type.Width-1)) != 0 {
+		value |= ^uint64(0) << op.Type.Width
+	}
+	return int64(value)
+}
+
+func setField(bitmap []byte, op usecase.BitFieldOp, value int64) {
+	for i := int64(0); i < int64(op.Type.Width); i++ {
+		bit := int(uint64(value)>>(uint64(op.Type.Width)-1-uint64(i))) & 1
+		setBitAt(bitmap, op.Offset+i, bit)
+	}
+}
+
+// fitField converts the value to the range of the field type with the overflow mode,
+// the returned flag is false if it overflows with OverflowFail
+func fitField(value *big.Int, fieldType usecase.BitFieldType, overflow usecase.BitOverflow) (int64, bool) {
+	size := new(big.Int).Lsh(big.NewInt(1), fieldType.Width)
+	min, max := big.NewInt(0), new(big.Int).Sub(size, big.NewInt(1))
+	if fieldType.Signed {
+		half := new(big.Int).Rsh(size, 1)
+		min = new(big.Int).Neg(half)
+		max = new(big.Int).Sub(half, big.NewInt(1))
+	}
+
+	if value.Cmp(min) >= 0 && value.Cmp(max) <= 0 {
+		return value.Int64(), true
+	}
+
+	switch overflow {
+	case usecase.OverflowSat:
+		if value.Cmp(min) < 0 {
+			return min.Int64(), true
+		}
+		return max.Int64(), true
+	case usecase.OverflowFail:
+		return 0, false
+	default:
+		wrapped := new(big.Int).Mod(value, size)
+		if fieldType.Signed && wrapped.Cmp(max) > 0 {
+			wrapped.Sub(wrapped, size)
+		}
+		return wrapped.Int64(), true
+	}
+}
@@ -0,0 +1,447 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func TestInMemoryRedis_SetBit(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		offset    int64
+		value     int
+		want      int
+		wantValue string
+		wantErr   error
+	}{
+		{
+			name:      "grows a missing key",
+			values:    map[string]interface{}{},
+			offset:    17,
+			value:     1,
+			wantValue: "\x00\x00\x40",
+		},
+		{
+			name:      "returns the previous bit",
+			values:    map[string]interface{}{"bitmap": "a"},
+			offset:    1,
+			value:     0,
+			want:      1,
+			wantValue: "!",
+		},
+		{
+			name:      "grows an existing string",
+			values:    map[string]interface{}{"bitmap": "a"},
+			offset:    15,
+			value:     1,
+			wantValue: "a\x01",
+		},
+		{
+			name:    "negative offset",
+			values:  map[string]interface{}{},
+			offset:  -1,
+			value:   1,
+			wantErr: domain.ErrBitOffset,
+		},
+		{
+			name:    "offset past the maximum length",
+			values:  map[string]interface{}{},
+			offset:  maxBitOffset + 1,
+			value:   1,
+			wantErr: domain.ErrBitOffset,
+		},
+		{
+			name:    "not a bit",
+			values:  map[string]interface{}{},
+			value:   2,
+			wantErr: domain.ErrBitValue,
+		},
+		{
+			name:    "wrong type",
+			values:  map[string]interface{}{"bitmap": newDeque("a")},
+			value:   1,
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.SetBit("bitmap", tt.offset, tt.value)
+			if err != tt.wantErr {
+				t.Fatalf("SetBit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SetBit() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.Get("bitmap"); value != tt.wantValue {
+				t.Errorf("Get() = %q, want %q", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SetBitKeepsExpiration(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	r := newTestRedis(map[string]interface{}{})
+	r.store.Store("bitmap", storeValue{value: "a", expiry: expiry})
+
+	if _, err := r.SetBit("bitmap", 7, 0); err != nil {
+		t.Fatalf("SetBit() error = %v", err)
+	}
+	if val, _ := r.load("bitmap"); !val.expiry.Equal(expiry) {
+		t.Errorf("expiry = %v, want %v", val.expiry, expiry)
+	}
+
+	// the bitmap is still a string for the string commands
+	if _, err := r.Append("bitmap", "b"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if value, _, _ := r.Get("bitmap"); value != "`b" {
+		t.Errorf("Get() = %q, want %q", value, "`b")
+	}
+}
+
+func TestInMemoryRedis_GetBit(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"bitmap": "a"})
+	for offset, want := range []int{0, 1, 1, 0, 0, 0, 0, 1, 0} {
+		got, err := r.GetBit("bitmap", int64(offset))
+		if err != nil {
+			t.Fatalf("GetBit(%d) error = %v", offset, err)
+		}
+		if got != want {
+			t.Errorf("GetBit(%d) = %v, want %v", offset, got, want)
+		}
+	}
+}
+
+func TestInMemoryRedis_BitCount(t *testing.T) {
+	tests := []struct {
+		name string
+		rng  *usecase.BitRange
+		want int64
+	}{
+		{
+			name: "whole string",
+			want: 26,
+		},
+		{
+			name: "bytes",
+			rng:  &usecase.BitRange{Start: 1, End: int64Ptr(1)},
+			want: 6,
+		},
+		{
+			name: "negative bytes",
+			rng:  &usecase.BitRange{Start: -2, End: int64Ptr(-1)},
+			want: 7,
+		},
+		{
+			name: "bits",
+			rng:  &usecase.BitRange{Start: 5, End: int64Ptr(30), Bit: true},
+			want: 17,
+		},
+		{
+			name: "start after end",
+			rng:  &usecase.BitRange{Start: 2, End: int64Ptr(0)},
+		},
+		{
+			name: "end past the string",
+			rng:  &usecase.BitRange{Start: 0, End: int64Ptr(100)},
+			want: 26,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"bitmap": "foobar"})
+			got, err := r.BitCount("bitmap", tt.rng)
+			if err != nil {
+				t.Fatalf("BitCount() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BitCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_BitPos(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		bit    int
+		rng    *usecase.BitRange
+		want   int64
+	}{
+		{
+			name:   "first set bit",
+			values: map[string]interface{}{"bitmap": "\xff\xf0\x00"},
+			bit:    0,
+			want:   12,
+		},
+		{
+			name:   "first set bit after the start",
+			values: map[string]interface{}{"bitmap": "\x00\xff\xf0"},
+			bit:    1,
+			rng:    &usecase.BitRange{Start: 2},
+			want:   16,
+		},
+		{
+			name:   "bits range",
+			values: map[string]interface{}{"bitmap": "\x00\xff\xf0"},
+			bit:    1,
+			rng:    &usecase.BitRange{Start: 7, End: int64Ptr(15), Bit: true},
+			want:   8,
+		},
+		{
+			name:   "no clear bit without an end is past the string",
+			values: map[string]interface{}{"bitmap": "\xff\xff"},
+			bit:    0,
+			want:   16,
+		},
+		{
+			name:   "no clear bit with an end",
+			values: map[string]interface{}{"bitmap": "\xff\xff"},
+			bit:    0,
+			rng:    &usecase.BitRange{Start: 0, End: int64Ptr(-1)},
+			want:   -1,
+		},
+		{
+			name:   "no set bit",
+			values: map[string]interface{}{"bitmap": "\x00\x00"},
+			bit:    1,
+			want:   -1,
+		},
+		{
+			name:   "missing key has no set bit",
+			values: map[string]interface{}{},
+			bit:    1,
+			want:   -1,
+		},
+		{
+			name:   "missing key starts with a clear bit",
+			values: map[string]interface{}{},
+			bit:    0,
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.BitPos("bitmap", tt.bit, tt.rng)
+			if err != nil {
+				t.Fatalf("BitPos() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BitPos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_BitOp(t *testing.T) {
+	tests := []struct {
+		name       string
+		operation  usecase.BitOperation
+		keys       []string
+		want       int
+		wantValue  string
+		wantExists bool
+		wantErr    error
+	}{
+		{
+			name:       "and pads with zeros",
+			operation:  usecase.BitAnd,
+			keys:       []string{"a", "b"},
+			want:       2,
+			wantValue:  "\x0c\x00",
+			wantExists: true,
+		},
+		{
+			name:       "or",
+			operation:  usecase.BitOr,
+			keys:       []string{"a", "b", "missing"},
+			want:       2,
+			wantValue:  "\x3f\x01",
+			wantExists: true,
+		},
+		{
+			name:       "xor",
+			operation:  usecase.BitXor,
+			keys:       []string{"a", "b"},
+			want:       2,
+			wantValue:  "\x33\x01",
+			wantExists: true,
+		},
+		{
+			name:       "not",
+			operation:  usecase.BitNot,
+			keys:       []string{"b"},
+			want:       2,
+			wantValue:  "\xf0\xfe",
+			wantExists: true,
+		},
+		{
+			name:      "empty result deletes the destination",
+			operation: usecase.BitOr,
+			keys:      []string{"missing"},
+		},
+		{
+			name:      "not of several keys",
+			operation: usecase.BitNot,
+			keys:      []string{"a", "b"},
+			wantErr:   domain.ErrBitOpNot,
+			// the destination isn't touched
+			wantValue:  "old",
+			wantExists: true,
+		},
+		{
+			name:       "wrong type",
+			operation:  usecase.BitAnd,
+			keys:       []string{"a", "list"},
+			wantErr:    domain.ErrWrongType,
+			wantValue:  "old",
+			wantExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{
+				"a":           "\x3c",
+				"b":           "\x0f\x01",
+				"list":        newDeque("a"),
+				"destination": "old",
+			})
+			got, err := r.BitOp(tt.operation, "destination", tt.keys)
+			if err != tt.wantErr {
+				t.Fatalf("BitOp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BitOp() = %v, want %v", got, tt.want)
+			}
+			value, exists, _ := r.Get("destination")
+			if value != tt.wantValue || exists != tt.wantExists {
+				t.Errorf("Get() = %q, %v, want %q, %v", value, exists, tt.wantValue, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_BitField(t *testing.T) {
+	i8 := usecase.BitFieldType{Signed: true, Width: 8}
+	u4 := usecase.BitFieldType{Width: 4}
+
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		ops       []usecase.BitFieldOp
+		want      []*int64
+		wantValue string
+		wantErr   error
+	}{
+		{
+			name:   "set returns the previous value and get sign-extends",
+			values: map[string]interface{}{},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldSet, Type: i8, Offset: 8, Value: -2},
+				{Kind: usecase.BitFieldSet, Type: i8, Offset: 8, Value: 100},
+				{Kind: usecase.BitFieldGet, Type: u4, Offset: 8},
+				{Kind: usecase.BitFieldGet, Type: i8, Offset: 8},
+			},
+			want:      []*int64{int64Ptr(0), int64Ptr(-2), int64Ptr(6), int64Ptr(100)},
+			wantValue: "\x00\x64",
+		},
+		{
+			name:   "incrby wraps by default",
+			values: map[string]interface{}{"bitmap": "\x7f"},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldIncrBy, Type: i8, Value: 1},
+				{Kind: usecase.BitFieldIncrBy, Type: u4, Offset: 4, Value: -2, Overflow: usecase.OverflowWrap},
+			},
+			want:      []*int64{int64Ptr(-128), int64Ptr(14)},
+			wantValue: "\x8e",
+		},
+		{
+			name:   "incrby saturates",
+			values: map[string]interface{}{"bitmap": "\x7f"},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldIncrBy, Type: i8, Value: 100, Overflow: usecase.OverflowSat},
+				{Kind: usecase.BitFieldIncrBy, Type: i8, Value: -1000, Overflow: usecase.OverflowSat},
+			},
+			want:      []*int64{int64Ptr(127), int64Ptr(-128)},
+			wantValue: "\x80",
+		},
+		{
+			name:   "incrby fails without writing",
+			values: map[string]interface{}{"bitmap": "\xf0"},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldIncrBy, Type: u4, Value: 16, Overflow: usecase.OverflowFail},
+				{Kind: usecase.BitFieldSet, Type: u4, Value: 16, Overflow: usecase.OverflowFail},
+				{Kind: usecase.BitFieldIncrBy, Type: u4, Value: -1, Overflow: usecase.OverflowFail},
+			},
+			want:      []*int64{nil, nil, int64Ptr(14)},
+			wantValue: "\xe0",
+		},
+		{
+			name:   "64 bits signed field",
+			values: map[string]interface{}{},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldSet, Type: usecase.BitFieldType{Signed: true, Width: 64}, Offset: 4, Value: -1},
+				{Kind: usecase.BitFieldGet, Type: usecase.BitFieldType{Width: 63}, Offset: 4},
+			},
+			want:      []*int64{int64Ptr(0), int64Ptr(1<<63 - 1)},
+			wantValue: "\x0f\xff\xff\xff\xff\xff\xff\xff\xf0",
+		},
+		{
+			name:   "get doesn't create the key",
+			values: map[string]interface{}{},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldGet, Type: i8, Offset: 100},
+			},
+			want: []*int64{int64Ptr(0)},
+		},
+		{
+			name:   "unsigned 64 bits field",
+			values: map[string]interface{}{},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldGet, Type: usecase.BitFieldType{Width: 64}},
+			},
+			wantErr: domain.ErrBitFieldType,
+		},
+		{
+			name:   "wrong type",
+			values: map[string]interface{}{"bitmap": newDeque("a")},
+			ops: []usecase.BitFieldOp{
+				{Kind: usecase.BitFieldGet, Type: i8},
+			},
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.BitField("bitmap", tt.ops)
+			if err != tt.wantErr {
+				t.Fatalf("BitField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BitField() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.Get("bitmap"); value != tt.wantValue {
+				t.Errorf("Get() = %q, want %q", value, tt.wantValue)
+			}
+		})
+	}
+}
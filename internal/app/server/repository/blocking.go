@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"time"
+)
+
+// listWaiter is a client blocked until one of the lists at keys gets an element
+type listWaiter struct {
+	keys []string
+	head bool
+	// move is set for BLMOVE, the popped element is pushed to the to side of destination
+	move        bool
+	destination string
+	to          usecase.ListSide
+	// result is buffered, so serving a waiter never blocks the command which pushed the element
+	result chan poppedElement
+}
+
+type poppedElement struct {
+	key   string
+	value string
+	err   error
+}
+
+// BLPop pops the head of the first non-empty list at keys, waiting up to timeout
+// (forever if it's 0) or until ctx is done for an element to be pushed.
+// The returned flag is false if no element arrived.
+func (r *InMemoryRedis) BLPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error) {
+	element, ok := r.blockingPop(ctx, &listWaiter{keys: keys, head: true}, timeout)
+	return element.key, element.value, ok, element.err
+}
+
+// BRPop is BLPop which pops the tail of the list
+func (r *InMemoryRedis) BRPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error) {
+	element, ok := r.blockingPop(ctx, &listWaiter{keys: keys, head: false}, timeout)
+	return element.key, element.value, ok, element.err
+}
+
+// BLMove is LMove which waits for an element like BLPop if the source is empty
+func (r *InMemoryRedis) BLMove(ctx context.Context, source string, destination string, from usecase.ListSide, to usecase.ListSide, timeout time.Duration) (string, bool, error) {
+	waiter := &listWaiter{
+		keys:        []string{source},
+		head:        from == usecase.ListLeft,
+		move:        true,
+		destination: destination,
+		to:          to,
+	}
+	element, ok := r.blockingPop(ctx, waiter, timeout)
+	return element.value, ok, element.err
+}
+
+func (r *InMemoryRedis) blockingPop(ctx context.Context, waiter *listWaiter, timeout time.Duration) (poppedElement, bool) {
+	r.mu.Lock()
+	for _, key := range waiter.keys {
+		list, err := r.loadList(key)
+		if err != nil {
+			r.mu.Unlock()
+			return poppedElement{err: err}, false
+		}
+		if list != nil {
+			element := r.popFor(waiter, key)
+			r.mu.Unlock()
+			return element, element.err == nil
+		}
+	}
+
+	waiter.result = make(chan poppedElement, 1)
+	r.addWaiter(waiter)
+	r.mu.Unlock()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case element := <-waiter.result:
+		return element, element.err == nil
+	case <-expired:
+	case <-ctx.Done():
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the waiter could be served after the timeout but before the lock was taken
+	select {
+	case element := <-waiter.result:
+		return element, element.err == nil
+	default:
+	}
+
+	r.removeWaiter(waiter)
+	return poppedElement{}, false
+}
+
+// popFor pops an element from the non-empty list at key on behalf of the waiter
+func (r *InMemoryRedis) popFor(waiter *listWaiter, key string) poppedElement {
+	if waiter.move {
+		// check the destination type before the source is modified
+		if _, err := r.loadList(waiter.destination); err != nil {
+			return poppedElement{err: err}
+		}
+	}
+
+	values, _ := r.pop(key, 1, waiter.head)
+	if waiter.move {
+		// pushing to the destination may serve the clients blocked on it in turn
+		_, _ = r.push(waiter.destination, values, waiter.to == usecase.ListLeft, false)
+	}
+	return poppedElement{key: key, value: values[0]}
+}
+
+// serveWaiters hands the elements of the list at key to the clients blocked on it in FIFO order
+func (r *InMemoryRedis) serveWaiters(key string) {
+	for len(r.waiters[key]) > 0 {
+		list, err := r.loadList(key)
+		if err != nil || list == nil {
+			return
+		}
+
+		waiter := r.waiters[key][0]
+		r.removeWaiter(waiter)
+		waiter.result <- r.popFor(waiter, key)
+	}
+}
+
+func (r *InMemoryRedis) addWaiter(waiter *listWaiter) {
+	if r.waiters == nil {
+		r.waiters = make(map[string][]*listWaiter)
+	}
+	for _, key := range waiter.keys {
+		r.waiters[key] = append(r.waiters[key], waiter)
+	}
+}
+
+func (r *InMemoryRedis) removeWaiter(waiter *listWaiter) {
+	for _, key := range waiter.keys {
+		waiters := r.waiters[key]
+		for i, w := range waiters {
+			if w == waiter {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+
+		if len(waiters) == 0 {
+			delete(r.waiters, key)
+		} else {
+			r.waiters[key] = waiters
+		}
+	}
+}
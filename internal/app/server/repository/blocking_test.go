@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// waitForWaiters waits until count clients are blocked on the key
+func waitForWaiters(t *testing.T, r *InMemoryRedis, key string, count int) {
+	for i := 0; i < 100; i++ {
+		r.mu.Lock()
+		blocked := len(r.waiters[key])
+		r.mu.Unlock()
+		if blocked == count {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("%d clients are not blocked on %q", count, key)
+}
+
+func TestInMemoryRedis_BLPop(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		keys      []string
+		head      bool
+		wantKey   string
+		wantValue string
+		wantOk    bool
+		wantErr   error
+	}{
+		{
+			name:      "pops the head of the first non-empty list",
+			values:    map[string]interface{}{"second": newDeque("a", "b")},
+			keys:      []string{"first", "second"},
+			head:      true,
+			wantKey:   "second",
+			wantValue: "a",
+			wantOk:    true,
+		},
+		{
+			name:      "BRPop pops the tail",
+			values:    map[string]interface{}{"list": newDeque("a", "b")},
+			keys:      []string{"list"},
+			wantKey:   "list",
+			wantValue: "b",
+			wantOk:    true,
+		},
+		{
+			name:   "times out on empty lists",
+			values: map[string]interface{}{},
+			keys:   []string{"list"},
+			head:   true,
+		},
+		{
+			name:    "fails on a wrong type",
+			values:  map[string]interface{}{"list": "value"},
+			keys:    []string{"list"},
+			head:    true,
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			pop := r.BRPop
+			if tt.head {
+				pop = r.BLPop
+			}
+
+			key, value, ok, err := pop(context.Background(), tt.keys, 10*time.Millisecond)
+			if err != tt.wantErr {
+				t.Fatalf("pop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if key != tt.wantKey || value != tt.wantValue || ok != tt.wantOk {
+				t.Errorf("pop() = %q, %q, %v, want %q, %q, %v", key, value, ok, tt.wantKey, tt.wantValue, tt.wantOk)
+			}
+			if len(r.waiters) != 0 {
+				t.Errorf("waiters = %v, want none", r.waiters)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_BLPopWakesInFIFOOrder(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{})
+
+	results := make([]chan string, 3)
+	for i := range results {
+		results[i] = make(chan string, 1)
+		go func(result chan string) {
+			_, value, _, _ := r.BLPop(context.Background(), []string{"list"}, 0)
+			result <- value
+		}(results[i])
+		waitForWaiters(t, r, "list", i+1)
+	}
+
+	if _, err := r.RPush("list", []string{"a", "b"}); err != nil {
+		t.Fatalf("RPush() error = %v", err)
+	}
+	if _, err := r.LPush("list", []string{"c"}); err != nil {
+		t.Fatalf("LPush() error = %v", err)
+	}
+
+	got := make([]string, 0, len(results))
+	for _, result := range results {
+		select {
+		case value := <-result:
+			got = append(got, value)
+		case <-time.After(time.Second):
+			t.Fatal("the blocked client wasn't woken")
+		}
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("popped = %v, want %v", got, want)
+	}
+	if values := listValues(t, r, "list"); len(values) != 0 {
+		t.Errorf("list = %v, want empty", values)
+	}
+}
+
+func TestInMemoryRedis_BLPopCanceled(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan bool, 1)
+	go func() {
+		_, _, ok, _ := r.BLPop(ctx, []string{"list"}, 0)
+		done <- ok
+	}()
+	waitForWaiters(t, r, "list", 1)
+	cancel()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("BLPop() ok = true after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("BLPop() wasn't canceled")
+	}
+
+	// the canceled client must not consume the pushed element
+	if _, err := r.LPush("list", []string{"a"}); err != nil {
+		t.Fatalf("LPush() error = %v", err)
+	}
+	if got := listValues(t, r, "list"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("list = %v, want [a]", got)
+	}
+}
+
+func TestInMemoryRedis_BLMove(t *testing.T) {
+	t.Run("moves the pushed element", func(t *testing.T) {
+		r := newTestRedis(map[string]interface{}{"destination": newDeque("x")})
+
+		done := make(chan string, 1)
+		go func() {
+			value, _, _ := r.BLMove(context.Background(), "source", "destination", usecase.ListLeft, usecase.ListRight, time.Second)
+			done <- value
+		}()
+		waitForWaiters(t, r, "source", 1)
+
+		if _, err := r.RPush("source", []string{"a", "b"}); err != nil {
+			t.Fatalf("RPush() error = %v", err)
+		}
+		if value := <-done; value != "a" {
+			t.Errorf("BLMove() = %q, want a", value)
+		}
+		if got := listValues(t, r, "source"); !reflect.DeepEqual(got, []string{"b"}) {
+			t.Errorf("source = %v, want [b]", got)
+		}
+		if got := listValues(t, r, "destination"); !reflect.DeepEqual(got, []string{"x", "a"}) {
+			t.Errorf("destination = %v, want [x a]", got)
+		}
+	})
+
+	t.Run("keeps the source on a wrong destination type", func(t *testing.T) {
+		r := newTestRedis(map[string]interface{}{"source": newDeque("a"), "destination": "value"})
+
+		_, ok, err := r.BLMove(context.Background(), "source", "destination", usecase.ListLeft, usecase.ListLeft, 0)
+		if err != domain.ErrWrongType || ok {
+			t.Fatalf("BLMove() = %v, %v, want false, %v", ok, err, domain.ErrWrongType)
+		}
+		if got := listValues(t, r, "source"); !reflect.DeepEqual(got, []string{"a"}) {
+			t.Errorf("source = %v, want [a]", got)
+		}
+	})
+}
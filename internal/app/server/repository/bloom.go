@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+)
+
+const (
+	bloomSeed = 0xc6a4a7935bd1e995
+	// bloomTighteningRatio is the ratio of the error rates of the next and the current sub-filters,
+	// the first sub-filter gets half of the error rate so the sum over all sub-filters stays below it
+	bloomTighteningRatio = 0.5
+	// bloomMaxExpansion is the limit of RedisBloom
+	bloomMaxExpansion = 32768
+	// filterMaxCapacity and filterMaxSize limit the Bloom and cuckoo filters,
+	// a sub-filter takes at most as many bytes as a string value
+	filterMaxCapacity = 1 << 30
+	filterMaxSize     = maxStringLength
+)
+
+// bloomFilter is a sub-filter of bits, an item sets hashes bits chosen by double hashing.
+// It's sized for capacity items with the given error rate.
+type bloomFilter struct {
+	bits     []uint64
+	size     uint64
+	hashes   int
+	capacity int
+	count    int
+}
+
+func newBloomFilter(capacity int, errorRate float64) *bloomFilter {
+	size := uint64(bloomSize(capacity, errorRate))
+	return &bloomFilter{
+		bits:     make([]uint64, size/64),
+		size:     size,
+		hashes:   int(math.Ceil(math.Ln2 * bloomBitsPerItem(errorRate))),
+		capacity: capacity,
+	}
+}
+
+func bloomBitsPerItem(errorRate float64) float64 {
+	return -math.Log(errorRate) / (math.Ln2 * math.Ln2)
+}
+
+// bloomSize is the number of the bits of a sub-filter rounded up to the whole words,
+// it's a float so a size too large for the memory doesn't overflow
+func bloomSize(capacity int, errorRate float64) float64 {
+	return math.Ceil(float64(capacity)*bloomBitsPerItem(errorRate)/64) * 64
+}
+
+// bloomHash is the pair of the hashes of the item, the bits of the item are h1 + i*h2
+type bloomHash struct {
+	h1, h2 uint64
+}
+
+func bloomHashOf(item string) bloomHash {
+	h1 := murmurHash64A([]byte(item), bloomSeed)
+	return bloomHash{h1: h1, h2: murmurHash64A([]byte(item), h1)}
+}
+
+func (f *bloomFilter) bit(hash bloomHash, i int) uint64 {
+	return (hash.h1 + uint64(i)*hash.h2) % f.size
+}
+
+func (f *bloomFilter) contains(hash bloomHash) bool {
+	for i := 0; i < f.hashes; i++ {
+		bit := f.bit(hash, i)
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bloomFilter) add(hash bloomHash) {
+	for i := 0; i < f.hashes; i++ {
+		bit := f.bit(hash, i)
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+	f.count++
+}
+
+// scalableBloom is a chain of sub-filters, the items are added to the last one and looked for in all of them.
+// Every sub-filter is expansion times larger and has a tighter error rate than the previous one,
+// so the false positive rate of the chain stays below errorRate however many items are added.
+type scalableBloom struct {
+	filters   []*bloomFilter
+	errorRate float64
+	expansion int
+	scaling   bool
+}
+
+func newScalableBloom(options usecase.BloomOptions) *scalableBloom {
+	b := &scalableBloom{
+		errorRate: options.ErrorRate,
+		expansion: options.Expansion,
+		scaling:   !options.NonScaling,
+	}
+	b.filters = []*bloomFilter{newBloomFilter(options.Capacity, options.ErrorRate*bloomTighteningRatio)}
+	return b
+}
+
+func validateBloomOptions(options usecase.BloomOptions) error {
+	if !(options.ErrorRate > 0 && options.ErrorRate < 1) {
+		return domain.ErrFilterErrorRate
+	}
+	if options.Capacity <= 0 {
+		return domain.ErrFilterCapacity
+	}
+	if options.Capacity > filterMaxCapacity || bloomSize(options.Capacity, options.ErrorRate*bloomTighteningRatio) > filterMaxSize*8 {
+		return domain.ErrFilterTooLarge
+	}
+	if !options.NonScaling && (options.Expansion < 1 || options.Expansion > bloomMaxExpansion) {
+		return domain.ErrBloomExpansion
+	}
+	return nil
+}
+
+func (b *scalableBloom) contains(hash bloomHash) bool {
+	for _, f := range b.filters {
+		if f.contains(hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds the item and reports whether it's new, an item which may have been added isn't added again
+func (b *scalableBloom) add(item string) (bool, error) {
+	hash := bloomHashOf(item)
+	if b.contains(hash) {
+		return false, nil
+	}
+
+	last := b.filters[len(b.filters)-1]
+	if last.count >= last.capacity {
+		if !b.scaling {
+			return false, domain.ErrBloomFull
+		}
+		errorRate := b.errorRate * math.Pow(bloomTighteningRatio, float64(len(b.filters)+1))
+		if last.capacity > filterMaxCapacity/b.expansion || bloomSize(last.capacity*b.expansion, errorRate) > filterMaxSize*8 {
+			return false, domain.ErrFilterTooLarge
+		}
+		last = newBloomFilter(last.capacity*b.expansion, errorRate)
+		b.filters = append(b.filters, last)
+	}
+
+	last.add(hash)
+	return true, nil
+}
+
+// loadBloom returns the Bloom filter stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadBloom(key string) (*scalableBloom, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	b, ok := val.value.(*scalableBloom)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return b, nil
+}
+
+// BFReserve creates an empty Bloom filter, the key must not exist
+func (r *InMemoryRedis) BFReserve(key string, options usecase.BloomOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateBloomOptions(options); err != nil {
+		return err
+	}
+	if _, exists := r.load(key); exists {
+		return domain.ErrItemExists
+	}
+
+	r.store.Store(key, storeValue{
+		value: newScalableBloom(options),
+	})
+	return nil
+}
+
+// BFAdd adds the items and reports for every item whether it's new. The filter is created
+// with usecase.DefaultBloomOptions if the key doesn't exist. When a non-scaling filter is full
+// an error is returned, the items before it stay added.
+func (r *InMemoryRedis) BFAdd(key string, items []string) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := r.loadBloom(key)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		b = newScalableBloom(usecase.DefaultBloomOptions)
+		r.store.Store(key, storeValue{
+			value: b,
+		})
+	}
+
+	added := make([]bool, 0, len(items))
+	for _, item := range items {
+		ok, err := b.add(item)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, ok)
+	}
+	return added, nil
+}
+
+// BFExists reports for every item whether it may have been added, false means it hasn't been for sure
+func (r *InMemoryRedis) BFExists(key string, items []string) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := r.loadBloom(key)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(items))
+	if b == nil {
+		return exists, nil
+	}
+	for i, item := range items {
+		exists[i] = b.contains(bloomHashOf(item))
+	}
+	return exists, nil
+}
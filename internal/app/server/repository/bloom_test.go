@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// falsePositiveRate checks items which haven't been added, they're distinct from the added "id:N" ones
+func falsePositiveRate(contains func(item string) bool) float64 {
+	const checks = 100000
+	positives := 0
+	for i := 0; i < checks; i++ {
+		if contains("other:" + strconv.Itoa(i)) {
+			positives++
+		}
+	}
+	return float64(positives) / checks
+}
+
+func TestScalableBloom_FalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		name      string
+		errorRate float64
+		capacity  int
+		items     int
+		filters   int
+	}{
+		{name: "10% at capacity", errorRate: 0.1, capacity: 10000, items: 10000, filters: 1},
+		{name: "1% at capacity", errorRate: 0.01, capacity: 10000, items: 10000, filters: 1},
+		{name: "0.1% at capacity", errorRate: 0.001, capacity: 10000, items: 10000, filters: 1},
+		{name: "1% scaled 100 times", errorRate: 0.01, capacity: 1000, items: 100000, filters: 7},
+		{name: "0.1% scaled 100 times", errorRate: 0.001, capacity: 1000, items: 100000, filters: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newScalableBloom(usecase.BloomOptions{ErrorRate: tt.errorRate, Capacity: tt.capacity, Expansion: 2})
+			for i := 0; i < tt.items; i++ {
+				if _, err := b.add("id:" + strconv.Itoa(i)); err != nil {
+					t.Fatalf("add() error = %v", err)
+				}
+			}
+			if len(b.filters) != tt.filters {
+				t.Errorf("filters = %d, want %d", len(b.filters), tt.filters)
+			}
+
+			// no false negatives
+			for i := 0; i < tt.items; i++ {
+				if !b.contains(bloomHashOf("id:" + strconv.Itoa(i))) {
+					t.Fatalf("contains(id:%d) = false, want true", i)
+				}
+			}
+
+			// the rates of the sub-filters sum up to the error rate, hashes are deterministic so a margin
+			// for the variance doesn't make the test flaky
+			rate := falsePositiveRate(func(item string) bool {
+				return b.contains(bloomHashOf(item))
+			})
+			if rate > 1.2*tt.errorRate {
+				t.Errorf("false positive rate = %.5f, want <= %.5f", rate, tt.errorRate)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_BFReserve(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"string": "a"})
+
+	tests := []struct {
+		name    string
+		key     string
+		options usecase.BloomOptions
+		wantErr error
+	}{
+		{name: "creates the filter", key: "seen", options: usecase.DefaultBloomOptions},
+		{name: "non-scaling without expansion", key: "fixed", options: usecase.BloomOptions{ErrorRate: 0.01, Capacity: 10, NonScaling: true}},
+		{name: "existing filter", key: "seen", options: usecase.DefaultBloomOptions, wantErr: domain.ErrItemExists},
+		{name: "existing key of another type", key: "string", options: usecase.DefaultBloomOptions, wantErr: domain.ErrItemExists},
+		{name: "zero error rate", key: "bad", options: usecase.BloomOptions{Capacity: 10, Expansion: 2}, wantErr: domain.ErrFilterErrorRate},
+		{name: "error rate of 1", key: "bad", options: usecase.BloomOptions{ErrorRate: 1, Capacity: 10, Expansion: 2}, wantErr: domain.ErrFilterErrorRate},
+		{name: "zero capacity", key: "bad", options: usecase.BloomOptions{ErrorRate: 0.01, Expansion: 2}, wantErr: domain.ErrFilterCapacity},
+		{name: "zero expansion", key: "bad", options: usecase.BloomOptions{ErrorRate: 0.01, Capacity: 10}, wantErr: domain.ErrBloomExpansion},
+		{name: "large expansion", key: "bad", options: usecase.BloomOptions{ErrorRate: 0.01, Capacity: 10, Expansion: bloomMaxExpansion + 1}, wantErr: domain.ErrBloomExpansion},
+		{name: "large capacity", key: "bad", options: usecase.BloomOptions{ErrorRate: 0.01, Capacity: maxInt, Expansion: 2}, wantErr: domain.ErrFilterTooLarge},
+		{name: "too large for the error rate", key: "bad", options: usecase.BloomOptions{ErrorRate: 1e-10, Capacity: filterMaxCapacity, Expansion: 2}, wantErr: domain.ErrFilterTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.BFReserve(tt.key, tt.options); err != tt.wantErr {
+				t.Errorf("BFReserve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, exists := r.load("bad"); exists {
+		t.Error("BFReserve() created the key on an error")
+	}
+}
+
+func TestScalableBloom_TooLarge(t *testing.T) {
+	// a full sub-filter of the largest capacity, its bits are too few for the capacity to keep the test small
+	b := &scalableBloom{
+		filters:   []*bloomFilter{{bits: make([]uint64, 1), size: 64, hashes: 1, capacity: filterMaxCapacity, count: filterMaxCapacity}},
+		errorRate: 0.01,
+		expansion: bloomMaxExpansion,
+		scaling:   true,
+	}
+	if _, err := b.add("a"); err != domain.ErrFilterTooLarge {
+		t.Fatalf("add() error = %v, want %v", err, domain.ErrFilterTooLarge)
+	}
+	if len(b.filters) != 1 {
+		t.Errorf("filters = %d, want 1", len(b.filters))
+	}
+}
+
+func TestInMemoryRedis_BFAddAndBFExists(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"string": "a"})
+	if err := r.BFReserve("fixed", usecase.BloomOptions{ErrorRate: 0.01, Capacity: 2, NonScaling: true}); err != nil {
+		t.Fatalf("BFReserve() error = %v", err)
+	}
+
+	add := []struct {
+		name    string
+		key     string
+		items   []string
+		want    []bool
+		wantErr error
+	}{
+		{name: "creates the filter", key: "seen", items: []string{"a", "b"}, want: []bool{true, true}},
+		{name: "added items aren't added again", key: "seen", items: []string{"b", "c", "c"}, want: []bool{false, true, false}},
+		{name: "fills a non-scaling filter", key: "fixed", items: []string{"a", "b", "a"}, want: []bool{true, true, false}},
+		{name: "full non-scaling filter", key: "fixed", items: []string{"c"}, wantErr: domain.ErrBloomFull},
+		{name: "wrong type", key: "string", items: []string{"a"}, wantErr: domain.ErrWrongType},
+	}
+	for _, tt := range add {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.BFAdd(tt.key, tt.items)
+			if err != tt.wantErr {
+				t.Fatalf("BFAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BFAdd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	exists := []struct {
+		name    string
+		key     string
+		items   []string
+		want    []bool
+		wantErr error
+	}{
+		{name: "added and missing items", key: "seen", items: []string{"a", "c", "d"}, want: []bool{true, true, false}},
+		{name: "missing key", key: "missing", items: []string{"a"}, want: []bool{false}},
+		{name: "wrong type", key: "string", items: []string{"a"}, wantErr: domain.ErrWrongType},
+	}
+	for _, tt := range exists {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.BFExists(tt.key, tt.items)
+			if err != tt.wantErr {
+				t.Fatalf("BFExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BFExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+)
+
+const (
+	cuckooSeed = 0x5bd1e995
+	// cuckooMaxBucketSize, cuckooMaxIterations and cuckooMaxExpansion are the limits of RedisBloom
+	cuckooMaxBucketSize = 255
+	cuckooMaxIterations = 65535
+	cuckooMaxExpansion  = 32768
+)
+
+// cuckooTable is a sub-filter of buckets of 8-bit fingerprints, 0 is an empty slot.
+// An item may be in one of two buckets: its index and the index xor the hash of the fingerprint,
+// so the other bucket of a fingerprint is known without the item. The number of the buckets is a power of 2.
+type cuckooTable struct {
+	slots      []uint8
+	numBuckets uint64
+	bucketSize int
+}
+
+func newCuckooTable(numBuckets uint64, bucketSize int) *cuckooTable {
+	return &cuckooTable{
+		slots:      make([]uint8, numBuckets*uint64(bucketSize)),
+		numBuckets: numBuckets,
+		bucketSize: bucketSize,
+	}
+}
+
+// cuckooHash is the hash of the item and its fingerprint, the fingerprint is never 0
+type cuckooHash struct {
+	hash        uint64
+	fingerprint uint8
+}
+
+func cuckooHashOf(item string) cuckooHash {
+	hash := murmurHash64A([]byte(item), cuckooSeed)
+	return cuckooHash{hash: hash, fingerprint: uint8(hash>>32%255 + 1)}
+}
+
+func (t *cuckooTable) index(hash uint64) uint64 {
+	return hash & (t.numBuckets - 1)
+}
+
+// altIndex is the other bucket of the fingerprint, altIndex(altIndex(i)) == i
+func (t *cuckooTable) altIndex(index uint64, fingerprint uint8) uint64 {
+	return (index ^ uint64(fingerprint)*cuckooSeed) & (t.numBuckets - 1)
+}
+
+func (t *cuckooTable) bucket(index uint64) []uint8 {
+	return t.slots[index*uint64(t.bucketSize) : (index+1)*uint64(t.bucketSize)]
+}
+
+// find returns the slot of the fingerprint in its buckets, -1 if it isn't there
+func (t *cuckooTable) find(hash cuckooHash) (uint64, int) {
+	i1 := t.index(hash.hash)
+	for _, index := range []uint64{i1, t.altIndex(i1, hash.fingerprint)} {
+		for slot, fingerprint := range t.bucket(index) {
+			if fingerprint == hash.fingerprint {
+				return index, slot
+			}
+		}
+	}
+	return 0, -1
+}
+
+// insertInto puts the fingerprint into a free slot of the bucket
+func (t *cuckooTable) insertInto(index uint64, fingerprint uint8) bool {
+	bucket := t.bucket(index)
+	for slot := range bucket {
+		if bucket[slot] == 0 {
+			bucket[slot] = fingerprint
+			return true
+		}
+	}
+	return false
+}
+
+// insert puts the fingerprint into one of its buckets. When both are full the fingerprint evicts another one,
+// which moves to its other bucket and so on up to maxIterations times. If no free slot is found
+// the evictions are undone, so a failed insert doesn't lose the fingerprints already in the table.
+func (t *cuckooTable) insert(hash cuckooHash, maxIterations int) bool {
+	fingerprint := hash.fingerprint
+	index := t.index(hash.hash)
+	if t.insertInto(index, fingerprint) || t.insertInto(t.altIndex(index, fingerprint), fingerprint) {
+		return true
+	}
+
+	type eviction struct {
+		index uint64
+		slot  int
+	}
+	evictions := make([]eviction, 0, maxIterations)
+	for i := 0; i < maxIterations; i++ {
+		slot := i % t.bucketSize
+		bucket := t.bucket(index)
+		bucket[slot], fingerprint = fingerprint, bucket[slot]
+		evictions = append(evictions, eviction{index: index, slot: slot})
+
+		index = t.altIndex(index, fingerprint)
+		if t.insertInto(index, fingerprint) {
+			return true
+		}
+	}
+
+	for i := len(evictions) - 1; i >= 0; i-- {
+		bucket := t.bucket(evictions[i].index)
+		bucket[evictions[i].slot], fingerprint = fingerprint, bucket[evictions[i].slot]
+	}
+	return false
+}
+
+// cuckooFilter is a chain of tables, the items are added to the last one and looked for in all of them.
+// Unlike a Bloom filter it supports deletion and the same item may be added several times.
+type cuckooFilter struct {
+	tables        []*cuckooTable
+	maxIterations int
+	expansion     int
+}
+
+func newCuckooFilter(options usecase.CuckooOptions) *cuckooFilter {
+	return &cuckooFilter{
+		tables:        []*cuckooTable{newCuckooTable(cuckooNumBuckets(options), options.BucketSize)},
+		maxIterations: options.MaxIterations,
+		expansion:     options.Expansion,
+	}
+}
+
+// cuckooNumBuckets is the least power of 2 of the buckets which hold the capacity
+func cuckooNumBuckets(options usecase.CuckooOptions) uint64 {
+	numBuckets := uint64(1)
+	for numBuckets*uint64(options.BucketSize) < uint64(options.Capacity) {
+		numBuckets <<= 1
+	}
+	return numBuckets
+}
+
+func validateCuckooOptions(options usecase.CuckooOptions) error {
+	if options.Capacity <= 0 {
+		return domain.ErrFilterCapacity
+	}
+	if options.BucketSize < 1 || options.BucketSize > cuckooMaxBucketSize {
+		return domain.ErrCuckooBucketSize
+	}
+	if options.MaxIterations < 1 || options.MaxIterations > cuckooMaxIterations {
+		return domain.ErrCuckooIterations
+	}
+	if options.Expansion < 0 || options.Expansion > cuckooMaxExpansion {
+		return domain.ErrCuckooExpansion
+	}
+	// the capacity is checked first, so the buckets don't overflow
+	if options.Capacity > filterMaxCapacity || cuckooNumBuckets(options)*uint64(options.BucketSize) > filterMaxSize {
+		return domain.ErrFilterTooLarge
+	}
+	return nil
+}
+
+func (f *cuckooFilter) contains(hash cuckooHash) bool {
+	for _, t := range f.tables {
+		if _, slot := t.find(hash); slot >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *cuckooFilter) add(hash cuckooHash) error {
+	last := f.tables[len(f.tables)-1]
+	if last.insert(hash, f.maxIterations) {
+		return nil
+	}
+	if f.expansion == 0 {
+		return domain.ErrCuckooFull
+	}
+
+	if last.numBuckets > filterMaxSize/uint64(f.expansion) {
+		return domain.ErrFilterTooLarge
+	}
+	numBuckets := uint64(1)
+	for numBuckets < last.numBuckets*uint64(f.expansion) {
+		numBuckets <<= 1
+	}
+	if numBuckets*uint64(last.bucketSize) > filterMaxSize {
+		return domain.ErrFilterTooLarge
+	}
+	last = newCuckooTable(numBuckets, last.bucketSize)
+	f.tables = append(f.tables, last)
+	last.insert(hash, f.maxIterations)
+	return nil
+}
+
+// remove deletes one copy of the fingerprint starting with the newest table
+func (f *cuckooFilter) remove(hash cuckooHash) bool {
+	for i := len(f.tables) - 1; i >= 0; i-- {
+		if index, slot := f.tables[i].find(hash); slot >= 0 {
+			f.tables[i].bucket(index)[slot] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// loadCuckoo returns the cuckoo filter stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadCuckoo(key string) (*cuckooFilter, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	f, ok := val.value.(*cuckooFilter)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return f, nil
+}
+
+// CFReserve creates an empty cuckoo filter, the key must not exist
+func (r *InMemoryRedis) CFReserve(key string, options usecase.CuckooOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateCuckooOptions(options); err != nil {
+		return err
+	}
+	if _, exists := r.load(key); exists {
+		return domain.ErrItemExists
+	}
+
+	r.store.Store(key, storeValue{
+		value: newCuckooFilter(options),
+	})
+	return nil
+}
+
+// CFAdd adds the item, the filter is created with usecase.DefaultCuckooOptions if the key doesn't exist.
+// With nx the item isn't added if it may exist, the result reports whether it has been added.
+func (r *InMemoryRedis) CFAdd(key string, item string, nx bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.loadCuckoo(key)
+	if err != nil {
+		return false, err
+	}
+	if f == nil {
+		f = newCuckooFilter(usecase.DefaultCuckooOptions)
+		r.store.Store(key, storeValue{
+			value: f,
+		})
+	}
+
+	hash := cuckooHashOf(item)
+	if nx && f.contains(hash) {
+		return false, nil
+	}
+	if err := f.add(hash); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CFDel deletes one copy of the item and reports whether it has been found. Deleting an item
+// which hasn't been added may delete another item with the same fingerprint.
+func (r *InMemoryRedis) CFDel(key string, item string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.loadCuckoo(key)
+	if err != nil {
+		return false, err
+	}
+	if f == nil {
+		return false, domain.ErrCuckooNotFound
+	}
+	return f.remove(cuckooHashOf(item)), nil
+}
+
+// CFExists reports for every item whether it may have been added, false means it hasn't been for sure
+func (r *InMemoryRedis) CFExists(key string, items []string) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.loadCuckoo(key)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make([]bool, len(items))
+	if f == nil {
+		return exists, nil
+	}
+	for i, item := range items {
+		exists[i] = f.contains(cuckooHashOf(item))
+	}
+	return exists, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCuckooFilter_FalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucketSize int
+		items      int
+		tables     int
+	}{
+		{name: "bucket of 1", bucketSize: 1, items: 5000, tables: 1},
+		{name: "bucket of 2", bucketSize: 2, items: 10000, tables: 1},
+		{name: "bucket of 4", bucketSize: 4, items: 10000, tables: 1},
+		{name: "bucket of 2 expanded", bucketSize: 2, items: 50000, tables: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCuckooFilter(usecase.CuckooOptions{Capacity: 10000, BucketSize: tt.bucketSize, MaxIterations: 20, Expansion: 1})
+			for i := 0; i < tt.items; i++ {
+				if err := f.add(cuckooHashOf("id:" + strconv.Itoa(i))); err != nil {
+					t.Fatalf("add() error = %v", err)
+				}
+			}
+			if len(f.tables) != tt.tables {
+				t.Errorf("tables = %d, want %d", len(f.tables), tt.tables)
+			}
+
+			// failed inserts are undone, so no added item is lost
+			for i := 0; i < tt.items; i++ {
+				if !f.contains(cuckooHashOf("id:" + strconv.Itoa(i))) {
+					t.Fatalf("contains(id:%d) = false, want true", i)
+				}
+			}
+
+			// an item is compared with the 2 buckets of every table, 1 of 255 fingerprints matches by chance
+			want := float64(tt.tables*2*tt.bucketSize) / 255
+			rate := falsePositiveRate(func(item string) bool {
+				return f.contains(cuckooHashOf(item))
+			})
+			if rate > want {
+				t.Errorf("false positive rate = %.5f, want <= %.5f", rate, want)
+			}
+		})
+	}
+}
+
+func TestCuckooFilter_Full(t *testing.T) {
+	f := newCuckooFilter(usecase.CuckooOptions{Capacity: 64, BucketSize: 2, MaxIterations: 20})
+
+	var err error
+	added := 0
+	for ; err == nil; added++ {
+		err = f.add(cuckooHashOf("id:" + strconv.Itoa(added)))
+	}
+	if err != domain.ErrCuckooFull {
+		t.Fatalf("add() error = %v, want %v", err, domain.ErrCuckooFull)
+	}
+	if len(f.tables) != 1 || added < 32 {
+		t.Errorf("tables = %d, added = %d, want 1 table of at least 32 items", len(f.tables), added)
+	}
+
+	for i := 0; i < added-1; i++ {
+		if !f.contains(cuckooHashOf("id:" + strconv.Itoa(i))) {
+			t.Fatalf("contains(id:%d) = false after a failed add, want true", i)
+		}
+	}
+}
+
+func TestCuckooFilter_TooLarge(t *testing.T) {
+	f := newCuckooFilter(usecase.CuckooOptions{Capacity: 1, BucketSize: 1, MaxIterations: 1, Expansion: cuckooMaxExpansion})
+	// the table is full, and the next one of the expansion can't grow beyond the limit
+	f.tables[0] = newCuckooTable(1<<15, 1)
+	for i := range f.tables[0].slots {
+		f.tables[0].slots[i] = 1
+	}
+
+	if err := f.add(cuckooHashOf("a")); err != domain.ErrFilterTooLarge {
+		t.Fatalf("add() error = %v, want %v", err, domain.ErrFilterTooLarge)
+	}
+	if len(f.tables) != 1 {
+		t.Errorf("tables = %d, want 1", len(f.tables))
+	}
+}
+
+func TestInMemoryRedis_CFReserve(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"string": "a"})
+
+	tests := []struct {
+		name    string
+		key     string
+		options usecase.CuckooOptions
+		wantErr error
+	}{
+		{name: "creates the filter", key: "seen", options: usecase.DefaultCuckooOptions},
+		{name: "without expansion", key: "fixed", options: usecase.CuckooOptions{Capacity: 10, BucketSize: 4, MaxIterations: 1}},
+		{name: "existing filter", key: "seen", options: usecase.DefaultCuckooOptions, wantErr: domain.ErrItemExists},
+		{name: "existing key of another type", key: "string", options: usecase.DefaultCuckooOptions, wantErr: domain.ErrItemExists},
+		{name: "zero capacity", key: "bad", options: usecase.CuckooOptions{BucketSize: 2, MaxIterations: 20}, wantErr: domain.ErrFilterCapacity},
+		{name: "large bucket", key: "bad", options: usecase.CuckooOptions{Capacity: 10, BucketSize: 256, MaxIterations: 20}, wantErr: domain.ErrCuckooBucketSize},
+		{name: "zero iterations", key: "bad", options: usecase.CuckooOptions{Capacity: 10, BucketSize: 2}, wantErr: domain.ErrCuckooIterations},
+		{name: "negative expansion", key: "bad", options: usecase.CuckooOptions{Capacity: 10, BucketSize: 2, MaxIterations: 20, Expansion: -1}, wantErr: domain.ErrCuckooExpansion},
+		{name: "large capacity", key: "bad", options: usecase.CuckooOptions{Capacity: maxInt, BucketSize: 2, MaxIterations: 20}, wantErr: domain.ErrFilterTooLarge},
+		{name: "too large for the bucket size", key: "bad", options: usecase.CuckooOptions{Capacity: filterMaxCapacity, BucketSize: cuckooMaxBucketSize, MaxIterations: 20}, wantErr: domain.ErrFilterTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.CFReserve(tt.key, tt.options); err != tt.wantErr {
+				t.Errorf("CFReserve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, exists := r.load("bad"); exists {
+		t.Error("CFReserve() created the key on an error")
+	}
+}
+
+func TestInMemoryRedis_CuckooFilter(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"string": "a"})
+
+	assertExists := func(items []string, want []bool) {
+		t.Helper()
+		got, err := r.CFExists("seen", items)
+		if err != nil {
+			t.Fatalf("CFExists() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CFExists(%v) = %v, want %v", items, got, want)
+		}
+	}
+
+	// the same item may be added twice, then it has to be deleted twice
+	for _, item := range []string{"a", "a", "b"} {
+		if added, err := r.CFAdd("seen", item, false); err != nil || !added {
+			t.Fatalf("CFAdd(%s) = %v, %v, want true", item, added, err)
+		}
+	}
+	if added, err := r.CFAdd("seen", "b", true); err != nil || added {
+		t.Errorf("CFAdd(b, nx) = %v, %v, want false", added, err)
+	}
+	assertExists([]string{"a", "b", "c"}, []bool{true, true, false})
+
+	if deleted, err := r.CFDel("seen", "a"); err != nil || !deleted {
+		t.Fatalf("CFDel(a) = %v, %v, want true", deleted, err)
+	}
+	assertExists([]string{"a"}, []bool{true})
+	if deleted, err := r.CFDel("seen", "a"); err != nil || !deleted {
+		t.Fatalf("CFDel(a) = %v, %v, want true", deleted, err)
+	}
+	assertExists([]string{"a", "b"}, []bool{false, true})
+	if deleted, err := r.CFDel("seen", "a"); err != nil || deleted {
+		t.Errorf("CFDel(a) of a deleted item = %v, %v, want false", deleted, err)
+	}
+
+	if _, err := r.CFDel("missing", "a"); err != domain.ErrCuckooNotFound {
+		t.Errorf("CFDel() of a missing key error = %v, want %v", err, domain.ErrCuckooNotFound)
+	}
+	if got, err := r.CFExists("missing", []string{"a"}); err != nil || !reflect.DeepEqual(got, []bool{false}) {
+		t.Errorf("CFExists() of a missing key = %v, %v, want [false]", got, err)
+	}
+	if _, err := r.CFAdd("string", "a", false); err != domain.ErrWrongType {
+		t.Errorf("CFAdd() error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if _, err := r.CFExists("string", []string{"a"}); err != domain.ErrWrongType {
+		t.Errorf("CFExists() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+const dequeMinCapacity = 8
+
+// deque is a double-ended queue over a ring buffer, pushes and pops at both ends are O(1)
+type deque struct {
+	buf    []string
+	head   int
+	length int
+}
+
+func newDeque(values ...string) *deque {
+	d := &deque{}
+	for _, value := range values {
+		d.pushBack(value)
+	}
+	return d
+}
+
+func (d *deque) len() int {
+	return d.length
+}
+
+// grow doubles the buffer when it's full, the elements are moved to its beginning
+func (d *deque) grow() {
+	if d.length < len(d.buf) {
+		return
+	}
+
+	size := len(d.buf) * 2
+	if size == 0 {
+		size = dequeMinCapacity
+	}
+
+	buf := make([]string, size)
+	for i := 0; i < d.length; i++ {
+		buf[i] = d.at(i)
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *deque) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+func (d *deque) pushFront(value string) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = value
+	d.length++
+}
+
+func (d *deque) pushBack(value string) {
+	d.grow()
+	d.buf[d.index(d.length)] = value
+	d.length++
+}
+
+// popFront removes the first element, the caller makes sure the deque isn't empty
+func (d *deque) popFront() string {
+	value := d.buf[d.head]
+	d.buf[d.head] = ""
+	d.head = d.index(1)
+	d.length--
+	return value
+}
+
+// popBack removes the last element, the caller makes sure the deque isn't empty
+func (d *deque) popBack() string {
+	i := d.index(d.length - 1)
+	value := d.buf[i]
+	d.buf[i] = ""
+	d.length--
+	return value
+}
+
+func (d *deque) at(i int) string {
+	return d.buf[d.index(i)]
+}
+
+func (d *deque) set(i int, value string) {
+	d.buf[d.index(i)] = value
+}
+
+// insert puts the value at i shifting the following elements to the back
+func (d *deque) insert(i int, value string) {
+	d.pushBack(value)
+	for j := d.length - 1; j > i; j-- {
+		d.set(j, d.at(j-1))
+	}
+	d.set(i, value)
+}
+
+// removeIf removes the elements at the indexes for which remove returns true, keeping the order
+func (d *deque) removeIf(remove func(i int) bool) {
+	kept := 0
+	for i := 0; i < d.length; i++ {
+		if !remove(i) {
+			d.set(kept, d.at(i))
+			kept++
+		}
+	}
+	for d.length > kept {
+		d.popBack()
+	}
+}
+
+// slice returns a copy of the elements from start to stop inclusive, the caller checks the bounds
+func (d *deque) slice(start, stop int) []string {
+	result := make([]string, 0, stop-start+1)
+	for i := start; i <= stop; i++ {
+		result = append(result, d.at(i))
+	}
+	return result
+}
@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"sort"
+)
+
+const (
+	// geoStep is the number of bits of the latitude and of the longitude in a geohash score
+	geoStep   = 26
+	geoLatMin = -85.05112878
+	geoLatMax = 85.05112878
+	geoLonMin = -180.0
+	geoLonMax = 180.0
+	// earthRadius is the radius in meters used by Redis to compute the distances
+	earthRadius = 6372797.560856
+	// mercatorMax is the half of the length of the equator in the Mercator projection
+	mercatorMax     = 20037726.37
+	geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+func validGeoPosition(longitude float64, latitude float64) bool {
+	return longitude >= geoLonMin && longitude <= geoLonMax && latitude >= geoLatMin && latitude <= geoLatMax
+}
+
+// geohashEncode splits the latitude range and the longitude range into 2^step cells
+// and interleaves the numbers of the cells of the position, the latitude takes the even bits
+func geohashEncode(longitude float64, latitude float64, latMin float64, latMax float64, step uint) uint64 {
+	cells := float64(uint64(1) << step)
+	latCell := math.Min((latitude-latMin)/(latMax-latMin)*cells, cells-1)
+	lonCell := math.Min((longitude-geoLonMin)/(geoLonMax-geoLonMin)*cells, cells-1)
+	return interleave(uint32(latCell), uint32(lonCell))
+}
+
+// geohashDecode returns the center of the cell of the score
+func geohashDecode(hash uint64) usecase.GeoPosition {
+	cells := float64(uint64(1) << geoStep)
+	latCell, lonCell := squash(hash), squash(hash>>1)
+
+	latSize := (geoLatMax - geoLatMin) / cells
+	lonSize := (geoLonMax - geoLonMin) / cells
+	return usecase.GeoPosition{
+		Longitude: math.Max(geoLonMin, math.Min(geoLonMax, geoLonMin+(float64(lonCell)+0.5)*lonSize)),
+		Latitude:  math.Max(geoLatMin, math.Min(geoLatMax, geoLatMin+(float64(latCell)+0.5)*latSize)),
+	}
+}
+
+// geohashString returns the standard 11 characters geohash, which is computed on the latitude range
+// from -90 to 90 unlike the scores
+func geohashString(position usecase.GeoPosition) string {
+	hash := geohashEncode(position.Longitude, position.Latitude, -90, 90, geoStep)
+
+	result := make([]byte, 11)
+	for i := range result {
+		index := uint64(0)
+		// the 52 bits fill 10 characters and 2 bits of the last one, which is always 0 like in Redis
+		if i < 10 {
+			index = (hash >> (52 - uint(i+1)*5)) & 0x1f
+		}
+		result[i] = geohashAlphabet[index]
+	}
+	return string(result)
+}
+
+// interleave spreads the bits of x to the even bits and the bits of y to the odd bits of the result
+func interleave(x uint32, y uint32) uint64 {
+	return spread(x) | spread(y)<<1
+}
+
+func spread(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// squash collects the even bits of x
+func squash(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return uint32(x)
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func toDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// geoDistance returns the haversine distance in meters
+func geoDistance(from usecase.GeoPosition, to usecase.GeoPosition) float64 {
+	lat1, lat2 := toRadians(from.Latitude), toRadians(to.Latitude)
+	u := math.Sin((lat2 - lat1) / 2)
+	v := math.Sin(toRadians(to.Longitude-from.Longitude) / 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(u*u+math.Cos(lat1)*math.Cos(lat2)*v*v))
+}
+
+// geoEstimateStep returns the step of the cells which are about as large as the radius,
+// they are narrower near the poles, so the step is decreased there
+func geoEstimateStep(radius float64, latitude float64) uint {
+	if radius == 0 {
+		return geoStep
+	}
+
+	step := 1
+	for radius < mercatorMax {
+		radius *= 2
+		step++
+	}
+	step -= 2
+
+	if latitude > 66 || latitude < -66 {
+		step--
+		if latitude > 80 || latitude < -80 {
+			step--
+		}
+	}
+
+	if step < 1 {
+		return 1
+	}
+	if step > geoStep {
+		return geoStep
+	}
+	return uint(step)
+}
+
+// geoScoreRange is the range of the scores [min, max) of the members in a cell
+type geoScoreRange struct {
+	min float64
+	max float64
+}
+
+// geoSearchRanges returns the score ranges of the cell of the center and of its neighbours. The step is decreased
+// until the cells cover the bounding box of the area, halfWidth and halfHeight are in meters.
+func geoSearchRanges(center usecase.GeoPosition, halfWidth float64, halfHeight float64) []geoScoreRange {
+	latDelta := toDegrees(halfHeight / earthRadius)
+	latMin := math.Max(center.Latitude-latDelta, geoLatMin)
+	latMax := math.Min(center.Latitude+latDelta, geoLatMax)
+
+	// the longitude degrees are the shortest at the latitude of the box farthest from the equator
+	lonDelta := 360.0
+	if farthest := math.Max(math.Abs(latMin), math.Abs(latMax)); farthest < 90 {
+		lonDelta = toDegrees(halfWidth / earthRadius / math.Cos(toRadians(farthest)))
+	}
+
+	step := geoEstimateStep(math.Max(halfWidth, halfHeight), center.Latitude)
+	var latCell, lonCell int64
+	for ; ; step-- {
+		hash := geohashEncode(center.Longitude, center.Latitude, geoLatMin, geoLatMax, step)
+		latCell, lonCell = int64(squash(hash)), int64(squash(hash>>1))
+		if step == 1 {
+			break
+		}
+
+		cells := float64(uint64(1) << step)
+		latSize := (geoLatMax - geoLatMin) / cells
+		lonSize := (geoLonMax - geoLonMin) / cells
+		if geoLatMin+float64(latCell-1)*latSize <= latMin && geoLatMin+float64(latCell+2)*latSize >= latMax &&
+			geoLonMin+float64(lonCell-1)*lonSize <= center.Longitude-lonDelta &&
+			geoLonMin+float64(lonCell+2)*lonSize >= center.Longitude+lonDelta {
+			break
+		}
+	}
+
+	cells := int64(1) << step
+	shift := 2 * (geoStep - step)
+	seen := make(map[uint64]bool, 9)
+	ranges := make([]geoScoreRange, 0, 9)
+	for lat := latCell - 1; lat <= latCell+1; lat++ {
+		if lat < 0 || lat >= cells {
+			continue
+		}
+		for lon := lonCell - 1; lon <= lonCell+1; lon++ {
+			// the cells wrap around the antimeridian
+			hash := interleave(uint32(lat), uint32((lon+cells)%cells))
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			ranges = append(ranges, geoScoreRange{min: float64(hash << shift), max: float64((hash + 1) << shift)})
+		}
+	}
+	return ranges
+}
+
+// GeoAdd adds the members to the sorted set at key with their geohashes as the scores like ZADD
+func (r *InMemoryRedis) GeoAdd(key string, members []usecase.GeoMember, options usecase.ZAddOptions) (int, error) {
+	scored := make([]usecase.ScoredMember, 0, len(members))
+	for _, member := range members {
+		if !validGeoPosition(member.Longitude, member.Latitude) {
+			return -1, domain.ErrGeoPosition
+		}
+		hash := geohashEncode(member.Longitude, member.Latitude, geoLatMin, geoLatMax, geoStep)
+		scored = append(scored, usecase.ScoredMember{Member: member.Member, Score: float64(hash)})
+	}
+	return r.ZAdd(key, scored, options)
+}
+
+// GeoPos returns the positions of the members, nil for the missing ones
+func (r *InMemoryRedis) GeoPos(key string, members []string) ([]*usecase.GeoPosition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*usecase.GeoPosition, len(members))
+	if z == nil {
+		return positions, nil
+	}
+	for i, member := range members {
+		if score, ok := z.scores[member]; ok {
+			position := geohashDecode(uint64(score))
+			positions[i] = &position
+		}
+	}
+	return positions, nil
+}
+
+// GeoDist returns the distance between the members in the unit given in meters,
+// false if any of them is missing
+func (r *InMemoryRedis) GeoDist(key string, first string, second string, unit float64) (float64, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, false, err
+	}
+
+	from, ok := z.scores[first]
+	if !ok {
+		return 0, false, nil
+	}
+	to, ok := z.scores[second]
+	if !ok {
+		return 0, false, nil
+	}
+	return geoDistance(geohashDecode(uint64(from)), geohashDecode(uint64(to))) / unit, true, nil
+}
+
+// GeoHash returns the geohash strings of the members, nil for the missing ones
+func (r *InMemoryRedis) GeoHash(key string, members []string) ([]*string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]*string, len(members))
+	if z == nil {
+		return hashes, nil
+	}
+	for i, member := range members {
+		if score, ok := z.scores[member]; ok {
+			hash := geohashString(geohashDecode(uint64(score)))
+			hashes[i] = &hash
+		}
+	}
+	return hashes, nil
+}
+
+func (r *InMemoryRedis) GeoSearch(key string, query usecase.GeoSearchQuery) ([]usecase.GeoLocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.geoSearch(key, query)
+}
+
+// GeoSearchStore stores the members found by the query at destination with their geohashes,
+// or with their distances with storeDist, and returns their number
+func (r *InMemoryRedis) GeoSearchStore(destination string, key string, query usecase.GeoSearchQuery, storeDist bool) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	locations, err := r.geoSearch(key, query)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(locations) == 0 {
+		r.del(destination)
+		return 0, nil
+	}
+
+	z := newSortedSet()
+	for _, location := range locations {
+		score := float64(location.Hash)
+		if storeDist {
+			score = location.Distance
+		}
+		z.add(location.Member, score)
+	}
+	r.store.Store(destination, storeValue{
+		value: z,
+	})
+	return len(locations), nil
+}
+
+func (r *InMemoryRedis) geoSearch(key string, query usecase.GeoSearchQuery) ([]usecase.GeoLocation, error) {
+	z, err := r.loadSortedSet(key)
+	if err != nil {
+		return nil, err
+	}
+	if z == nil {
+		return []usecase.GeoLocation{}, nil
+	}
+
+	center := usecase.GeoPosition{Longitude: query.Longitude, Latitude: query.Latitude}
+	if query.FromMember {
+		score, ok := z.scores[query.Member]
+		if !ok {
+			return nil, domain.ErrGeoMemberNotFound
+		}
+		center = geohashDecode(uint64(score))
+	} else if !validGeoPosition(center.Longitude, center.Latitude) {
+		return nil, domain.ErrGeoPosition
+	}
+
+	halfWidth, halfHeight := query.Radius*query.Unit, query.Radius*query.Unit
+	if query.ByBox {
+		halfWidth, halfHeight = query.Width*query.Unit/2, query.Height*query.Unit/2
+	}
+
+	locations := make([]usecase.GeoLocation, 0)
+	for _, scores := range geoSearchRanges(center, halfWidth, halfHeight) {
+		node := z.list.first(func(n *skiplistNode) bool { return n.score >= scores.min })
+		for ; node != nil && node.score < scores.max; node = node.level[0].forward {
+			if query.Any && query.Count > 0 && len(locations) == query.Count {
+				break
+			}
+
+			position := geohashDecode(uint64(node.score))
+			distance, ok := geoDistanceInArea(center, position, query.ByBox, halfWidth, halfHeight)
+			if !ok {
+				continue
+			}
+			locations = append(locations, usecase.GeoLocation{
+				Member:   node.member,
+				Distance: distance / query.Unit,
+				Hash:     int64(node.score),
+				Position: position,
+			})
+		}
+	}
+
+	order := query.Sort
+	if order == usecase.GeoSortNone && query.Count > 0 && !query.Any {
+		order = usecase.GeoSortAsc
+	}
+	switch order {
+	case usecase.GeoSortAsc:
+		sort.SliceStable(locations, func(i, j int) bool { return locations[i].Distance < locations[j].Distance })
+	case usecase.GeoSortDesc:
+		sort.SliceStable(locations, func(i, j int) bool { return locations[i].Distance > locations[j].Distance })
+	}
+
+	if query.Count > 0 && len(locations) > query.Count {
+		locations = locations[:query.Count]
+	}
+	return locations, nil
+}
+
+// geoDistanceInArea returns the distance in meters from the center to the position
+// if it's within the radius halfWidth or within the box
+func geoDistanceInArea(center usecase.GeoPosition, position usecase.GeoPosition, byBox bool,
+	halfWidth float64, halfHeight float64) (float64, bool) {
+	if byBox {
+		latDistance := earthRadius * math.Abs(toRadians(position.Latitude-center.Latitude))
+		if latDistance > halfHeight {
+			return 0, false
+		}
+		// the longitude distance is measured along the latitude of the position
+		lonDistance := geoDistance(usecase.GeoPosition{Longitude: center.Longitude, Latitude: position.Latitude}, position)
+		if lonDistance > halfWidth {
+			return 0, false
+		}
+		return geoDistance(center, position), true
+	}
+
+	distance := geoDistance(center, position)
+	return distance, distance <= halfWidth
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// testSicily is the geo set of the Redis examples, the scores are the geohashes of
+// Palermo (13.361389, 38.115556), Catania (15.087269, 37.502669) and Agrigento (13.583333, 37.316667)
+func testSicily() *sortedSet {
+	return newTestSortedSet(
+		usecase.ScoredMember{Member: "Palermo", Score: 3479099956230698},
+		usecase.ScoredMember{Member: "Catania", Score: 3479447370796909},
+		usecase.ScoredMember{Member: "Agrigento", Score: 3479030013248308},
+	)
+}
+
+func locationMembers(locations []usecase.GeoLocation) []string {
+	members := make([]string, 0, len(locations))
+	for _, location := range locations {
+		members = append(members, location.Member)
+	}
+	return members
+}
+
+func TestInMemoryRedis_GeoAdd(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		members []usecase.GeoMember
+		options usecase.ZAddOptions
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr error
+	}{
+		{
+			name:   "GeoAdd when a key doesn't exist",
+			fields: fields{},
+			args:   args{key: "sicily", members: []usecase.GeoMember{{Member: "Palermo", Longitude: 13.361389, Latitude: 38.115556}}},
+			want:   1,
+		},
+		{
+			name:   "GeoAdd adds new members",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", members: []usecase.GeoMember{{Member: "Palermo", Longitude: 13.361389, Latitude: 38.115556}, {Member: "Rome", Longitude: 12.496365, Latitude: 41.902782}}},
+			want:   1,
+		},
+		{
+			name:   "GeoAdd with CH counts moved members",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", members: []usecase.GeoMember{{Member: "Palermo", Longitude: 13, Latitude: 38}}, options: usecase.ZAddOptions{CH: true}},
+			want:   1,
+		},
+		{
+			name:   "GeoAdd with XX doesn't add members",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", members: []usecase.GeoMember{{Member: "Rome", Longitude: 12.496365, Latitude: 41.902782}}, options: usecase.ZAddOptions{XX: true}},
+			want:   0,
+		},
+		{
+			name:    "GeoAdd rejects a latitude out of the Mercator range",
+			fields:  fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:    args{key: "sicily", members: []usecase.GeoMember{{Member: "Pole", Longitude: 0, Latitude: 89}}},
+			want:    -1,
+			wantErr: domain.ErrGeoPosition,
+		},
+		{
+			name:    "GeoAdd rejects a longitude out of range",
+			fields:  fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:    args{key: "sicily", members: []usecase.GeoMember{{Member: "Nowhere", Longitude: 181, Latitude: 0}}},
+			want:    -1,
+			wantErr: domain.ErrGeoPosition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.GeoAdd(tt.args.key, tt.args.members, tt.args.options)
+			if err != tt.wantErr {
+				t.Errorf("GeoAdd() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GeoAdd() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_GeoAddScore(t *testing.T) {
+	r := newTestRedis(nil)
+	_, err := r.GeoAdd("sicily", []usecase.GeoMember{
+		{Member: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		{Member: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+		{Member: "Agrigento", Longitude: 13.583333, Latitude: 37.316667},
+	}, usecase.ZAddOptions{})
+	if err != nil {
+		t.Fatalf("GeoAdd() error = %v", err)
+	}
+
+	// the scores are the same as in Redis
+	for member, want := range testSicily().scores {
+		if score, _, _ := r.ZScore("sicily", member); score != want {
+			t.Errorf("GeoAdd() score of %v = %v, want %v", member, score, want)
+		}
+	}
+}
+
+func TestInMemoryRedis_GeoPos(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"sicily": testSicily()})
+	got, err := r.GeoPos("sicily", []string{"Palermo", "Missing"})
+	if err != nil {
+		t.Fatalf("GeoPos() error = %v", err)
+	}
+
+	if got[1] != nil {
+		t.Errorf("GeoPos() of a missing member = %v, want nil", got[1])
+	}
+	if got[0] == nil || math.Abs(got[0].Longitude-13.361389) > 1e-5 || math.Abs(got[0].Latitude-38.115556) > 1e-5 {
+		t.Errorf("GeoPos() = %v, want about 13.361389, 38.115556", got[0])
+	}
+}
+
+func TestInMemoryRedis_GeoDist(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key    string
+		first  string
+		second string
+		unit   float64
+	}
+	sicily := map[string]interface{}{"sicily": testSicily()}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   float64
+		wantOk bool
+	}{
+		{name: "GeoDist in meters", fields: fields{values: sicily}, args: args{key: "sicily", first: "Palermo", second: "Catania", unit: 1}, want: 166274.1516, wantOk: true},
+		{name: "GeoDist in kilometers", fields: fields{values: sicily}, args: args{key: "sicily", first: "Palermo", second: "Catania", unit: 1000}, want: 166.2742, wantOk: true},
+		{name: "GeoDist in miles", fields: fields{values: sicily}, args: args{key: "sicily", first: "Palermo", second: "Catania", unit: 1609.34}, want: 103.3182, wantOk: true},
+		{name: "GeoDist of the same member", fields: fields{values: sicily}, args: args{key: "sicily", first: "Palermo", second: "Palermo", unit: 1}, want: 0, wantOk: true},
+		{name: "GeoDist of a missing member", fields: fields{values: sicily}, args: args{key: "sicily", first: "Palermo", second: "Missing", unit: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, ok, err := r.GeoDist(tt.args.key, tt.args.first, tt.args.second, tt.args.unit)
+			if err != nil || ok != tt.wantOk {
+				t.Errorf("GeoDist() ok = %v, error = %v, want %v", ok, err, tt.wantOk)
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("GeoDist() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_GeoHash(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"sicily": testSicily()})
+	got, err := r.GeoHash("sicily", []string{"Palermo", "Catania", "Missing"})
+	if err != nil {
+		t.Fatalf("GeoHash() error = %v", err)
+	}
+
+	if got[0] == nil || *got[0] != "sqc8b49rny0" {
+		t.Errorf("GeoHash() of Palermo = %v, want sqc8b49rny0", got[0])
+	}
+	if got[1] == nil || *got[1] != "sqdtr74hyu0" {
+		t.Errorf("GeoHash() of Catania = %v, want sqdtr74hyu0", got[1])
+	}
+	if got[2] != nil {
+		t.Errorf("GeoHash() of a missing member = %v, want nil", *got[2])
+	}
+}
+
+func TestInMemoryRedis_GeoSearch(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key   string
+		query usecase.GeoSearchQuery
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "GeoSearch by radius from a position",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Unit: 1000, Sort: usecase.GeoSortAsc}},
+			want:   []string{"Catania", "Agrigento", "Palermo"},
+		},
+		{
+			name:   "GeoSearch by radius in descending order",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Unit: 1000, Sort: usecase.GeoSortDesc}},
+			want:   []string{"Palermo", "Agrigento", "Catania"},
+		},
+		{
+			name:   "GeoSearch by a smaller radius",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 100, Unit: 1000, Sort: usecase.GeoSortAsc}},
+			want:   []string{"Catania"},
+		},
+		{
+			name:   "GeoSearch with COUNT returns the nearest members",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Unit: 1000, Count: 2}},
+			want:   []string{"Catania", "Agrigento"},
+		},
+		{
+			name:   "GeoSearch from a member",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{FromMember: true, Member: "Palermo", Radius: 100, Unit: 1000, Sort: usecase.GeoSortAsc}},
+			want:   []string{"Palermo", "Agrigento"},
+		},
+		{
+			name:   "GeoSearch by box",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 14, Latitude: 37.5, ByBox: true, Width: 200, Height: 80, Unit: 1000, Sort: usecase.GeoSortAsc}},
+			want:   []string{"Agrigento", "Catania"},
+		},
+		{
+			name:   "GeoSearch by a box including everything",
+			fields: fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:   args{key: "sicily", query: usecase.GeoSearchQuery{Longitude: 14, Latitude: 37.5, ByBox: true, Width: 400, Height: 400, Unit: 1000, Sort: usecase.GeoSortAsc}},
+			want:   []string{"Agrigento", "Palermo", "Catania"},
+		},
+		{
+			name:    "GeoSearch from a missing member",
+			fields:  fields{values: map[string]interface{}{"sicily": testSicily()}},
+			args:    args{key: "sicily", query: usecase.GeoSearchQuery{FromMember: true, Member: "Missing", Radius: 100, Unit: 1000}},
+			wantErr: domain.ErrGeoMemberNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.GeoSearch(tt.args.key, tt.args.query)
+			if err != tt.wantErr {
+				t.Errorf("GeoSearch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(locationMembers(got), tt.want) {
+				t.Errorf("GeoSearch() got = %v, want %v", locationMembers(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_GeoSearchDistances(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"sicily": testSicily()})
+	got, err := r.GeoSearch("sicily", usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Unit: 1000, Sort: usecase.GeoSortAsc})
+	if err != nil {
+		t.Fatalf("GeoSearch() error = %v", err)
+	}
+
+	// the distances of Catania and Palermo are the ones reported by Redis
+	want := []float64{56.4413, 130.4235, 190.4424}
+	for i, location := range got {
+		if math.Abs(location.Distance-want[i]) > 1e-4 {
+			t.Errorf("GeoSearch() distance of %v = %v, want %v", location.Member, location.Distance, want[i])
+		}
+	}
+}
+
+// TestInMemoryRedis_GeoSearchMatchesFullScan compares the search in the geohash cells with checking every member
+// near the antimeridian, near the poles and at the equator
+func TestInMemoryRedis_GeoSearchMatchesFullScan(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	r := newTestRedis(nil)
+	members := make([]usecase.GeoMember, 0, 5000)
+	for i := 0; i < cap(members); i++ {
+		members = append(members, usecase.GeoMember{
+			Member:    strconv.Itoa(i),
+			Longitude: random.Float64()*360 - 180,
+			Latitude:  random.Float64()*170 - 85,
+		})
+	}
+	if _, err := r.GeoAdd("points", members, usecase.ZAddOptions{}); err != nil {
+		t.Fatalf("GeoAdd() error = %v", err)
+	}
+
+	queries := []usecase.GeoSearchQuery{
+		{Longitude: 179.9, Latitude: 0, Radius: 800, Unit: 1000},
+		{Longitude: -179.9, Latitude: 50, ByBox: true, Width: 1500, Height: 600, Unit: 1000},
+		{Longitude: 10, Latitude: 84, Radius: 1000, Unit: 1000},
+		{Longitude: -60, Latitude: -83, ByBox: true, Width: 3000, Height: 500, Unit: 1000},
+		{Longitude: 0, Latitude: 0, Radius: 3000, Unit: 1000},
+		{Longitude: 30, Latitude: 45, Radius: 20000, Unit: 1000},
+	}
+	for _, query := range queries {
+		got, err := r.GeoSearch("points", query)
+		if err != nil {
+			t.Fatalf("GeoSearch() error = %v", err)
+		}
+
+		center := usecase.GeoPosition{Longitude: query.Longitude, Latitude: query.Latitude}
+		halfWidth, halfHeight := query.Radius*query.Unit, query.Radius*query.Unit
+		if query.ByBox {
+			halfWidth, halfHeight = query.Width*query.Unit/2, query.Height*query.Unit/2
+		}
+		want := make([]string, 0)
+		for _, member := range members {
+			position := geohashDecode(geohashEncode(member.Longitude, member.Latitude, geoLatMin, geoLatMax, geoStep))
+			if _, ok := geoDistanceInArea(center, position, query.ByBox, halfWidth, halfHeight); ok {
+				want = append(want, member.Member)
+			}
+		}
+
+		gotMembers := locationMembers(got)
+		sort.Strings(gotMembers)
+		sort.Strings(want)
+		if len(want) == 0 || !reflect.DeepEqual(gotMembers, want) {
+			t.Errorf("GeoSearch(%+v) found %v members, want %v", query, len(gotMembers), len(want))
+		}
+	}
+}
+
+func TestInMemoryRedis_GeoSearchStore(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"sicily": testSicily()})
+	query := usecase.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Unit: 1000}
+
+	got, err := r.GeoSearchStore("near", "sicily", query, false)
+	if err != nil || got != 3 {
+		t.Fatalf("GeoSearchStore() got = %v, error = %v, want 3", got, err)
+	}
+	if score, _, _ := r.ZScore("near", "Palermo"); score != 3479099956230698 {
+		t.Errorf("GeoSearchStore() stored score = %v, want the geohash", score)
+	}
+
+	got, err = r.GeoSearchStore("distances", "sicily", query, true)
+	if err != nil || got != 3 {
+		t.Fatalf("GeoSearchStore() with storeDist got = %v, error = %v, want 3", got, err)
+	}
+	if score, _, _ := r.ZScore("distances", "Catania"); math.Abs(score-56.4413) > 1e-4 {
+		t.Errorf("GeoSearchStore() stored distance = %v, want 56.4413", score)
+	}
+
+	query.Radius = 1
+	got, err = r.GeoSearchStore("near", "sicily", query, false)
+	if err != nil || got != 0 {
+		t.Fatalf("GeoSearchStore() got = %v, error = %v, want 0", got, err)
+	}
+	if _, exists := r.load("near"); exists {
+		t.Errorf("GeoSearchStore() kept the destination of an empty result")
+	}
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"sort"
+	"strconv"
+)
+
+type hash map[string]string
+
+// fields returns the fields sorted, so HGETALL, HKEYS and HVALS agree on the order
+func (h hash) fields() []string {
+	result := make([]string, 0, len(h))
+	for field := range h {
+		result = append(result, field)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// loadHash returns the hash stored at key, a nil hash if the key doesn't exist
+func (r *InMemoryRedis) loadHash(key string) (hash, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	h, ok := val.value.(hash)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return h, nil
+}
+
+// loadOrCreateHash is loadHash which stores an empty hash if the key doesn't exist
+func (r *InMemoryRedis) loadOrCreateHash(key string) (hash, error) {
+	h, err := r.loadHash(key)
+	if err != nil || h != nil {
+		return h, err
+	}
+
+	h = make(hash)
+	r.store.Store(key, storeValue{
+		value: h,
+	})
+	return h, nil
+}
+
+func (r *InMemoryRedis) HGet(key string, field string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil || h == nil {
+		return "", false, err
+	}
+
+	v, ok := h[field]
+	return v, ok, nil
+}
+
+// HSet sets the fields and returns the number of the newly created ones
+func (r *InMemoryRedis) HSet(key string, pairs []usecase.FieldValue) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(pairs) == 0 {
+		_, err := r.loadHash(key)
+		return 0, err
+	}
+
+	h, err := r.loadOrCreateHash(key)
+	if err != nil {
+		return -1, err
+	}
+
+	created := 0
+	for _, pair := range pairs {
+		if _, ok := h[pair.Field]; !ok {
+			created++
+		}
+		h[pair.Field] = pair.Value
+	}
+	return created, nil
+}
+
+// HSetNX sets the field only if it doesn't exist yet, the returned flag is true if it was set
+func (r *InMemoryRedis) HSetNX(key string, field string, value string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadOrCreateHash(key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := h[field]; ok {
+		return false, nil
+	}
+	h[field] = value
+	return true, nil
+}
+
+// HDel removes the fields and returns the number of the removed ones, the key is deleted with the last field
+func (r *InMemoryRedis) HDel(key string, fields []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil || h == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, field := range fields {
+		if _, ok := h[field]; ok {
+			delete(h, field)
+			removed++
+		}
+	}
+
+	if len(h) == 0 {
+		r.del(key)
+	}
+	return removed, nil
+}
+
+func (r *InMemoryRedis) HGetAll(key string) ([]usecase.FieldValue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]usecase.FieldValue, 0)
+	h, err := r.loadHash(key)
+	if err != nil || h == nil {
+		return result, err
+	}
+
+	for _, field := range h.fields() {
+		result = append(result, usecase.FieldValue{Field: field, Value: h[field]})
+	}
+	return result, nil
+}
+
+func (r *InMemoryRedis) HKeys(key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil || h == nil {
+		return []string{}, err
+	}
+	return h.fields(), nil
+}
+
+func (r *InMemoryRedis) HVals(key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil || h == nil {
+		return []string{}, err
+	}
+
+	fields := h.fields()
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, h[field])
+	}
+	return result, nil
+}
+
+func (r *InMemoryRedis) HLen(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil {
+		return 0, err
+	}
+	return len(h), nil
+}
+
+func (r *InMemoryRedis) HExists(key string, field string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := h[field]
+	return ok, nil
+}
+
+// HMGet returns the values of the fields, nil for the missing ones
+func (r *InMemoryRedis) HMGet(key string, fields []string) ([]*string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadHash(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*string, len(fields))
+	for i, field := range fields {
+		if value, ok := h[field]; ok {
+			result[i] = &value
+		}
+	}
+	return result, nil
+}
+
+// HIncrBy adds the increment to the integer stored in the field, a missing field is treated as 0
+func (r *InMemoryRedis) HIncrBy(key string, field string, increment int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, err := r.loadOrCreateHash(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	if value, ok := h[field]; ok {
+		current, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, domain.ErrHashNotInteger
+		}
+	}
+
+	if (increment > 0 && current > math.MaxInt64-increment) || (increment < 0 && current < math.MinInt64-increment) {
+		return 0, domain.ErrOverflow
+	}
+
+	current += increment
+	h[field] = strconv.FormatInt(current, 10)
+	return current, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestInMemoryRedis_HSetCountsNewFields(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"hash": hash{"a": "1"}})
+
+	got, err := r.HSet("hash", []usecase.FieldValue{{Field: "a", Value: "2"}, {Field: "b", Value: "3"}, {Field: "b", Value: "4"}})
+	if err != nil {
+		t.Fatalf("HSet() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("HSet() = %v, want 1", got)
+	}
+
+	pairs, _ := r.HGetAll("hash")
+	want := []usecase.FieldValue{{Field: "a", Value: "2"}, {Field: "b", Value: "4"}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("HGetAll() = %v, want %v", pairs, want)
+	}
+}
+
+func TestInMemoryRedis_HSetNX(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		field     string
+		want      bool
+		wantErr   error
+		wantValue string
+	}{
+		{
+			name:      "creates the hash",
+			values:    map[string]interface{}{},
+			field:     "a",
+			want:      true,
+			wantValue: "new",
+		},
+		{
+			name:      "keeps the existing field",
+			values:    map[string]interface{}{"hash": hash{"a": "old"}},
+			field:     "a",
+			want:      false,
+			wantValue: "old",
+		},
+		{
+			name:    "fails on a wrong type",
+			values:  map[string]interface{}{"hash": "value"},
+			field:   "a",
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.HSetNX("hash", tt.field, "new")
+			if err != tt.wantErr {
+				t.Fatalf("HSetNX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HSetNX() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.HGet("hash", tt.field); value != tt.wantValue {
+				t.Errorf("HGet() = %q, want %q", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_HDel(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"hash": hash{"a": "1", "b": "2"}})
+
+	if got, _ := r.HDel("hash", []string{"a", "missing"}); got != 1 {
+		t.Errorf("HDel() = %v, want 1", got)
+	}
+	if got, _ := r.HKeys("hash"); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("HKeys() = %v, want [b]", got)
+	}
+
+	if got, _ := r.HDel("hash", []string{"b"}); got != 1 {
+		t.Errorf("HDel() = %v, want 1", got)
+	}
+	if _, ok := r.store.Load("hash"); ok {
+		t.Error("the empty hash is not deleted")
+	}
+}
+
+func TestInMemoryRedis_HashReads(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"hash": hash{"b": "2", "a": "1"}, "string": "value"})
+
+	if got, _ := r.HKeys("hash"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("HKeys() = %v, want [a b]", got)
+	}
+	if got, _ := r.HVals("hash"); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("HVals() = %v, want [1 2]", got)
+	}
+	if got, _ := r.HLen("hash"); got != 2 {
+		t.Errorf("HLen() = %v, want 2", got)
+	}
+	if got, _ := r.HLen("missing"); got != 0 {
+		t.Errorf("HLen() of a missing key = %v, want 0", got)
+	}
+	if got, _ := r.HExists("hash", "a"); !got {
+		t.Error("HExists() = false, want true")
+	}
+	if got, _ := r.HExists("hash", "c"); got {
+		t.Error("HExists() = true, want false")
+	}
+
+	values, err := r.HMGet("hash", []string{"b", "c", "a"})
+	if err != nil {
+		t.Fatalf("HMGet() error = %v", err)
+	}
+	if len(values) != 3 || values[0] == nil || *values[0] != "2" || values[1] != nil || values[2] == nil || *values[2] != "1" {
+		t.Errorf("HMGet() = %v, want [2 <nil> 1]", values)
+	}
+
+	if _, err := r.HGetAll("string"); err != domain.ErrWrongType {
+		t.Errorf("HGetAll() error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if _, err := r.HMGet("string", []string{"a"}); err != domain.ErrWrongType {
+		t.Errorf("HMGet() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
+
+func TestInMemoryRedis_HIncrBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		increment int64
+		want      int64
+		wantErr   error
+	}{
+		{
+			name:      "missing field starts from 0",
+			values:    map[string]interface{}{},
+			increment: 5,
+			want:      5,
+		},
+		{
+			name:      "decrements",
+			values:    map[string]interface{}{"hash": hash{"counter": "10"}},
+			increment: -3,
+			want:      7,
+		},
+		{
+			name:      "not an integer",
+			values:    map[string]interface{}{"hash": hash{"counter": "ten"}},
+			increment: 1,
+			wantErr:   domain.ErrHashNotInteger,
+		},
+		{
+			name:      "overflow",
+			values:    map[string]interface{}{"hash": hash{"counter": strconv.FormatInt(1<<62, 10)}},
+			increment: 1 << 62,
+			wantErr:   domain.ErrOverflow,
+		},
+		{
+			name:      "wrong type",
+			values:    map[string]interface{}{"hash": "value"},
+			increment: 1,
+			wantErr:   domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.HIncrBy("hash", "counter", tt.increment)
+			if err != tt.wantErr {
+				t.Fatalf("HIncrBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HIncrBy() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.HGet("hash", "counter"); value != strconv.FormatInt(tt.want, 10) {
+				t.Errorf("HGet() = %q, want %d", value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"encoding/binary"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+const (
+	// hllP is the number of the hash bits which select a register, Redis uses the same precision
+	hllP         = 14
+	hllRegisters = 1 << hllP
+	// hllQ is the number of the hash bits which are counted, a register holds at most hllQ+1
+	hllQ = 64 - hllP
+	// hllSparseMaxRegisters is the number of the set registers after which the sparse representation
+	// is converted to the dense one, a sparse register takes 4 bytes and a dense one takes 1 byte
+	hllSparseMaxRegisters = hllRegisters / 16
+	hllSeed               = 0xadc83b19
+)
+
+// hyperLogLog estimates the number of the unique elements with the standard error of 1.04/sqrt(hllRegisters) = 0.81%.
+// While few registers are set they're kept sparse, as a sorted slice of index<<8 | value,
+// then the registers are converted to a dense array.
+type hyperLogLog struct {
+	sparse []uint32
+	dense  []uint8
+}
+
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{}
+}
+
+func (h *hyperLogLog) isSparse() bool {
+	return h.dense == nil
+}
+
+// add adds the element and reports whether a register has changed
+func (h *hyperLogLog) add(element string) bool {
+	hash := murmurHash64A([]byte(element), hllSeed)
+	index := int(hash & (hllRegisters - 1))
+
+	// the sentinel bit limits the run of zeros to hllQ
+	hash = hash>>hllP | 1<<hllQ
+	return h.set(index, uint8(bits.TrailingZeros64(hash)+1))
+}
+
+// set raises the register to the value and reports whether it has changed
+func (h *hyperLogLog) set(index int, value uint8) bool {
+	if !h.isSparse() {
+		if h.dense[index] >= value {
+			return false
+		}
+		h.dense[index] = value
+		return true
+	}
+
+	i := sort.Search(len(h.sparse), func(i int) bool {
+		return int(h.sparse[i]>>8) >= index
+	})
+	if i < len(h.sparse) && int(h.sparse[i]>>8) == index {
+		if uint8(h.sparse[i]) >= value {
+			return false
+		}
+		h.sparse[i] = uint32(index)<<8 | uint32(value)
+		return true
+	}
+
+	h.sparse = append(h.sparse, 0)
+	copy(h.sparse[i+1:], h.sparse[i:])
+	h.sparse[i] = uint32(index)<<8 | uint32(value)
+
+	if len(h.sparse) > hllSparseMaxRegisters {
+		h.toDense()
+	}
+	return true
+}
+
+func (h *hyperLogLog) toDense() {
+	h.dense = make([]uint8, hllRegisters)
+	for _, register := range h.sparse {
+		h.dense[register>>8] = uint8(register)
+	}
+	h.sparse = nil
+}
+
+// forEach calls fn for every register which isn't 0
+func (h *hyperLogLog) forEach(fn func(index int, value uint8)) {
+	if h.isSparse() {
+		for _, register := range h.sparse {
+			fn(int(register>>8), uint8(register))
+		}
+		return
+	}
+
+	for index, value := range h.dense {
+		if value != 0 {
+			fn(index, value)
+		}
+	}
+}
+
+// merge raises every register to the register of the other, the result counts the union of the elements
+func (h *hyperLogLog) merge(other *hyperLogLog) {
+	if !other.isSparse() && h.isSparse() {
+		h.toDense()
+	}
+	other.forEach(func(index int, value uint8) {
+		h.set(index, value)
+	})
+}
+
+// count estimates the cardinality with the estimator of Otmar Ertl, "New cardinality estimation algorithms
+// for HyperLogLog sketches", which Redis also uses. It has no bias for small and large cardinalities.
+func (h *hyperLogLog) count() int64 {
+	var histogram [hllQ + 2]int
+	set := 0
+	h.forEach(func(_ int, value uint8) {
+		histogram[value]++
+		set++
+	})
+	histogram[0] = hllRegisters - set
+
+	m := float64(hllRegisters)
+	z := m * hllTau((m-float64(histogram[hllQ+1]))/m)
+	for k := hllQ; k >= 1; k-- {
+		z += float64(histogram[k])
+		z *= 0.5
+	}
+	z += m * hllSigma(float64(histogram[0])/m)
+
+	return int64(math.Round(0.5 / math.Ln2 * m * m / z))
+}
+
+func hllSigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+
+	y, z := 1.0, x
+	for {
+		x *= x
+		previous := z
+		z += x * y
+		y += y
+		if z == previous {
+			return z
+		}
+	}
+}
+
+func hllTau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		previous := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == previous {
+			return z / 3
+		}
+	}
+}
+
+// murmurHash64A is the 64-bit MurmurHash2 by Austin Appleby
+func murmurHash64A(data []byte, seed uint64) uint64 {
+	const (
+		m = 0xc6a4a7935bd1e995
+		r = 47
+	)
+
+	h := seed ^ uint64(len(data))*m
+	for ; len(data) >= 8; data = data[8:] {
+		k := binary.LittleEndian.Uint64(data)
+		k *= m
+		k ^= k >> r
+		k *= m
+
+		h ^= k
+		h *= m
+	}
+
+	if len(data) > 0 {
+		for i := len(data) - 1; i >= 0; i-- {
+			h ^= uint64(data[i]) << (8 * uint(i))
+		}
+		h *= m
+	}
+
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
+
+// loadHyperLogLog returns the HyperLogLog stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadHyperLogLog(key string) (*hyperLogLog, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	h, ok := val.value.(*hyperLogLog)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return h, nil
+}
+
+// loadOrCreateHyperLogLog returns the HyperLogLog stored at key, it's created if the key doesn't exist
+func (r *InMemoryRedis) loadOrCreateHyperLogLog(key string) (*hyperLogLog, bool, error) {
+	h, err := r.loadHyperLogLog(key)
+	if err != nil || h != nil {
+		return h, false, err
+	}
+
+	h = newHyperLogLog()
+	r.store.Store(key, storeValue{
+		value: h,
+	})
+	return h, true, nil
+}
+
+// PFAdd adds the elements and reports whether the estimate may have changed, i.e. a register has changed
+// or the key has been created
+func (r *InMemoryRedis) PFAdd(key string, elements []string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, created, err := r.loadOrCreateHyperLogLog(key)
+	if err != nil {
+		return false, err
+	}
+
+	changed := created
+	for _, element := range elements {
+		if h.add(element) {
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// PFCount estimates the number of the unique elements added to any of the keys, missing keys are empty
+func (r *InMemoryRedis) PFCount(keys []string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(keys) == 1 {
+		h, err := r.loadHyperLogLog(keys[0])
+		if err != nil || h == nil {
+			return 0, err
+		}
+		return h.count(), nil
+	}
+
+	union, err := r.mergeHyperLogLogs(newHyperLogLog(), keys)
+	if err != nil {
+		return 0, err
+	}
+	return union.count(), nil
+}
+
+// PFMerge merges the keys into the destination, the destination is created if it doesn't exist
+func (r *InMemoryRedis) PFMerge(destination string, keys []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the types before the destination is created
+	for _, key := range append([]string{destination}, keys...) {
+		if _, err := r.loadHyperLogLog(key); err != nil {
+			return err
+		}
+	}
+
+	h, _, err := r.loadOrCreateHyperLogLog(destination)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mergeHyperLogLogs(h, keys)
+	return err
+}
+
+func (r *InMemoryRedis) mergeHyperLogLogs(h *hyperLogLog, keys []string) (*hyperLogLog, error) {
+	for _, key := range keys {
+		other, err := r.loadHyperLogLog(key)
+		if err != nil {
+			return nil, err
+		}
+		if other != nil && other != h {
+			h.merge(other)
+		}
+	}
+	return h, nil
+}
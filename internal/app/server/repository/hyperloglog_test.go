@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// hllStandardError is the standard error of the estimate, 0.81% like in Redis
+var hllStandardError = 1.04 / math.Sqrt(hllRegisters)
+
+func TestHyperLogLog_Accuracy(t *testing.T) {
+	for _, cardinality := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+		t.Run(strconv.Itoa(cardinality), func(t *testing.T) {
+			h := newHyperLogLog()
+			for i := 0; i < cardinality; i++ {
+				h.add("element:" + strconv.Itoa(i))
+			}
+
+			// the estimate must be within 3 standard errors, hashes are deterministic so the test isn't flaky
+			got := h.count()
+			if relative := math.Abs(float64(got-int64(cardinality))) / float64(cardinality); relative > 3*hllStandardError {
+				t.Errorf("count() = %d, want %d ± %.2f%%", got, cardinality, 300*hllStandardError)
+			}
+		})
+	}
+}
+
+func TestHyperLogLog_SparseToDense(t *testing.T) {
+	h := newHyperLogLog()
+	for i := 0; h.isSparse(); i++ {
+		h.add("element:" + strconv.Itoa(i))
+	}
+	if len(h.sparse) != 0 || len(h.dense) != hllRegisters {
+		t.Fatalf("sparse registers = %d, dense registers = %d, want 0, %d", len(h.sparse), len(h.dense), hllRegisters)
+	}
+
+	// the same registers kept sparse must give the same estimate
+	sparse := newHyperLogLog()
+	h.forEach(func(index int, value uint8) {
+		sparse.sparse = append(sparse.sparse, uint32(index)<<8|uint32(value))
+	})
+	if got, want := sparse.count(), h.count(); got != want {
+		t.Errorf("sparse count() = %d, dense count() = %d", got, want)
+	}
+
+	// merging a dense HyperLogLog converts the sparse one
+	merged := newHyperLogLog()
+	merged.add("element:0")
+	merged.merge(h)
+	if merged.isSparse() || merged.count() != h.count() {
+		t.Errorf("merged count() = %d, sparse = %v, want %d, false", merged.count(), merged.isSparse(), h.count())
+	}
+}
+
+func TestInMemoryRedis_PFAdd(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a")})
+
+	tests := []struct {
+		name     string
+		key      string
+		elements []string
+		want     bool
+		wantErr  error
+	}{
+		{name: "creates the key without elements", key: "hll", want: true},
+		{name: "adds new elements", key: "hll", elements: []string{"a", "b", "c"}, want: true},
+		{name: "existing elements don't change it", key: "hll", elements: []string{"a", "c"}},
+		{name: "wrong type", key: "list", elements: []string{"a"}, wantErr: domain.ErrWrongType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.PFAdd(tt.key, tt.elements)
+			if err != tt.wantErr {
+				t.Fatalf("PFAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PFAdd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_PFCountAndPFMerge(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a")})
+
+	// two overlapping days of visitors: 0..19999 and 10000..39999
+	add := func(key string, from, to int) {
+		elements := make([]string, 0, to-from)
+		for i := from; i < to; i++ {
+			elements = append(elements, "visitor:"+strconv.Itoa(i))
+		}
+		if _, err := r.PFAdd(key, elements); err != nil {
+			t.Fatalf("PFAdd() error = %v", err)
+		}
+	}
+	add("monday", 0, 20000)
+	add("tuesday", 10000, 40000)
+	add("small", 39990, 40010)
+
+	assertCount := func(keys []string, want int64) {
+		t.Helper()
+		got, err := r.PFCount(keys)
+		if err != nil {
+			t.Fatalf("PFCount(%v) error = %v", keys, err)
+		}
+		if relative := math.Abs(float64(got-want)) / float64(want); relative > 3*hllStandardError {
+			t.Errorf("PFCount(%v) = %d, want %d", keys, got, want)
+		}
+	}
+	assertCount([]string{"monday"}, 20000)
+	assertCount([]string{"monday", "tuesday", "missing"}, 40000)
+	assertCount([]string{"tuesday", "small"}, 30010)
+
+	// counting several keys doesn't modify them
+	assertCount([]string{"monday"}, 20000)
+
+	if err := r.PFMerge("week", []string{"monday", "tuesday"}); err != nil {
+		t.Fatalf("PFMerge() error = %v", err)
+	}
+	assertCount([]string{"week"}, 40000)
+
+	// the destination is merged too
+	if err := r.PFMerge("week", []string{"small"}); err != nil {
+		t.Fatalf("PFMerge() error = %v", err)
+	}
+	assertCount([]string{"week"}, 40010)
+
+	if got, _ := r.PFCount([]string{"missing"}); got != 0 {
+		t.Errorf("PFCount() of a missing key = %d, want 0", got)
+	}
+	if _, err := r.PFCount([]string{"monday", "list"}); err != domain.ErrWrongType {
+		t.Errorf("PFCount() error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if err := r.PFMerge("created", []string{"list"}); err != domain.ErrWrongType {
+		t.Errorf("PFMerge() error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if _, exists := r.load("created"); exists {
+		t.Error("PFMerge() created the destination on an error")
+	}
+}
@@ -0,0 +1,593 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonDocument is the value of a JSON key. The values of the document are *jsonObject, *jsonArray, string,
+// json.Number, bool and nil, the numbers keep their text so integers stay integers.
+type jsonDocument struct {
+	root interface{}
+}
+
+// jsonObject is a JSON object which keeps the order of its keys
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+type jsonArray struct {
+	items []interface{}
+}
+
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]interface{})}
+}
+
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *jsonObject) remove(key string) bool {
+	if _, ok := o.values[key]; !ok {
+		return false
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// parseJSON decodes a single JSON value
+func parseJSON(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	value, err := decodeJSON(decoder)
+	if err != nil {
+		return nil, domain.ErrJSONInvalid
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, domain.ErrJSONInvalid
+	}
+	return value, nil
+}
+
+func decodeJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		object := newJSONObject()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.set(key.(string), value)
+		}
+		_, err = decoder.Token()
+		return object, err
+	case '[':
+		array := &jsonArray{items: make([]interface{}, 0)}
+		for decoder.More() {
+			value, err := decodeJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array.items = append(array.items, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	default:
+		return nil, domain.ErrJSONInvalid
+	}
+}
+
+// encodeJSON returns the compact JSON text of the value
+func encodeJSON(value interface{}) string {
+	var buf bytes.Buffer
+	writeJSON(&buf, value)
+	return buf.String()
+}
+
+func writeJSON(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case *jsonObject:
+		buf.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, key)
+			buf.WriteByte(':')
+			writeJSON(buf, v.values[key])
+		}
+		buf.WriteByte('}')
+	case *jsonArray:
+		buf.WriteByte('[')
+		for i, item := range v.items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(buf, item)
+		}
+		buf.WriteByte(']')
+	case string:
+		writeJSONString(buf, v)
+	case json.Number:
+		buf.WriteString(string(v))
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	default:
+		buf.WriteString("null")
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	// Encode terminates the value with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// copyJSON returns a deep copy of the value, so that it can be stored at several paths
+func copyJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *jsonObject:
+		object := newJSONObject()
+		for _, key := range v.keys {
+			object.set(key, copyJSON(v.values[key]))
+		}
+		return object
+	case *jsonArray:
+		array := &jsonArray{items: make([]interface{}, 0, len(v.items))}
+		for _, item := range v.items {
+			array.items = append(array.items, copyJSON(item))
+		}
+		return array
+	default:
+		return value
+	}
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case *jsonObject:
+		return "object"
+	case *jsonArray:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(string(v), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// addJSONNumbers adds integers as int64 and falls back to float64 for fractions and overflows
+func addJSONNumbers(a json.Number, b json.Number) (json.Number, error) {
+	x, errX := strconv.ParseInt(string(a), 10, 64)
+	y, errY := strconv.ParseInt(string(b), 10, 64)
+	if errX == nil && errY == nil && (y <= 0 || x <= math.MaxInt64-y) && (y >= 0 || x >= math.MinInt64-y) {
+		return json.Number(strconv.FormatInt(x+y, 10)), nil
+	}
+
+	fx, err := a.Float64()
+	if err != nil {
+		return "", domain.ErrJSONNotNumber
+	}
+	fy, err := b.Float64()
+	if err != nil {
+		return "", domain.ErrJSONNotNumber
+	}
+	sum := fx + fy
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return "", domain.ErrIncrNaNOrInfinity
+	}
+
+	// a float result stays a float, even if it's whole
+	text := strconv.FormatFloat(sum, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+	return json.Number(text), nil
+}
+
+// set replaces the matched value in its parent or the root of the document
+func (m jsonMatch) set(doc *jsonDocument, value interface{}) {
+	switch parent := m.parent.(type) {
+	case *jsonObject:
+		parent.values[m.key] = value
+	case *jsonArray:
+		parent.items[m.index] = value
+	default:
+		doc.root = value
+	}
+}
+
+// loadJSON returns the document stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadJSON(key string) (*jsonDocument, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	doc, ok := val.value.(*jsonDocument)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return doc, nil
+}
+
+// JSONSet sets the value at the path. A missing key can be created only at the root, a missing object key
+// is added if the path ends with it. The returned flag is false if NX, XX or a missing path prevented the update.
+func (r *InMemoryRedis) JSONSet(key string, path string, value string, options usecase.JSONSetOptions) (bool, error) {
+	if options.NX && options.XX {
+		return false, domain.ErrSyntax
+	}
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return false, err
+	}
+	parsed, err := parseJSON(value)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil {
+		return false, err
+	}
+
+	if doc == nil {
+		if len(parsedPath.selectors) > 0 {
+			return false, domain.ErrJSONNewAtRoot
+		}
+		if options.XX {
+			return false, nil
+		}
+		r.store.Store(key, storeValue{
+			value: &jsonDocument{root: parsed},
+		})
+		return true, nil
+	}
+
+	matches := selectJSON([]jsonMatch{{value: doc.root}}, parsedPath.selectors)
+	if len(matches) > 0 {
+		if options.NX {
+			return false, nil
+		}
+		for i, match := range matches {
+			if i > 0 {
+				parsed = copyJSON(parsed)
+			}
+			match.set(doc, parsed)
+		}
+		return true, nil
+	}
+
+	last := parsedPath.selectors[len(parsedPath.selectors)-1]
+	if options.XX || last.recursive || last.wildcard || len(last.keys) != 1 {
+		return false, nil
+	}
+
+	added := false
+	parents := selectJSON([]jsonMatch{{value: doc.root}}, parsedPath.selectors[:len(parsedPath.selectors)-1])
+	for _, parent := range parents {
+		if object, ok := parent.value.(*jsonObject); ok {
+			if added {
+				parsed = copyJSON(parsed)
+			}
+			object.set(last.keys[0], parsed)
+			added = true
+		}
+	}
+	return added, nil
+}
+
+// JSONGet returns the JSON text of the values at the paths, the whole document without paths.
+// A JSONPath selects an array of values and a legacy path a single value. With several paths the result
+// is an object with the values of every path. The returned flag is false if the key doesn't exist.
+func (r *InMemoryRedis) JSONGet(key string, paths []string) (string, bool, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	parsedPaths := make([]jsonPath, 0, len(paths))
+	legacy := true
+	for _, path := range paths {
+		parsed, err := parseJSONPath(path)
+		if err != nil {
+			return "", false, err
+		}
+		legacy = legacy && parsed.legacy
+		parsedPaths = append(parsedPaths, parsed)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil || doc == nil {
+		return "", false, err
+	}
+
+	// the paths are legacy only if all of them are
+	results := make([]interface{}, 0, len(paths))
+	for _, path := range parsedPaths {
+		path.legacy = legacy
+		matches := path.match(doc.root)
+		if !legacy {
+			array := &jsonArray{items: make([]interface{}, 0, len(matches))}
+			for _, match := range matches {
+				array.items = append(array.items, match.value)
+			}
+			results = append(results, array)
+			continue
+		}
+
+		if len(matches) == 0 {
+			return "", false, domain.ErrJSONPathNotFound
+		}
+		results = append(results, matches[0].value)
+	}
+
+	if len(paths) == 1 {
+		return encodeJSON(results[0]), true, nil
+	}
+
+	object := newJSONObject()
+	for i, path := range paths {
+		object.set(path, results[i])
+	}
+	return encodeJSON(object), true, nil
+}
+
+// JSONDel deletes the values at the path and returns their number, deleting the root deletes the key
+func (r *InMemoryRedis) JSONDel(key string, path string) (int, error) {
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return -1, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil || doc == nil {
+		return 0, err
+	}
+
+	if len(parsedPath.selectors) == 0 {
+		r.del(key)
+		return 1, nil
+	}
+
+	deleted := 0
+	indexes := make(map[*jsonArray][]int)
+	for _, match := range selectJSON([]jsonMatch{{value: doc.root}}, parsedPath.selectors) {
+		switch parent := match.parent.(type) {
+		case *jsonObject:
+			if parent.remove(match.key) {
+				deleted++
+			}
+		case *jsonArray:
+			indexes[parent] = append(indexes[parent], match.index)
+		}
+	}
+
+	// the items are removed from the end, so that the indexes of the rest don't change
+	for array, selected := range indexes {
+		sort.Sort(sort.Reverse(sort.IntSlice(selected)))
+		for i, index := range selected {
+			if i > 0 && index == selected[i-1] {
+				continue
+			}
+			array.items = append(array.items[:index], array.items[index+1:]...)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// JSONType returns the types of the values at the path, nil if the key doesn't exist
+func (r *InMemoryRedis) JSONType(key string, path string) ([]string, error) {
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	types := make([]string, 0)
+	for _, match := range parsedPath.match(doc.root) {
+		types = append(types, jsonType(match.value))
+	}
+	return types, nil
+}
+
+// JSONNumIncrBy increments the numbers at the path and returns their new values, nil for the values
+// which aren't numbers. A legacy path must select a number.
+func (r *InMemoryRedis) JSONNumIncrBy(key string, path string, increment string) ([]*string, error) {
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseJSON(increment)
+	if err != nil {
+		return nil, domain.ErrJSONNotNumber
+	}
+	number, ok := parsed.(json.Number)
+	if !ok {
+		return nil, domain.ErrJSONNotNumber
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, domain.ErrNoSuchKey
+	}
+
+	matches := parsedPath.match(doc.root)
+	if parsedPath.legacy && len(matches) == 0 {
+		return nil, domain.ErrJSONPathNotFound
+	}
+
+	// the sums are computed before any update, so that an error leaves the document unchanged
+	sums := make([]*json.Number, len(matches))
+	for i, match := range matches {
+		current, ok := match.value.(json.Number)
+		if !ok {
+			if parsedPath.legacy {
+				return nil, domain.ErrJSONNotNumber
+			}
+			continue
+		}
+		sum, err := addJSONNumbers(current, number)
+		if err != nil {
+			return nil, err
+		}
+		sums[i] = &sum
+	}
+
+	values := make([]*string, len(matches))
+	for i, match := range matches {
+		if sums[i] != nil {
+			match.set(doc, *sums[i])
+			value := string(*sums[i])
+			values[i] = &value
+		}
+	}
+	return values, nil
+}
+
+// JSONArrAppend appends the values to the arrays at the path and returns their new lengths, nil for the values
+// which aren't arrays. A legacy path must select an array.
+func (r *InMemoryRedis) JSONArrAppend(key string, path string, values []string) ([]*int, error) {
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		item, err := parseJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, item)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, domain.ErrNoSuchKey
+	}
+
+	matches := parsedPath.match(doc.root)
+	if parsedPath.legacy && len(matches) == 0 {
+		return nil, domain.ErrJSONPathNotFound
+	}
+
+	lengths := make([]*int, len(matches))
+	for i, match := range matches {
+		array, ok := match.value.(*jsonArray)
+		if !ok {
+			if parsedPath.legacy {
+				return nil, domain.ErrJSONNotArray
+			}
+			continue
+		}
+
+		for _, item := range parsed {
+			array.items = append(array.items, copyJSON(item))
+		}
+		length := len(array.items)
+		lengths[i] = &length
+	}
+	return lengths, nil
+}
+
+// JSONObjKeys returns the keys of the objects at the path, nil for the values which aren't objects.
+// The result is nil if the key doesn't exist.
+func (r *InMemoryRedis) JSONObjKeys(key string, path string) ([][]string, error) {
+	parsedPath, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, err := r.loadJSON(key)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	matches := parsedPath.match(doc.root)
+	keys := make([][]string, len(matches))
+	for i, match := range matches {
+		object, ok := match.value.(*jsonObject)
+		if !ok {
+			if parsedPath.legacy {
+				return nil, domain.ErrJSONNotObject
+			}
+			continue
+		}
+		keys[i] = append(make([]string, 0, len(object.keys)), object.keys...)
+	}
+	return keys, nil
+}
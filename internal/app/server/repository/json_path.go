@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"strconv"
+	"strings"
+)
+
+// jsonSelector is a step of a JSONPath: a wildcard, keys, array indexes or a slice,
+// with recursive it's applied to the value and all its descendants (..)
+type jsonSelector struct {
+	recursive bool
+	wildcard  bool
+	keys      []string
+	indexes   []int
+	slice     *jsonSlice
+}
+
+// jsonSlice is an array slice [start:end:step], the bounds are optional and can be negative
+type jsonSlice struct {
+	start *int
+	end   *int
+	step  int
+}
+
+// jsonPath is a parsed JSONPath, a legacy path selects only the first match
+type jsonPath struct {
+	selectors []jsonSelector
+	legacy    bool
+}
+
+// jsonMatch is a value selected by a path with the location to replace or delete it,
+// parent is nil for the root
+type jsonMatch struct {
+	value  interface{}
+	parent interface{}
+	key    string
+	index  int
+}
+
+// parseJSONPath parses a JSONPath like $.store.book[0,1]..price or a legacy path like .store.book[0],
+// filter expressions aren't supported
+func parseJSONPath(path string) (jsonPath, error) {
+	result := jsonPath{legacy: !usecase.IsJSONPath(path)}
+	rest := strings.TrimPrefix(path, "$")
+	if result.legacy {
+		if rest == "" || rest == "." {
+			return result, nil
+		}
+		if rest[0] != '.' && rest[0] != '[' {
+			rest = "." + rest
+		}
+	}
+
+	for rest != "" {
+		var selector jsonSelector
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			selector.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				break
+			}
+			fallthrough
+		case rest[0] == '.':
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return jsonPath{}, domain.ErrJSONPath
+			}
+			if name == "*" {
+				selector.wildcard = true
+			} else {
+				selector.keys = []string{name}
+			}
+			result.selectors = append(result.selectors, selector)
+			continue
+		case rest[0] != '[':
+			return jsonPath{}, domain.ErrJSONPath
+		}
+
+		end := closingBracket(rest)
+		if end < 0 {
+			return jsonPath{}, domain.ErrJSONPath
+		}
+		if err := parseJSONBracket(rest[1:end], &selector); err != nil {
+			return jsonPath{}, err
+		}
+		rest = rest[end+1:]
+		result.selectors = append(result.selectors, selector)
+	}
+	return result, nil
+}
+
+// closingBracket returns the index of the bracket closing the one at the start of s, skipping quoted keys
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '\'' || s[i] == '"'):
+			quote = s[i]
+		case quote == 0 && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseJSONBracket parses the content of [...]: *, a slice or a union of quoted keys or indexes
+func parseJSONBracket(content string, selector *jsonSelector) error {
+	content = strings.TrimSpace(content)
+	if content == "*" {
+		selector.wildcard = true
+		return nil
+	}
+	if content == "" || strings.HasPrefix(content, "?") {
+		return domain.ErrJSONPath
+	}
+
+	if !strings.ContainsAny(content, "'\"") && strings.Contains(content, ":") {
+		slice, err := parseJSONSlice(content)
+		if err != nil {
+			return err
+		}
+		selector.slice = slice
+		return nil
+	}
+
+	for _, part := range splitJSONUnion(content) {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && (part[0] == '\'' || part[0] == '"') && part[len(part)-1] == part[0] {
+			key, err := unquoteJSONKey(part)
+			if err != nil {
+				return err
+			}
+			selector.keys = append(selector.keys, key)
+			continue
+		}
+
+		index, err := strconv.Atoi(part)
+		if err != nil {
+			return domain.ErrJSONPath
+		}
+		selector.indexes = append(selector.indexes, index)
+	}
+	return nil
+}
+
+// splitJSONUnion splits the union by the commas outside of the quoted keys
+func splitJSONUnion(content string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(content); i++ {
+		switch {
+		case quote != 0 && content[i] == '\\':
+			i++
+		case quote != 0 && content[i] == quote:
+			quote = 0
+		case quote == 0 && (content[i] == '\'' || content[i] == '"'):
+			quote = content[i]
+		case quote == 0 && content[i] == ',':
+			parts = append(parts, content[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, content[start:])
+}
+
+func unquoteJSONKey(quoted string) (string, error) {
+	if quoted[0] == '\'' {
+		quoted = `"` + strings.Replace(strings.Replace(quoted[1:len(quoted)-1], `\'`, `'`, -1), `"`, `\"`, -1) + `"`
+	}
+	key, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", domain.ErrJSONPath
+	}
+	return key, nil
+}
+
+func parseJSONSlice(content string) (*jsonSlice, error) {
+	parts := strings.Split(content, ":")
+	if len(parts) > 3 {
+		return nil, domain.ErrJSONPath
+	}
+
+	slice := &jsonSlice{step: 1}
+	bounds := []**int{&slice.start, &slice.end}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, domain.ErrJSONPath
+		}
+		if i == 2 {
+			if value <= 0 {
+				return nil, domain.ErrJSONPath
+			}
+			slice.step = value
+			continue
+		}
+		*bounds[i] = &value
+	}
+	return slice, nil
+}
+
+// match returns the values selected by the path, only the first of them for a legacy path
+func (p jsonPath) match(root interface{}) []jsonMatch {
+	matches := selectJSON([]jsonMatch{{value: root}}, p.selectors)
+	if p.legacy && len(matches) > 1 {
+		return matches[:1]
+	}
+	return matches
+}
+
+func selectJSON(current []jsonMatch, selectors []jsonSelector) []jsonMatch {
+	for _, selector := range selectors {
+		var next []jsonMatch
+		for _, match := range current {
+			if !selector.recursive {
+				next = selector.apply(match, next)
+				continue
+			}
+			for _, descendant := range jsonDescendants(match, nil) {
+				next = selector.apply(descendant, next)
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// jsonDescendants appends the match and all the values nested in it in the document order
+func jsonDescendants(match jsonMatch, result []jsonMatch) []jsonMatch {
+	result = append(result, match)
+	for _, child := range (jsonSelector{wildcard: true}).apply(match, nil) {
+		result = jsonDescendants(child, result)
+	}
+	return result
+}
+
+// apply appends the children of the match selected by the selector to result
+func (s jsonSelector) apply(match jsonMatch, result []jsonMatch) []jsonMatch {
+	switch value := match.value.(type) {
+	case *jsonObject:
+		if s.wildcard {
+			for _, key := range value.keys {
+				result = append(result, jsonMatch{value: value.values[key], parent: value, key: key})
+			}
+		}
+		for _, key := range s.keys {
+			if child, ok := value.values[key]; ok {
+				result = append(result, jsonMatch{value: child, parent: value, key: key})
+			}
+		}
+	case *jsonArray:
+		length := len(value.items)
+		var indexes []int
+		switch {
+		case s.wildcard:
+			for i := range value.items {
+				indexes = append(indexes, i)
+			}
+		case s.slice != nil:
+			indexes = s.slice.indexes(length)
+		default:
+			for _, index := range s.indexes {
+				if index < 0 {
+					index += length
+				}
+				if index >= 0 && index < length {
+					indexes = append(indexes, index)
+				}
+			}
+		}
+		for _, index := range indexes {
+			result = append(result, jsonMatch{value: value.items[index], parent: value, index: index})
+		}
+	}
+	return result
+}
+
+// indexes returns the indexes of the slice of an array of the length
+func (s *jsonSlice) indexes(length int) []int {
+	bound := func(value *int, defaultValue int) int {
+		if value == nil {
+			return defaultValue
+		}
+		index := *value
+		if index < 0 {
+			index += length
+		}
+		if index < 0 {
+			return 0
+		}
+		if index > length {
+			return length
+		}
+		return index
+	}
+
+	var indexes []int
+	for i := bound(s.start, 0); i < bound(s.end, length); i += s.step {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
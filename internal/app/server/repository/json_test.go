@@ -0,0 +1,393 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testJSONDocument = `{"name":"Leonard","age":30,"tags":["a","b"],"address":{"city":"Moscow","zip":"101000"},` +
+	`"orders":[{"id":1,"price":10.5},{"id":2,"price":3}]}`
+
+// newTestJSONDocument parses the text of a test document, it panics on invalid JSON
+func newTestJSONDocument(text string) *jsonDocument {
+	root, err := parseJSON(text)
+	if err != nil {
+		panic(err)
+	}
+	return &jsonDocument{root: root}
+}
+
+func jsonOf(t *testing.T, r *InMemoryRedis, key string, paths ...string) string {
+	got, _, err := r.JSONGet(key, paths)
+	if err != nil {
+		t.Fatalf("JSONGet() error = %v", err)
+	}
+	return got
+}
+
+func TestParseJSONKeepsKeyOrderAndNumbers(t *testing.T) {
+	text := `{"b":1,"a":[1.50,-2e3,true,null,"<\"x\">"],"c":{}}`
+	value, err := parseJSON(text)
+	if err != nil {
+		t.Fatalf("parseJSON() error = %v", err)
+	}
+	if got := encodeJSON(value); got != text {
+		t.Errorf("encodeJSON() = %v, want %v", got, text)
+	}
+
+	for _, invalid := range []string{"", "{", `{"a":}`, "[1,]", "1 2", "nope"} {
+		if _, err := parseJSON(invalid); err != domain.ErrJSONInvalid {
+			t.Errorf("parseJSON(%q) error = %v, want %v", invalid, err, domain.ErrJSONInvalid)
+		}
+	}
+}
+
+func TestInMemoryRedis_JSONGet(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key   string
+		paths []string
+	}
+	doc := fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr error
+	}{
+		{name: "JSONGet without paths returns the document", fields: doc, args: args{key: "doc"}, want: testJSONDocument},
+		{name: "JSONGet with the root", fields: doc, args: args{key: "doc", paths: []string{"$"}}, want: "[" + testJSONDocument + "]"},
+		{name: "JSONGet with a key", fields: doc, args: args{key: "doc", paths: []string{"$.address.city"}}, want: `["Moscow"]`},
+		{name: "JSONGet with a bracket key", fields: doc, args: args{key: "doc", paths: []string{`$['address']["zip"]`}}, want: `["101000"]`},
+		{name: "JSONGet with an index", fields: doc, args: args{key: "doc", paths: []string{"$.tags[1]"}}, want: `["b"]`},
+		{name: "JSONGet with a negative index", fields: doc, args: args{key: "doc", paths: []string{"$.tags[-1]"}}, want: `["b"]`},
+		{name: "JSONGet with a union", fields: doc, args: args{key: "doc", paths: []string{"$.orders[0,1].id"}}, want: `[1,2]`},
+		{name: "JSONGet with a slice", fields: doc, args: args{key: "doc", paths: []string{"$.orders[1:].price"}}, want: `[3]`},
+		{name: "JSONGet with a wildcard", fields: doc, args: args{key: "doc", paths: []string{"$.address.*"}}, want: `["Moscow","101000"]`},
+		{name: "JSONGet with a recursive descent", fields: doc, args: args{key: "doc", paths: []string{"$..price"}}, want: `[10.5,3]`},
+		{name: "JSONGet with a missing path", fields: doc, args: args{key: "doc", paths: []string{"$.missing"}}, want: `[]`},
+		{name: "JSONGet with a legacy path", fields: doc, args: args{key: "doc", paths: []string{".address.city"}}, want: `"Moscow"`},
+		{name: "JSONGet with a legacy path without a dot", fields: doc, args: args{key: "doc", paths: []string{"orders[0]"}}, want: `{"id":1,"price":10.5}`},
+		{name: "JSONGet with a missing legacy path", fields: doc, args: args{key: "doc", paths: []string{".missing"}}, wantErr: domain.ErrJSONPathNotFound},
+		{name: "JSONGet with several legacy paths", fields: doc, args: args{key: "doc", paths: []string{".name", ".age"}}, want: `{".name":"Leonard",".age":30}`},
+		{name: "JSONGet with several paths", fields: doc, args: args{key: "doc", paths: []string{"$.name", ".age"}}, want: `{"$.name":["Leonard"],".age":[30]}`},
+		{name: "JSONGet with an invalid path", fields: doc, args: args{key: "doc", paths: []string{"$.tags[?(@>1)]"}}, wantErr: domain.ErrJSONPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, ok, err := r.JSONGet(tt.args.key, tt.args.paths)
+			if err != tt.wantErr {
+				t.Errorf("JSONGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (!ok || got != tt.want) {
+				t.Errorf("JSONGet() got = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_JSONGetMissingKey(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument), "string": "value"})
+	if _, ok, err := r.JSONGet("missing", nil); ok || err != nil {
+		t.Errorf("JSONGet() ok = %v, error = %v, want false", ok, err)
+	}
+	if _, _, err := r.JSONGet("string", nil); err != domain.ErrWrongType {
+		t.Errorf("JSONGet() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
+
+func TestInMemoryRedis_JSONSet(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		path    string
+		value   string
+		options usecase.JSONSetOptions
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    bool
+		wantDoc string
+		wantErr error
+	}{
+		{
+			name:    "JSONSet replaces a value",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.city", value: `"Kazan"`},
+			want:    true,
+			wantDoc: `{"city":"Kazan","zip":"101000"}`,
+		},
+		{
+			name:    "JSONSet adds a missing key",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.street", value: `{"name":"Tverskaya"}`},
+			want:    true,
+			wantDoc: `{"city":"Moscow","zip":"101000","street":{"name":"Tverskaya"}}`,
+		},
+		{
+			name:    "JSONSet with NX doesn't replace a value",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.city", value: `"Kazan"`, options: usecase.JSONSetOptions{NX: true}},
+			wantDoc: `{"city":"Moscow","zip":"101000"}`,
+		},
+		{
+			name:    "JSONSet with XX doesn't add a key",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.street", value: `"Tverskaya"`, options: usecase.JSONSetOptions{XX: true}},
+			wantDoc: `{"city":"Moscow","zip":"101000"}`,
+		},
+		{
+			name:    "JSONSet replaces every match",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address[*]", value: `null`},
+			want:    true,
+			wantDoc: `{"city":null,"zip":null}`,
+		},
+		{
+			name:    "JSONSet doesn't add keys under missing objects",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.missing.city", value: `"Kazan"`},
+			wantDoc: `{"city":"Moscow","zip":"101000"}`,
+		},
+		{
+			name:    "JSONSet with invalid JSON",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.city", value: `Kazan`},
+			wantErr: domain.ErrJSONInvalid,
+		},
+		{
+			name:    "JSONSet with NX and XX",
+			fields:  fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}},
+			args:    args{key: "doc", path: "$.address.city", value: `"Kazan"`, options: usecase.JSONSetOptions{NX: true, XX: true}},
+			wantErr: domain.ErrSyntax,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.JSONSet(tt.args.key, tt.args.path, tt.args.value, tt.args.options)
+			if err != tt.wantErr {
+				t.Errorf("JSONSet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JSONSet() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantDoc != "" {
+				if doc := jsonOf(t, r, "doc", ".address"); doc != tt.wantDoc {
+					t.Errorf("JSONSet() address = %v, want %v", doc, tt.wantDoc)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_JSONSetRoot(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument), "string": "value"})
+	if _, err := r.JSONSet("new", "$.a", `1`, usecase.JSONSetOptions{}); err != domain.ErrJSONNewAtRoot {
+		t.Errorf("JSONSet() error = %v, want %v", err, domain.ErrJSONNewAtRoot)
+	}
+	if ok, _ := r.JSONSet("new", "$", `1`, usecase.JSONSetOptions{XX: true}); ok {
+		t.Errorf("JSONSet() with XX created the key")
+	}
+	if ok, _ := r.JSONSet("doc", ".", `1`, usecase.JSONSetOptions{NX: true}); ok {
+		t.Errorf("JSONSet() with NX replaced the document")
+	}
+	if ok, err := r.JSONSet("doc", "$", `[1]`, usecase.JSONSetOptions{}); !ok || err != nil {
+		t.Errorf("JSONSet() ok = %v, error = %v, want true", ok, err)
+	}
+	if doc := jsonOf(t, r, "doc"); doc != "[1]" {
+		t.Errorf("JSONSet() document = %v, want [1]", doc)
+	}
+	if _, err := r.JSONSet("string", "$", `1`, usecase.JSONSetOptions{}); err != domain.ErrWrongType {
+		t.Errorf("JSONSet() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
+
+func TestInMemoryRedis_JSONDel(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key  string
+		path string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantDoc string
+	}{
+		{name: "JSONDel deletes a key", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.address"}, want: 1, wantDoc: `{"name":"Leonard","age":30,"tags":["a","b"],"orders":[{"id":1,"price":10.5},{"id":2,"price":3}]}`},
+		{name: "JSONDel deletes array items", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.tags[0,1]"}, want: 2, wantDoc: `{"name":"Leonard","age":30,"tags":[],"address":{"city":"Moscow","zip":"101000"},"orders":[{"id":1,"price":10.5},{"id":2,"price":3}]}`},
+		{name: "JSONDel deletes recursively", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$..price"}, want: 2, wantDoc: `{"name":"Leonard","age":30,"tags":["a","b"],"address":{"city":"Moscow","zip":"101000"},"orders":[{"id":1},{"id":2}]}`},
+		{name: "JSONDel with a missing path", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.missing"}, want: 0, wantDoc: testJSONDocument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.JSONDel(tt.args.key, tt.args.path)
+			if err != nil || got != tt.want {
+				t.Errorf("JSONDel() got = %v, error = %v, want %v", got, err, tt.want)
+				return
+			}
+			if doc := jsonOf(t, r, tt.args.key); doc != tt.wantDoc {
+				t.Errorf("JSONDel() document = %v, want %v", doc, tt.wantDoc)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_JSONDelRoot(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument), "string": "value"})
+	if got, err := r.JSONDel("doc", "$"); got != 1 || err != nil {
+		t.Errorf("JSONDel() got = %v, error = %v, want 1", got, err)
+	}
+	if _, exists := r.load("doc"); exists {
+		t.Errorf("JSONDel() of the root kept the key")
+	}
+	if got, err := r.JSONDel("doc", "$"); got != 0 || err != nil {
+		t.Errorf("JSONDel() of a missing key got = %v, error = %v, want 0", got, err)
+	}
+}
+
+func TestInMemoryRedis_JSONType(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{
+		"doc": newTestJSONDocument(strings.TrimSuffix(testJSONDocument, "}") + `,"extra":[true,null,1.5]}`),
+	})
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "$", want: []string{"object"}},
+		{path: "$.*", want: []string{"string", "integer", "array", "object", "array", "array"}},
+		{path: "$.extra[*]", want: []string{"boolean", "null", "number"}},
+		{path: ".orders[0].price", want: []string{"number"}},
+		{path: "$.missing", want: []string{}},
+	}
+	for _, tt := range tests {
+		got, err := r.JSONType("doc", tt.path)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("JSONType(%v) got = %v, error = %v, want %v", tt.path, got, err, tt.want)
+		}
+	}
+
+	if got, err := r.JSONType("missing", "$"); got != nil || err != nil {
+		t.Errorf("JSONType() of a missing key got = %v, error = %v, want nil", got, err)
+	}
+}
+
+func TestInMemoryRedis_JSONNumIncrBy(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key       string
+		path      string
+		increment string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      []*string
+		wantPath  string
+		wantValue string
+		wantErr   error
+	}{
+		{name: "JSONNumIncrBy increments an integer", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.age", increment: "2"}, want: []*string{stringPtr("32")}, wantPath: "$.age", wantValue: "[32]"},
+		{name: "JSONNumIncrBy increments a float", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.orders[0].price", increment: "0.5"}, want: []*string{stringPtr("11.0")}, wantPath: "$..price", wantValue: "[11.0,3]"},
+		{name: "JSONNumIncrBy increments an integer by a float", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.age", increment: "-0.5"}, want: []*string{stringPtr("29.5")}, wantPath: "$.age", wantValue: "[29.5]"},
+		{name: "JSONNumIncrBy skips the values which aren't numbers", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.*", increment: "1"}, want: []*string{nil, stringPtr("31"), nil, nil, nil}, wantPath: "$.name", wantValue: `["Leonard"]`},
+		{name: "JSONNumIncrBy with a legacy path", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: ".orders[1].price", increment: "1"}, want: []*string{stringPtr("4")}, wantPath: "$.orders[1].price", wantValue: "[4]"},
+		{name: "JSONNumIncrBy with a legacy path to a string", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: ".name", increment: "1"}, wantErr: domain.ErrJSONNotNumber},
+		{name: "JSONNumIncrBy with a missing legacy path", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: ".missing", increment: "1"}, wantErr: domain.ErrJSONPathNotFound},
+		{name: "JSONNumIncrBy by a string", fields: fields{values: map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument)}}, args: args{key: "doc", path: "$.age", increment: `"1"`}, wantErr: domain.ErrJSONNotNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.JSONNumIncrBy(tt.args.key, tt.args.path, tt.args.increment)
+			if err != tt.wantErr {
+				t.Errorf("JSONNumIncrBy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSONNumIncrBy() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantPath != "" {
+				if value := jsonOf(t, r, tt.args.key, tt.wantPath); value != tt.wantValue {
+					t.Errorf("JSONNumIncrBy() %v = %v, want %v", tt.wantPath, value, tt.wantValue)
+				}
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestInMemoryRedis_JSONArrAppend(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument), "string": "value"})
+	got, err := r.JSONArrAppend("doc", "$[*]", []string{`"c"`, `{"id":3}`})
+	if err != nil || !reflect.DeepEqual(got, []*int{nil, nil, intPtr(4), nil, intPtr(4)}) {
+		t.Errorf("JSONArrAppend() got = %v, error = %v", got, err)
+	}
+	if value := jsonOf(t, r, "doc", ".tags"); value != `["a","b","c",{"id":3}]` {
+		t.Errorf("JSONArrAppend() tags = %v", value)
+	}
+
+	// the appended values are copies
+	if _, err := r.JSONSet("doc", "$.tags[3].id", "4", usecase.JSONSetOptions{}); err != nil {
+		t.Fatalf("JSONSet() error = %v", err)
+	}
+	if value := jsonOf(t, r, "doc", "$.orders[3].id"); value != `[3]` {
+		t.Errorf("JSONArrAppend() appended the same value to the arrays, orders[3].id = %v", value)
+	}
+
+	if _, err := r.JSONArrAppend("doc", ".name", []string{"1"}); err != domain.ErrJSONNotArray {
+		t.Errorf("JSONArrAppend() error = %v, want %v", err, domain.ErrJSONNotArray)
+	}
+	if _, err := r.JSONArrAppend("missing", "$", []string{"1"}); err != domain.ErrNoSuchKey {
+		t.Errorf("JSONArrAppend() error = %v, want %v", err, domain.ErrNoSuchKey)
+	}
+}
+
+func TestInMemoryRedis_JSONObjKeys(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"doc": newTestJSONDocument(testJSONDocument), "string": "value"})
+	tests := []struct {
+		path    string
+		want    [][]string
+		wantErr error
+	}{
+		{path: "$", want: [][]string{{"name", "age", "tags", "address", "orders"}}},
+		{path: "$.orders[*]", want: [][]string{{"id", "price"}, {"id", "price"}}},
+		{path: "$.name", want: [][]string{nil}},
+		{path: ".address", want: [][]string{{"city", "zip"}}},
+		{path: ".name", wantErr: domain.ErrJSONNotObject},
+	}
+	for _, tt := range tests {
+		got, err := r.JSONObjKeys("doc", tt.path)
+		if err != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("JSONObjKeys(%v) got = %v, error = %v, want %v, %v", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+)
+
+// loadList returns the list stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadList(key string) (*deque, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	list, ok := val.value.(*deque)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return list, nil
+}
+
+// listIndex converts a Redis index, negative indexes count from the tail, to an index of a list with the length
+func listIndex(index, length int) (int, bool) {
+	if index < 0 {
+		index += length
+	}
+	return index, index >= 0 && index < length
+}
+
+// normalizeRange converts the inclusive Redis range to the indexes of a sequence with the length,
+// the returned flag is false if the range is empty
+func normalizeRange(start, stop, length int) (int, int, bool) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	return start, stop, start <= stop
+}
+
+// push adds the values to the head or the tail of the list and serves the clients blocked on it,
+// with existing set the values are added only if the list exists. No values don't create an empty list.
+func (r *InMemoryRedis) push(key string, values []string, head bool, existing bool) (int, error) {
+	list, err := r.loadList(key)
+	if err != nil {
+		return -1, err
+	}
+
+	if list == nil {
+		if existing || len(values) == 0 {
+			return 0, nil
+		}
+		list = newDeque()
+		r.store.Store(key, storeValue{
+			value: list,
+		})
+	}
+
+	for _, value := range values {
+		if head {
+			list.pushFront(value)
+		} else {
+			list.pushBack(value)
+		}
+	}
+
+	size := list.len()
+	r.serveWaiters(key)
+	return size, nil
+}
+
+func (r *InMemoryRedis) RPush(key string, values []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.push(key, values, false, false)
+}
+
+// LPushX is LPush which doesn't create a list
+func (r *InMemoryRedis) LPushX(key string, values []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.push(key, values, true, true)
+}
+
+// RPushX is RPush which doesn't create a list
+func (r *InMemoryRedis) RPushX(key string, values []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.push(key, values, false, true)
+}
+
+// LPop removes and returns up to count elements from the head, nil if the key doesn't exist
+func (r *InMemoryRedis) LPop(key string, count int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pop(key, count, true)
+}
+
+// RPop removes and returns up to count elements from the tail, nil if the key doesn't exist
+func (r *InMemoryRedis) RPop(key string, count int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pop(key, count, false)
+}
+
+func (r *InMemoryRedis) pop(key string, count int, head bool) ([]string, error) {
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return nil, err
+	}
+
+	// the count comes from the client, it may be far beyond the length
+	size := count
+	if size > list.len() {
+		size = list.len()
+	}
+	result := make([]string, 0, size)
+	for len(result) < count && list.len() > 0 {
+		if head {
+			result = append(result, list.popFront())
+		} else {
+			result = append(result, list.popBack())
+		}
+	}
+
+	if list.len() == 0 {
+		r.del(key)
+	}
+	return result, nil
+}
+
+func (r *InMemoryRedis) LRange(key string, start int, stop int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return []string{}, err
+	}
+
+	start, stop, ok := normalizeRange(start, stop, list.len())
+	if !ok {
+		return []string{}, nil
+	}
+	return list.slice(start, stop), nil
+}
+
+func (r *InMemoryRedis) LLen(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return 0, err
+	}
+	return list.len(), nil
+}
+
+// LIndex is LGet which reports missing keys and out of range indexes with the flag instead of errors
+func (r *InMemoryRedis) LIndex(key string, index int) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return "", false, err
+	}
+
+	i, ok := listIndex(index, list.len())
+	if !ok {
+		return "", false, nil
+	}
+	return list.at(i), true, nil
+}
+
+// LInsert inserts the value before or after the first occurrence of pivot and returns the list length,
+// -1 if pivot isn't found and 0 if the key doesn't exist
+func (r *InMemoryRedis) LInsert(key string, before bool, pivot string, value string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return 0, err
+	}
+
+	for i := 0; i < list.len(); i++ {
+		if list.at(i) != pivot {
+			continue
+		}
+
+		if !before {
+			i++
+		}
+		list.insert(i, value)
+		return list.len(), nil
+	}
+	return -1, nil
+}
+
+// LRem removes count occurrences of the value from the head, from the tail if count is negative
+// or all of them if count is 0
+func (r *InMemoryRedis) LRem(key string, count int, value string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return 0, err
+	}
+
+	removed := make(map[int]bool)
+	if count >= 0 {
+		for i := 0; i < list.len() && (count == 0 || len(removed) < count); i++ {
+			if list.at(i) == value {
+				removed[i] = true
+			}
+		}
+	} else {
+		for i := list.len() - 1; i >= 0 && len(removed) < -count; i-- {
+			if list.at(i) == value {
+				removed[i] = true
+			}
+		}
+	}
+
+	list.removeIf(func(i int) bool {
+		return removed[i]
+	})
+
+	if list.len() == 0 {
+		r.del(key)
+	}
+	return len(removed), nil
+}
+
+// LTrim keeps only the elements from start to stop, the key is deleted if the range is empty
+func (r *InMemoryRedis) LTrim(key string, start int, stop int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return err
+	}
+
+	start, stop, ok := normalizeRange(start, stop, list.len())
+	if !ok {
+		r.del(key)
+		return nil
+	}
+
+	for list.len() > stop+1 {
+		list.popBack()
+	}
+	for i := 0; i < start; i++ {
+		list.popFront()
+	}
+	return nil
+}
+
+// LPos returns the indexes of the elements equal to the value
+func (r *InMemoryRedis) LPos(key string, element string, options usecase.LPosOptions) ([]int, error) {
+	if options.Rank == 0 {
+		return nil, domain.ErrRankIsZero
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]int, 0)
+	list, err := r.loadList(key)
+	if err != nil || list == nil {
+		return result, err
+	}
+
+	skip := options.Rank - 1
+	i, step := 0, 1
+	if options.Rank < 0 {
+		skip = -options.Rank - 1
+		i, step = list.len()-1, -1
+	}
+
+	for compared := 0; i >= 0 && i < list.len(); i, compared = i+step, compared+1 {
+		if options.MaxLen > 0 && compared == options.MaxLen {
+			break
+		}
+		if list.at(i) != element {
+			continue
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+		result = append(result, i)
+		if options.Count > 0 && len(result) == options.Count {
+			break
+		}
+	}
+	return result, nil
+}
+
+// LMove pops an element from one side of the source and pushes it to a side of the destination atomically,
+// the returned flag is false if the source doesn't exist
+func (r *InMemoryRedis) LMove(source string, destination string, from usecase.ListSide, to usecase.ListSide) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(source)
+	if err != nil || list == nil {
+		return "", false, err
+	}
+
+	// check the destination type before the source is modified
+	if _, err := r.loadList(destination); err != nil {
+		return "", false, err
+	}
+
+	values, _ := r.pop(source, 1, from == usecase.ListLeft)
+	if _, err := r.push(destination, values, to == usecase.ListLeft, false); err != nil {
+		return "", false, err
+	}
+	return values[0], true, nil
+}
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func listValues(t *testing.T, r *InMemoryRedis, key string) []string {
+	values, err := r.LRange(key, 0, -1)
+	if err != nil {
+		t.Fatalf("LRange() error = %v", err)
+	}
+	return values
+}
+
+func TestDeque_Grow(t *testing.T) {
+	d := newDeque()
+	want := make([]string, 0)
+	for i := 0; i < 50; i++ {
+		value := string(rune('a' + i%26))
+		if i%2 == 0 {
+			d.pushFront(value)
+			want = append([]string{value}, want...)
+		} else {
+			d.pushBack(value)
+			want = append(want, value)
+		}
+	}
+
+	if got := d.slice(0, d.len()-1); !reflect.DeepEqual(got, want) {
+		t.Errorf("deque = %v, want %v", got, want)
+	}
+}
+
+func TestInMemoryRedis_Push(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		push    func(r *InMemoryRedis) (int, error)
+		want    int
+		wantErr bool
+		wantVal []string
+	}{
+		{
+			name:    "LPush prepends the values one after another",
+			key:     "list",
+			push:    func(r *InMemoryRedis) (int, error) { return r.LPush("list", []string{"x", "y"}) },
+			want:    5,
+			wantVal: []string{"y", "x", "a", "b", "c"},
+		},
+		{
+			name:    "RPush appends the values",
+			key:     "list",
+			push:    func(r *InMemoryRedis) (int, error) { return r.RPush("list", []string{"x", "y"}) },
+			want:    5,
+			wantVal: []string{"a", "b", "c", "x", "y"},
+		},
+		{
+			name:    "LPushX when a key doesn't exist",
+			key:     "mykey",
+			push:    func(r *InMemoryRedis) (int, error) { return r.LPushX("mykey", []string{"x"}) },
+			want:    0,
+			wantVal: []string{},
+		},
+		{
+			name:    "RPushX when a key holding the wrong kind of value",
+			key:     "str",
+			push:    func(r *InMemoryRedis) (int, error) { return r.RPushX("str", []string{"x"}) },
+			want:    -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c"), "str": "some_string"})
+			got, err := tt.push(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("%s() got = %v, want %v", tt.name, got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			if values := listValues(t, r, tt.key); !reflect.DeepEqual(values, tt.wantVal) {
+				t.Errorf("%s() list = %v, want %v", tt.name, values, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_PushNoValues(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b")})
+
+	got, err := r.LPush("mykey", []string{})
+	if err != nil || got != 0 {
+		t.Errorf("LPush() got = %v, %v, want 0", got, err)
+	}
+	if _, exists := r.store.Load("mykey"); exists {
+		t.Errorf("LPush() created an empty list")
+	}
+
+	got, err = r.RPush("list", nil)
+	if err != nil || got != 2 {
+		t.Errorf("RPush() got = %v, %v, want 2", got, err)
+	}
+}
+
+func TestInMemoryRedis_Pop(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c")})
+
+	got, err := r.LPop("list", 2)
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("LPop() got = %v, %v, want [a b]", got, err)
+	}
+
+	got, err = r.RPop("list", 5)
+	if err != nil || !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("RPop() got = %v, %v, want [c]", got, err)
+	}
+
+	if _, exists := r.store.Load("list"); exists {
+		t.Errorf("RPop() didn't delete an empty list")
+	}
+
+	r = newTestRedis(map[string]interface{}{"list": newDeque("a", "b")})
+	got, err = r.LPop("list", math.MaxInt64)
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("LPop() with a huge count got = %v, %v, want [a b]", got, err)
+	}
+
+	got, err = r.LPop("list", 1)
+	if err != nil || got != nil {
+		t.Errorf("LPop() of a missing key got = %v, %v, want nil", got, err)
+	}
+}
+
+func TestInMemoryRedis_LRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int
+		stop  int
+		want  []string
+	}{
+		{name: "LRange of the whole list", start: 0, stop: -1, want: []string{"a", "b", "c", "d"}},
+		{name: "LRange with negative indexes", start: -3, stop: -2, want: []string{"b", "c"}},
+		{name: "LRange with a stop out of range", start: 2, stop: 100, want: []string{"c", "d"}},
+		{name: "LRange with start after stop", start: 3, stop: 1, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c", "d")})
+			got, err := r.LRange("list", tt.start, tt.stop)
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LRange() got = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_LIndexAndLSet(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c")})
+
+	if err := r.LSet("list", -1, "z"); err != nil {
+		t.Fatalf("LSet() error = %v", err)
+	}
+	if got, ok, err := r.LIndex("list", -1); err != nil || !ok || got != "z" {
+		t.Errorf("LIndex() got = %v, %v, %v, want z", got, ok, err)
+	}
+	if err := r.LSet("list", -4, "z"); err != domain.ErrIndexOutOfRange {
+		t.Errorf("LSet() error = %v, want %v", err, domain.ErrIndexOutOfRange)
+	}
+	if _, ok, err := r.LIndex("list", 3); err != nil || ok {
+		t.Errorf("LIndex() out of range got = %v, %v, want false", ok, err)
+	}
+}
+
+func TestInMemoryRedis_LInsert(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  bool
+		pivot   string
+		want    int
+		wantVal []string
+	}{
+		{name: "LInsert before the pivot", before: true, pivot: "b", want: 4, wantVal: []string{"a", "x", "b", "c"}},
+		{name: "LInsert after the last element", before: false, pivot: "c", want: 4, wantVal: []string{"a", "b", "c", "x"}},
+		{name: "LInsert when the pivot doesn't exist", before: true, pivot: "z", want: -1, wantVal: []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c")})
+			got, err := r.LInsert("list", tt.before, tt.pivot, "x")
+			if err != nil || got != tt.want {
+				t.Errorf("LInsert() got = %v, %v, want %v", got, err, tt.want)
+			}
+			if values := listValues(t, r, "list"); !reflect.DeepEqual(values, tt.wantVal) {
+				t.Errorf("LInsert() list = %v, want %v", values, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_LRem(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		want    int
+		wantVal []string
+	}{
+		{name: "LRem from the head", count: 2, want: 2, wantVal: []string{"b", "c", "a"}},
+		{name: "LRem from the tail", count: -1, want: 1, wantVal: []string{"a", "b", "a", "c"}},
+		{name: "LRem all the occurrences", count: 0, want: 3, wantVal: []string{"b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "a", "c", "a")})
+			got, err := r.LRem("list", tt.count, "a")
+			if err != nil || got != tt.want {
+				t.Errorf("LRem() got = %v, %v, want %v", got, err, tt.want)
+			}
+			if values := listValues(t, r, "list"); !reflect.DeepEqual(values, tt.wantVal) {
+				t.Errorf("LRem() list = %v, want %v", values, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_LTrim(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      int
+		stop       int
+		wantVal    []string
+		wantExists bool
+	}{
+		{name: "LTrim keeps the range", start: 1, stop: -2, wantVal: []string{"b", "c"}, wantExists: true},
+		{name: "LTrim with an empty range deletes the key", start: 3, stop: 1, wantVal: []string{}, wantExists: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "c", "d")})
+			if err := r.LTrim("list", tt.start, tt.stop); err != nil {
+				t.Fatalf("LTrim() error = %v", err)
+			}
+			if values := listValues(t, r, "list"); !reflect.DeepEqual(values, tt.wantVal) {
+				t.Errorf("LTrim() list = %v, want %v", values, tt.wantVal)
+			}
+			if _, exists := r.store.Load("list"); exists != tt.wantExists {
+				t.Errorf("LTrim() key exists = %v, want %v", exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_LPos(t *testing.T) {
+	tests := []struct {
+		name    string
+		options usecase.LPosOptions
+		want    []int
+		wantErr error
+	}{
+		{name: "LPos of the first match", options: usecase.LPosOptions{Rank: 1, Count: 1}, want: []int{0}},
+		{name: "LPos of all the matches", options: usecase.LPosOptions{Rank: 1}, want: []int{0, 2, 4}},
+		{name: "LPos from the second match", options: usecase.LPosOptions{Rank: 2, Count: 1}, want: []int{2}},
+		{name: "LPos from the tail", options: usecase.LPosOptions{Rank: -1, Count: 2}, want: []int{4, 2}},
+		{name: "LPos with MAXLEN", options: usecase.LPosOptions{Rank: 1, MaxLen: 3}, want: []int{0, 2}},
+		{name: "LPos with zero rank", options: usecase.LPosOptions{Rank: 0}, wantErr: domain.ErrRankIsZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"list": newDeque("a", "b", "a", "c", "a")})
+			got, err := r.LPos("list", "a", tt.options)
+			if err != tt.wantErr {
+				t.Errorf("LPos() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LPos() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_LMove(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{
+		"src": newDeque("a", "b"),
+		"str": "some_string",
+	})
+
+	if _, _, err := r.LMove("src", "str", usecase.ListLeft, usecase.ListLeft); err != domain.ErrWrongType {
+		t.Errorf("LMove() to a wrong type error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if values := listValues(t, r, "src"); !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("LMove() modified the source on error: %v", values)
+	}
+
+	got, ok, err := r.LMove("src", "dst", usecase.ListRight, usecase.ListLeft)
+	if err != nil || !ok || got != "b" {
+		t.Errorf("LMove() got = %v, %v, %v, want b", got, ok, err)
+	}
+	got, ok, err = r.LMove("src", "dst", usecase.ListLeft, usecase.ListRight)
+	if err != nil || !ok || got != "a" {
+		t.Errorf("LMove() got = %v, %v, %v, want a", got, ok, err)
+	}
+	if values := listValues(t, r, "dst"); !reflect.DeepEqual(values, []string{"b", "a"}) {
+		t.Errorf("LMove() destination = %v, want [b a]", values)
+	}
+
+	if _, ok, err := r.LMove("src", "dst", usecase.ListLeft, usecase.ListLeft); err != nil || ok {
+		t.Errorf("LMove() from an empty source got = %v, %v, want false", ok, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/babon21/redis-impl/internal/app/server/domain"
 	"github.com/babon21/redis-impl/internal/app/server/usecase"
 	"regexp"
+	"sort"
 	"sync"
 	"time"
 )
@@ -11,6 +12,13 @@ import (
 type InMemoryRedis struct {
 	store    sync.Map
 	interval int
+	// mu serializes the commands, so read-modify-write of stored values is atomic.
+	// Keys and ScanKeys only iterate over the key space and don't need it.
+	mu sync.Mutex
+	// waiters are the clients blocked by BLPOP, BRPOP and BLMOVE in FIFO order per key, guarded by mu
+	waiters map[string][]*listWaiter
+	// streamWaiters are the clients blocked by XREAD and XREADGROUP, guarded by mu
+	streamWaiters map[string][]*streamWaiter
 }
 
 func NewInMemoryRedisStore() usecase.RedisStore {
@@ -24,24 +32,43 @@ type storeValue struct {
 	expiry time.Time
 }
 
-func (r *InMemoryRedis) Set(key string, value string) {
+// Set stores the string, a key holding another type is overwritten unless the old value is requested
+func (r *InMemoryRedis) Set(key string, value string, options usecase.SetOptions) (usecase.SetResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result usecase.SetResult
+	val, exists := r.load(key)
+	if options.Get && exists {
+		old, ok := stringOf(val.value)
+		if !ok {
+			return result, domain.ErrWrongType
+		}
+		result.Old, result.OldExists = old, true
+	}
+
+	if (options.NX && exists) || (options.XX && !exists) {
+		return result, nil
+	}
+
+	expiry := options.Expiry
+	if options.KeepTTL {
+		expiry = val.expiry
+	}
+
 	r.store.Store(key, storeValue{
-		value: value,
+		value:  value,
+		expiry: expiry,
 	})
+	result.Written = true
+	return result, nil
 }
 
 func (r *InMemoryRedis) Get(key string) (string, bool, error) {
-	value, exists := r.load(key)
-	if !exists {
-		return "", false, nil
-	}
-
-	strValue, ok := value.value.(string)
-	if !ok {
-		return "", false, domain.ErrWrongType
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	return strValue, true, nil
+	return r.loadString(key)
 }
 
 func (r *InMemoryRedis) load(key string) (storeValue, bool) {
@@ -59,6 +86,13 @@ func (r *InMemoryRedis) load(key string) (storeValue, bool) {
 }
 
 func (r *InMemoryRedis) Del(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.del(key)
+}
+
+func (r *InMemoryRedis) del(key string) bool {
 	if _, found := r.store.LoadAndDelete(key); found {
 		return true
 	}
@@ -66,139 +100,118 @@ func (r *InMemoryRedis) Del(key string) bool {
 }
 
 func (r *InMemoryRedis) Keys(pattern string) ([]string, error) {
-	reg, err := regexp.Compile(pattern)
+	result := make([]string, 0, 10)
+	err := r.ScanKeys(pattern, func(key string) bool {
+		result = append(result, key)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]string, 0, 10)
+	// the iteration order of sync.Map is random, sorting makes the result stable
+	sort.Strings(result)
+	return result, nil
+}
+
+// ScanKeys calls callback for each key matching the pattern without collecting them,
+// the iteration stops when callback returns false
+func (r *InMemoryRedis) ScanKeys(pattern string, callback func(key string) bool) error {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
 	r.store.Range(func(key, value interface{}) bool {
 		keyString := key.(string)
 		if reg.MatchString(keyString) {
-			result = append(result, keyString)
+			return callback(keyString)
 		}
 		return true
 	})
-	return result, nil
+	return nil
 }
 
 func (r *InMemoryRedis) startPeriodicDeleteExpirationKey() {
 	ticker := time.NewTicker(time.Second * time.Duration(r.interval))
 	for range ticker.C {
 		r.store.Range(func(key, val interface{}) bool {
-			keyString := key.(string)
-			value := val.(storeValue)
-			r.tryDeleteKeyIfExpire(keyString, value)
-
+			r.deleteExpired(key.(string))
 			return true
 		})
 	}
 }
 
-func (r *InMemoryRedis) HGet(key string, field string) (string, bool, error) {
-	value, exists := r.load(key)
-	if !exists {
-		return "", false, nil
-	}
+// deleteExpired deletes the key if it has expired and drops the samples of a time series older than its retention
+func (r *InMemoryRedis) deleteExpired(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	storedMap, ok := value.value.(map[string]string)
-	if !ok {
-		return "", false, domain.ErrWrongType
-	}
-
-	v, ok := storedMap[field]
-	if !ok {
-		return "", false, nil
-	}
-
-	return v, true, nil
-}
-
-func (r *InMemoryRedis) HSet(key string, field string, value string) error {
+	// the value is loaded again under the lock as it may be overwritten since Range got it
 	val, exists := r.load(key)
 	if !exists {
-		newMap := make(map[string]string)
-		r.store.Store(key, storeValue{
-			value: newMap,
-		})
-
-		newMap[field] = value
-		return nil
+		return
 	}
 
-	storedMap, ok := val.value.(map[string]string)
-	if !ok {
-		return domain.ErrWrongType
+	if s, ok := val.value.(*timeSeries); ok {
+		s.trim()
 	}
-
-	storedMap[field] = value
-	return nil
 }
 
 func (r *InMemoryRedis) LGet(key string, index int) (string, error) {
-	val, exists := r.load(key)
-	if !exists {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil {
+		return "", err
+	}
+	if list == nil {
 		return "", domain.ErrNoSuchKey
 	}
 
-	list, ok := val.value.([]string)
+	i, ok := listIndex(index, list.len())
 	if !ok {
-		return "", domain.ErrWrongType
-	}
-
-	arrLength := len(list)
-	if index < 0 || index >= arrLength {
 		return "", domain.ErrIndexOutOfRange
 	}
 
-	return list[index], nil
+	return list.at(i), nil
 }
 
+// LSet replaces the element at index, negative indexes count from the tail
 func (r *InMemoryRedis) LSet(key string, index int, value string) error {
-	val, exists := r.load(key)
-	if !exists {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, err := r.loadList(key)
+	if err != nil {
+		return err
+	}
+	if list == nil {
 		return domain.ErrNoSuchKey
 	}
 
-	list, ok := val.value.([]string)
+	i, ok := listIndex(index, list.len())
 	if !ok {
-		return domain.ErrWrongType
-	}
-
-	arrLength := len(list)
-	if index < 0 || index >= arrLength {
 		return domain.ErrIndexOutOfRange
 	}
 
-	list[index] = value
+	list.set(i, value)
 	return nil
 }
 
-func (r *InMemoryRedis) LPush(key string, value string) (int, error) {
-	val, exists := r.load(key)
-	if !exists {
-		newArr := make([]string, 0, 5)
-		newArr = append(newArr, value)
-		r.store.Store(key, storeValue{
-			value: newArr,
-		})
-
-		return len(newArr), nil
-	}
+// LPush inserts the values at the head one after another, so the last value becomes the first element
+func (r *InMemoryRedis) LPush(key string, values []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	arr, ok := val.value.([]string)
-	if !ok {
-		return -1, domain.ErrWrongType
-	}
-
-	arr = append(arr, value)
-	r.store.Store(key, storeValue{
-		value: arr,
-	})
-	return len(arr), nil
+	return r.push(key, values, true, false)
 }
 
 func (r *InMemoryRedis) Expire(key string, duration int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	val, exists := r.load(key)
 	if !exists {
 		return false
@@ -223,7 +236,7 @@ func (r *InMemoryRedis) checkKeyExpiration(val storeValue) bool {
 
 func (r *InMemoryRedis) tryDeleteKeyIfExpire(key string, val storeValue) bool {
 	if r.checkKeyExpiration(val) {
-		r.Del(key)
+		r.del(key)
 		return true
 	}
 	return false
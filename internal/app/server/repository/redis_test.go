@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
 	"reflect"
 	"sync"
 	"testing"
@@ -184,7 +185,7 @@ func TestInMemoryRedis_HGet(t *testing.T) {
 			name: "HGet when key and field exists",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newMap := make(map[string]string)
+				newMap := make(hash)
 				store.Store("mykey", storeValue{
 					value: newMap,
 				})
@@ -224,14 +225,13 @@ func TestInMemoryRedis_HSet(t *testing.T) {
 	}
 	type args struct {
 		key   string
-		field string
-		value string
+		pairs []usecase.FieldValue
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    bool
+		want    int
 		wantErr bool
 	}{
 		{
@@ -244,22 +244,22 @@ func TestInMemoryRedis_HSet(t *testing.T) {
 
 				return store
 			}()},
-			args:    args{key: "mykey", field: "some_field"},
+			args:    args{key: "mykey", pairs: []usecase.FieldValue{{Field: "some_field"}}},
 			wantErr: true,
 		},
 		{
 			name: "HSet success",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newMap := make(map[string]string)
+				newMap := make(hash)
 				store.Store("mykey", storeValue{
 					value: newMap,
 				})
 
 				return store
 			}()},
-			args:    args{key: "mykey", field: "some_field", value: "value"},
-			want:    true,
+			args:    args{key: "mykey", pairs: []usecase.FieldValue{{Field: "some_field", Value: "value"}}},
+			want:    1,
 			wantErr: false,
 		},
 	}
@@ -268,11 +268,14 @@ func TestInMemoryRedis_HSet(t *testing.T) {
 			r := &InMemoryRedis{
 				store: tt.fields.store,
 			}
-			err := r.HSet(tt.args.key, tt.args.field, tt.args.value)
+			got, err := r.HSet(tt.args.key, tt.args.pairs)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("HSet() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("HSet() got = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -370,9 +373,8 @@ func TestInMemoryRedis_LGet(t *testing.T) {
 			name: "LGet when index out of range",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newArr := make([]string, 0, 5)
 				store.Store("mykey", storeValue{
-					value: newArr,
+					value: newDeque("value"),
 				})
 
 				return store
@@ -385,10 +387,8 @@ func TestInMemoryRedis_LGet(t *testing.T) {
 			name: "LGet success",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newArr := make([]string, 0, 5)
-				newArr = append(newArr, "value")
 				store.Store("mykey", storeValue{
-					value: newArr,
+					value: newDeque("value"),
 				})
 
 				return store
@@ -420,8 +420,8 @@ func TestInMemoryRedis_LPush(t *testing.T) {
 		store sync.Map
 	}
 	type args struct {
-		key   string
-		value string
+		key    string
+		values []string
 	}
 	tests := []struct {
 		name    string
@@ -440,7 +440,7 @@ func TestInMemoryRedis_LPush(t *testing.T) {
 
 				return store
 			}()},
-			args:    args{key: "mykey", value: "value"},
+			args:    args{key: "mykey", values: []string{"value"}},
 			want:    -1,
 			wantErr: true,
 		},
@@ -448,15 +448,14 @@ func TestInMemoryRedis_LPush(t *testing.T) {
 			name: "LPush success",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newArr := make([]string, 0, 5)
 				store.Store("mykey", storeValue{
-					value: newArr,
+					value: newDeque("value"),
 				})
 
 				return store
 			}()},
-			args:    args{key: "mykey", value: "value"},
-			want:    1,
+			args:    args{key: "mykey", values: []string{"value1", "value2"}},
+			want:    3,
 			wantErr: false,
 		},
 	}
@@ -465,7 +464,7 @@ func TestInMemoryRedis_LPush(t *testing.T) {
 			r := &InMemoryRedis{
 				store: tt.fields.store,
 			}
-			got, err := r.LPush(tt.args.key, tt.args.value)
+			got, err := r.LPush(tt.args.key, tt.args.values)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LPush() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -515,9 +514,8 @@ func TestInMemoryRedis_LSet(t *testing.T) {
 			name: "LSet when index out of range",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newArr := make([]string, 0, 5)
 				store.Store("mykey", storeValue{
-					value: newArr,
+					value: newDeque("value"),
 				})
 
 				return store
@@ -529,10 +527,8 @@ func TestInMemoryRedis_LSet(t *testing.T) {
 			name: "LSet success",
 			fields: fields{store: func() sync.Map {
 				var store sync.Map
-				newArr := make([]string, 0, 5)
-				newArr = append(newArr, "value")
 				store.Store("mykey", storeValue{
-					value: newArr,
+					value: newDeque("value"),
 				})
 
 				return store
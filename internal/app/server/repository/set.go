@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"math/rand"
+	"sort"
+)
+
+type stringSet map[string]struct{}
+
+// maxInt is the largest int, the math constant appeared only in Go 1.17
+const maxInt = int(^uint(0) >> 1)
+
+// maxRandomMembers bounds the reply of SRANDMEMBER with a negative count, which is built under the lock
+const maxRandomMembers = 1 << 20
+
+func (s stringSet) members() []string {
+	result := make([]string, 0, len(s))
+	for member := range s {
+		result = append(result, member)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// loadSet returns the set stored at key, a nil set if the key doesn't exist
+func (r *InMemoryRedis) loadSet(key string) (stringSet, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	set, ok := val.value.(stringSet)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return set, nil
+}
+
+// SAdd adds the members to the set, no members don't create an empty set
+func (r *InMemoryRedis) SAdd(key string, members []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil {
+		return -1, err
+	}
+
+	if set == nil {
+		if len(members) == 0 {
+			return 0, nil
+		}
+		set = make(stringSet, len(members))
+		r.store.Store(key, storeValue{
+			value: set,
+		})
+	}
+
+	added := 0
+	for _, member := range members {
+		if _, ok := set[member]; !ok {
+			set[member] = struct{}{}
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (r *InMemoryRedis) SRem(key string, members []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil || set == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, member := range members {
+		if _, ok := set[member]; ok {
+			delete(set, member)
+			removed++
+		}
+	}
+
+	if len(set) == 0 {
+		r.del(key)
+	}
+	return removed, nil
+}
+
+func (r *InMemoryRedis) SMembers(key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil {
+		return nil, err
+	}
+	return set.members(), nil
+}
+
+func (r *InMemoryRedis) SIsMember(key string, member string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := set[member]
+	return ok, nil
+}
+
+func (r *InMemoryRedis) SCard(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil {
+		return 0, err
+	}
+	return len(set), nil
+}
+
+// SPop removes and returns up to count random members
+func (r *InMemoryRedis) SPop(key string, count int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the count comes from the client, it may be far beyond the cardinality
+	if count > len(set) {
+		count = len(set)
+	}
+	result := make([]string, 0, count)
+	// the iteration order of a map is random
+	for member := range set {
+		if len(result) == count {
+			break
+		}
+		result = append(result, member)
+		delete(set, member)
+	}
+
+	if set != nil && len(set) == 0 {
+		r.del(key)
+	}
+	return result, nil
+}
+
+// SRandMember returns count distinct random members if count is positive,
+// otherwise -count members which may repeat, at most maxRandomMembers of them.
+func (r *InMemoryRedis) SRandMember(key string, count int) ([]string, error) {
+	if count < -maxRandomMembers {
+		return nil, domain.ErrValueOutOfRange
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.loadSet(key)
+	if err != nil || len(set) == 0 {
+		return []string{}, err
+	}
+
+	members := set.members()
+	if count < 0 {
+		result := make([]string, 0, -count)
+		for i := count; i < 0; i++ {
+			result = append(result, members[rand.Intn(len(members))])
+		}
+		return result, nil
+	}
+
+	rand.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+	if count < len(members) {
+		members = members[:count]
+	}
+	return members, nil
+}
+
+func (r *InMemoryRedis) SInter(keys []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.combineSets(keys, intersect)
+	if err != nil {
+		return nil, err
+	}
+	return set.members(), nil
+}
+
+func (r *InMemoryRedis) SUnion(keys []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.combineSets(keys, union)
+	if err != nil {
+		return nil, err
+	}
+	return set.members(), nil
+}
+
+func (r *InMemoryRedis) SDiff(keys []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.combineSets(keys, difference)
+	if err != nil {
+		return nil, err
+	}
+	return set.members(), nil
+}
+
+func (r *InMemoryRedis) SInterStore(destination string, keys []string) (int, error) {
+	return r.combineSetsAndStore(destination, keys, intersect)
+}
+
+func (r *InMemoryRedis) SUnionStore(destination string, keys []string) (int, error) {
+	return r.combineSetsAndStore(destination, keys, union)
+}
+
+func (r *InMemoryRedis) SDiffStore(destination string, keys []string) (int, error) {
+	return r.combineSetsAndStore(destination, keys, difference)
+}
+
+type setOperation int
+
+const (
+	intersect setOperation = iota
+	union
+	difference
+)
+
+// combineSets applies the operation to the sets from left to right, missing keys are empty sets
+func (r *InMemoryRedis) combineSets(keys []string, operation setOperation) (stringSet, error) {
+	sets := make([]stringSet, 0, len(keys))
+	for _, key := range keys {
+		set, err := r.loadSet(key)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+
+	result := make(stringSet)
+	if len(sets) == 0 {
+		return result, nil
+	}
+
+	for member := range sets[0] {
+		result[member] = struct{}{}
+	}
+
+	for _, set := range sets[1:] {
+		switch operation {
+		case intersect:
+			for member := range result {
+				if _, ok := set[member]; !ok {
+					delete(result, member)
+				}
+			}
+		case union:
+			for member := range set {
+				result[member] = struct{}{}
+			}
+		case difference:
+			for member := range set {
+				delete(result, member)
+			}
+		}
+	}
+	return result, nil
+}
+
+// combineSetsAndStore overwrites destination with the result, an empty result deletes destination
+func (r *InMemoryRedis) combineSetsAndStore(destination string, keys []string, operation setOperation) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, err := r.combineSets(keys, operation)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(set) == 0 {
+		r.del(destination)
+		return 0, nil
+	}
+
+	r.store.Store(destination, storeValue{
+		value: set,
+	})
+	return len(set), nil
+}
@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestSet(members ...string) stringSet {
+	set := make(stringSet)
+	for _, member := range members {
+		set[member] = struct{}{}
+	}
+	return set
+}
+
+func TestInMemoryRedis_SAdd(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		members []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "SAdd when a key doesn't exist",
+			fields:  fields{},
+			args:    args{key: "mykey", members: []string{"a", "b", "a"}},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "SAdd without members when a key doesn't exist",
+			fields:  fields{},
+			args:    args{key: "mykey", members: []string{}},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "SAdd counts only new members",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:    args{key: "mykey", members: []string{"a", "b"}},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "SAdd when a key holding the wrong kind of value",
+			fields:  fields{values: map[string]interface{}{"mykey": "some_string"}},
+			args:    args{key: "mykey", members: []string{"a"}},
+			want:    -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.SAdd(tt.args.key, tt.args.members)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SAdd() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SAdd() got = %v, want %v", got, tt.want)
+			}
+			if _, exists := r.store.Load(tt.args.key); exists != (len(tt.args.members) > 0) {
+				t.Errorf("SAdd() key exists = %v, want %v", exists, len(tt.args.members) > 0)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SRem(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		members []string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		want       int
+		wantExists bool
+		wantErr    bool
+	}{
+		{
+			name:       "SRem some members",
+			fields:     fields{values: map[string]interface{}{"mykey": newTestSet("a", "b")}},
+			args:       args{key: "mykey", members: []string{"a", "c"}},
+			want:       1,
+			wantExists: true,
+			wantErr:    false,
+		},
+		{
+			name:       "SRem deletes an empty set",
+			fields:     fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:       args{key: "mykey", members: []string{"a"}},
+			want:       1,
+			wantExists: false,
+			wantErr:    false,
+		},
+		{
+			name:    "SRem when a key holding the wrong kind of value",
+			fields:  fields{values: map[string]interface{}{"mykey": []string{"a"}}},
+			args:    args{key: "mykey", members: []string{"a"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.SRem(tt.args.key, tt.args.members)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SRem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SRem() got = %v, want %v", got, tt.want)
+			}
+			if _, exists := r.store.Load(tt.args.key); exists != tt.wantExists {
+				t.Errorf("SRem() key exists = %v, want %v", exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SIsMember(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key    string
+		member string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "SIsMember when a member exists",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:    args{key: "mykey", member: "a"},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "SIsMember when a key doesn't exist",
+			fields:  fields{},
+			args:    args{key: "mykey", member: "a"},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "SIsMember when a key holding the wrong kind of value",
+			fields:  fields{values: map[string]interface{}{"mykey": "some_string"}},
+			args:    args{key: "mykey", member: "a"},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.SIsMember(tt.args.key, tt.args.member)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SIsMember() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SIsMember() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SPop(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key   string
+		count int
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantLen  int
+		wantCard int
+		wantErr  bool
+	}{
+		{
+			name:     "SPop less than the set size",
+			fields:   fields{values: map[string]interface{}{"mykey": newTestSet("a", "b", "c")}},
+			args:     args{key: "mykey", count: 2},
+			wantLen:  2,
+			wantCard: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "SPop more than the set size",
+			fields:   fields{values: map[string]interface{}{"mykey": newTestSet("a", "b")}},
+			args:     args{key: "mykey", count: 5},
+			wantLen:  2,
+			wantCard: 0,
+			wantErr:  false,
+		},
+		{
+			name:    "SPop when a key holding the wrong kind of value",
+			fields:  fields{values: map[string]interface{}{"mykey": "some_string"}},
+			args:    args{key: "mykey", count: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.SPop(tt.args.key, tt.args.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SPop() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("SPop() got = %v, want %v members", got, tt.wantLen)
+			}
+			if card, _ := r.SCard(tt.args.key); card != tt.wantCard {
+				t.Errorf("SPop() cardinality = %v, want %v", card, tt.wantCard)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SRandMember(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key   string
+		count int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "SRandMember with a positive count returns distinct members",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a", "b", "c")}},
+			args:    args{key: "mykey", count: 5},
+			wantLen: 3,
+		},
+		{
+			name:    "SRandMember with a negative count may repeat members",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:    args{key: "mykey", count: -3},
+			wantLen: 3,
+		},
+		{
+			name:    "SRandMember with a negative count out of range",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:    args{key: "mykey", count: -maxInt - 1},
+			wantErr: true,
+		},
+		{
+			name:    "SRandMember with a negative count over the reply limit",
+			fields:  fields{values: map[string]interface{}{"mykey": newTestSet("a")}},
+			args:    args{key: "mykey", count: -maxRandomMembers - 1},
+			wantErr: true,
+		},
+		{
+			name:    "SRandMember when a key doesn't exist",
+			fields:  fields{},
+			args:    args{key: "mykey", count: 3},
+			wantLen: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.SRandMember(tt.args.key, tt.args.count)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SRandMember() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("SRandMember() got = %v, want %v members", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SetAlgebra(t *testing.T) {
+	values := map[string]interface{}{
+		"s1":  newTestSet("a", "b", "c"),
+		"s2":  newTestSet("b", "c", "d"),
+		"str": "some_string",
+	}
+	tests := []struct {
+		name    string
+		call    func(r *InMemoryRedis) ([]string, error)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "SInter",
+			call:    func(r *InMemoryRedis) ([]string, error) { return r.SInter([]string{"s1", "s2"}) },
+			want:    []string{"b", "c"},
+			wantErr: false,
+		},
+		{
+			name:    "SInter with a missing key",
+			call:    func(r *InMemoryRedis) ([]string, error) { return r.SInter([]string{"s1", "missing"}) },
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "SUnion",
+			call:    func(r *InMemoryRedis) ([]string, error) { return r.SUnion([]string{"s1", "s2", "missing"}) },
+			want:    []string{"a", "b", "c", "d"},
+			wantErr: false,
+		},
+		{
+			name:    "SDiff",
+			call:    func(r *InMemoryRedis) ([]string, error) { return r.SDiff([]string{"s1", "s2"}) },
+			want:    []string{"a"},
+			wantErr: false,
+		},
+		{
+			name:    "SUnion with a key holding the wrong kind of value",
+			call:    func(r *InMemoryRedis) ([]string, error) { return r.SUnion([]string{"s1", "str"}) },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(values)
+			got, err := tt.call(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SInterStore(t *testing.T) {
+	type args struct {
+		destination string
+		keys        []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        int
+		wantMembers []string
+	}{
+		{
+			name:        "SInterStore overwrites the destination",
+			args:        args{destination: "str", keys: []string{"s1", "s2"}},
+			want:        2,
+			wantMembers: []string{"b", "c"},
+		},
+		{
+			name:        "SInterStore deletes the destination for an empty result",
+			args:        args{destination: "s1", keys: []string{"s1", "missing"}},
+			want:        0,
+			wantMembers: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{
+				"s1":  newTestSet("a", "b", "c"),
+				"s2":  newTestSet("b", "c", "d"),
+				"str": "some_string",
+			})
+			got, err := r.SInterStore(tt.args.destination, tt.args.keys)
+			if err != nil {
+				t.Errorf("SInterStore() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SInterStore() got = %v, want %v", got, tt.want)
+			}
+			if members, _ := r.SMembers(tt.args.destination); !reflect.DeepEqual(members, tt.wantMembers) {
+				t.Errorf("SInterStore() destination = %v, want %v", members, tt.wantMembers)
+			}
+		})
+	}
+}
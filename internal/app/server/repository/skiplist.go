@@ -0,0 +1,201 @@
+package repository
+
+import "math/rand"
+
+const (
+	skiplistMaxLevel = 32
+	// skiplistP is the probability of a node to have one more level
+	skiplistP = 0.25
+)
+
+// skiplist keeps the members of a sorted set ordered by score and then by member.
+// Every level stores the span, i.e. the number of nodes it skips, so ranks are found in O(log N).
+type skiplist struct {
+	header *skiplistNode
+	tail   *skiplistNode
+	length int
+	level  int
+}
+
+type skiplistNode struct {
+	member   string
+	score    float64
+	backward *skiplistNode
+	level    []skiplistLevel
+}
+
+type skiplistLevel struct {
+	forward *skiplistNode
+	span    int
+}
+
+func newSkiplist() *skiplist {
+	return &skiplist{
+		header: &skiplistNode{level: make([]skiplistLevel, skiplistMaxLevel)},
+		level:  1,
+	}
+}
+
+// before reports whether the node is ordered before the member with the score
+func (n *skiplistNode) before(score float64, member string) bool {
+	return n.score < score || (n.score == score && n.member < member)
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skiplistMaxLevel && rand.Float64() < skiplistP {
+		level++
+	}
+	return level
+}
+
+// insert adds the member, the caller makes sure it isn't in the list yet
+func (sl *skiplist) insert(score float64, member string) {
+	var update [skiplistMaxLevel]*skiplistNode
+	var rank [skiplistMaxLevel]int
+
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.level[i].forward != nil && x.level[i].forward.before(score, member) {
+			rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > sl.level {
+		for i := sl.level; i < level; i++ {
+			update[i] = sl.header
+			update[i].level[i].span = sl.length
+		}
+		sl.level = level
+	}
+
+	x = &skiplistNode{
+		member: member,
+		score:  score,
+		level:  make([]skiplistLevel, level),
+	}
+	for i := 0; i < level; i++ {
+		x.level[i].forward = update[i].level[i].forward
+		update[i].level[i].forward = x
+
+		x.level[i].span = update[i].level[i].span - (rank[0] - rank[i])
+		update[i].level[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < sl.level; i++ {
+		update[i].level[i].span++
+	}
+
+	if update[0] != sl.header {
+		x.backward = update[0]
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x
+	} else {
+		sl.tail = x
+	}
+	sl.length++
+}
+
+// delete removes the member with the score and reports whether it was found
+func (sl *skiplist) delete(score float64, member string) bool {
+	var update [skiplistMaxLevel]*skiplistNode
+
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && x.level[i].forward.before(score, member) {
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	x = x.level[0].forward
+	if x == nil || x.score != score || x.member != member {
+		return false
+	}
+
+	for i := 0; i < sl.level; i++ {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		sl.tail = x.backward
+	}
+	for sl.level > 1 && sl.header.level[sl.level-1].forward == nil {
+		sl.level--
+	}
+	sl.length--
+	return true
+}
+
+// rank returns the 1-based rank of the member with the score or 0 if it isn't in the list
+func (sl *skiplist) rank(score float64, member string) int {
+	rank := 0
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !(score < x.level[i].forward.score ||
+			(score == x.level[i].forward.score && member < x.level[i].forward.member)) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+		if x != sl.header && x.score == score && x.member == member {
+			return rank
+		}
+	}
+	return 0
+}
+
+// byRank returns the node with the 1-based rank or nil if it's out of range
+func (sl *skiplist) byRank(rank int) *skiplistNode {
+	traversed := 0
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span <= rank {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		if traversed == rank && x != sl.header {
+			return x
+		}
+	}
+	return nil
+}
+
+// first returns the first node matching the predicate, which must be false for
+// the leading nodes of the list and true for the rest of them
+func (sl *skiplist) first(matches func(n *skiplistNode) bool) *skiplistNode {
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !matches(x.level[i].forward) {
+			x = x.level[i].forward
+		}
+	}
+	return x.level[0].forward
+}
+
+// last returns the last node matching the predicate, which must be true for
+// the leading nodes of the list and false for the rest of them
+func (sl *skiplist) last(matches func(n *skiplistNode) bool) *skiplistNode {
+	x := sl.header
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && matches(x.level[i].forward) {
+			x = x.level[i].forward
+		}
+	}
+	if x == sl.header {
+		return nil
+	}
+	return x
+}
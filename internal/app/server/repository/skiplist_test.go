@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSkiplist_InsertDeleteRank(t *testing.T) {
+	sl := newSkiplist()
+	scores := make(map[string]float64)
+	for i := 0; i < 1000; i++ {
+		member := "member" + strconv.Itoa(rand.Intn(300))
+		if score, ok := scores[member]; ok {
+			sl.delete(score, member)
+			delete(scores, member)
+			continue
+		}
+		score := float64(rand.Intn(50))
+		sl.insert(score, member)
+		scores[member] = score
+	}
+
+	want := make([]string, 0, len(scores))
+	for member := range scores {
+		want = append(want, member)
+	}
+	sort.Slice(want, func(i, j int) bool {
+		a, b := want[i], want[j]
+		return scores[a] < scores[b] || (scores[a] == scores[b] && a < b)
+	})
+
+	if sl.length != len(want) {
+		t.Fatalf("length = %v, want %v", sl.length, len(want))
+	}
+	for i, member := range want {
+		if rank := sl.rank(scores[member], member); rank != i+1 {
+			t.Errorf("rank(%v) = %v, want %v", member, rank, i+1)
+		}
+		if node := sl.byRank(i + 1); node == nil || node.member != member {
+			t.Errorf("byRank(%v) = %v, want %v", i+1, node, member)
+		}
+	}
+
+	node := sl.tail
+	for i := len(want) - 1; i >= 0; i-- {
+		if node == nil || node.member != want[i] {
+			t.Fatalf("backward link at %v = %v, want %v", i, node, want[i])
+		}
+		node = node.backward
+	}
+	if node != nil {
+		t.Errorf("backward link of the first node = %v, want nil", node)
+	}
+}
@@ -0,0 +1,635 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// sortedSet keeps the members ordered in the skiplist and their scores in the map for O(1) lookups
+type sortedSet struct {
+	scores map[string]float64
+	list   *skiplist
+}
+
+func newSortedSet() *sortedSet {
+	return &sortedSet{
+		scores: make(map[string]float64),
+		list:   newSkiplist(),
+	}
+}
+
+// add inserts the member or updates its score
+func (z *sortedSet) add(member string, score float64) {
+	if current, ok := z.scores[member]; ok {
+		if current == score {
+			return
+		}
+		z.list.delete(current, member)
+	}
+	z.scores[member] = score
+	z.list.insert(score, member)
+}
+
+func (z *sortedSet) remove(member string) bool {
+	score, ok := z.scores[member]
+	if !ok {
+		return false
+	}
+	delete(z.scores, member)
+	z.list.delete(score, member)
+	return true
+}
+
+// between returns the members from the lower to the upper bound or vice versa with rev
+func (z *sortedSet) between(aboveMin, belowMax func(n *skiplistNode) bool, rev bool, limit *usecase.ZRangeLimit) []usecase.ScoredMember {
+	offset, count := 0, -1
+	if limit != nil {
+		offset, count = limit.Offset, limit.Count
+	}
+
+	result := make([]usecase.ScoredMember, 0)
+	if offset < 0 {
+		return result
+	}
+
+	var node *skiplistNode
+	if rev {
+		node = z.list.last(belowMax)
+	} else {
+		node = z.list.first(aboveMin)
+	}
+
+	for node != nil && count != 0 {
+		if (rev && !aboveMin(node)) || (!rev && !belowMax(node)) {
+			break
+		}
+
+		if offset > 0 {
+			offset--
+		} else {
+			result = append(result, usecase.ScoredMember{Member: node.member, Score: node.score})
+			count--
+		}
+
+		if rev {
+			node = node.backward
+		} else {
+			node = node.level[0].forward
+		}
+	}
+	return result
+}
+
+type scoreBound struct {
+	value     float64
+	exclusive bool
+}
+
+// parseScoreBound parses a score optionally prefixed with "(" to exclude it from the range
+func parseScoreBound(bound string) (scoreBound, error) {
+	var result scoreBound
+	if strings.HasPrefix(bound, "(") {
+		result.exclusive = true
+		bound = bound[1:]
+	}
+
+	value, err := strconv.ParseFloat(bound, 64)
+	if err != nil || math.IsNaN(value) {
+		return scoreBound{}, domain.ErrMinMaxNotFloat
+	}
+	result.value = value
+	return result, nil
+}
+
+func (b scoreBound) isMin(n *skiplistNode) bool {
+	if b.exclusive {
+		return n.score > b.value
+	}
+	return n.score >= b.value
+}
+
+func (b scoreBound) isMax(n *skiplistNode) bool {
+	if b.exclusive {
+		return n.score < b.value
+	}
+	return n.score <= b.value
+}
+
+type lexBound struct {
+	value     string
+	exclusive bool
+	// infinity is -1 for "-" which is less than any member and 1 for "+" which is greater
+	infinity int
+}
+
+// parseLexBound parses "-", "+" or a member prefixed with "[" (inclusive) or "(" (exclusive)
+func parseLexBound(bound string) (lexBound, error) {
+	switch {
+	case bound == "-":
+		return lexBound{infinity: -1}, nil
+	case bound == "+":
+		return lexBound{infinity: 1}, nil
+	case strings.HasPrefix(bound, "["):
+		return lexBound{value: bound[1:]}, nil
+	case strings.HasPrefix(bound, "("):
+		return lexBound{value: bound[1:], exclusive: true}, nil
+	default:
+		return lexBound{}, domain.ErrMinMaxNotLex
+	}
+}
+
+func (b lexBound) isMin(n *skiplistNode) bool {
+	switch {
+	case b.infinity != 0:
+		return b.infinity < 0
+	case b.exclusive:
+		return n.member > b.value
+	default:
+		return n.member >= b.value
+	}
+}
+
+func (b lexBound) isMax(n *skiplistNode) bool {
+	switch {
+	case b.infinity != 0:
+		return b.infinity > 0
+	case b.exclusive:
+		return n.member < b.value
+	default:
+		return n.member <= b.value
+	}
+}
+
+// loadSortedSet returns the sorted set stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadSortedSet(key string) (*sortedSet, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	z, ok := val.value.(*sortedSet)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return z, nil
+}
+
+func (r *InMemoryRedis) ZAdd(key string, members []usecase.ScoredMember, options usecase.ZAddOptions) (int, error) {
+	if err := validateZAddOptions(options); err != nil {
+		return -1, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil {
+		return -1, err
+	}
+
+	created := z == nil
+	if created {
+		z = newSortedSet()
+	}
+
+	added, changed := 0, 0
+	for _, member := range members {
+		current, exists := z.scores[member.Member]
+		if !exists {
+			if !options.XX {
+				z.add(member.Member, member.Score)
+				added++
+			}
+			continue
+		}
+
+		if options.NX || (options.GT && member.Score <= current) || (options.LT && member.Score >= current) {
+			continue
+		}
+		if member.Score != current {
+			z.add(member.Member, member.Score)
+			changed++
+		}
+	}
+
+	if created && len(z.scores) > 0 {
+		r.store.Store(key, storeValue{
+			value: z,
+		})
+	}
+
+	if options.CH {
+		return added + changed, nil
+	}
+	return added, nil
+}
+
+// ZAddIncr increments the score of the member like ZADD INCR,
+// the returned flag is false if the options prevented the update
+func (r *InMemoryRedis) ZAddIncr(key string, member string, increment float64, options usecase.ZAddOptions) (float64, bool, error) {
+	if err := validateZAddOptions(options); err != nil {
+		return 0, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.zincr(key, member, increment, options)
+}
+
+func (r *InMemoryRedis) ZIncrBy(key string, increment float64, member string) (float64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	score, _, err := r.zincr(key, member, increment, usecase.ZAddOptions{})
+	return score, err
+}
+
+func (r *InMemoryRedis) zincr(key string, member string, increment float64, options usecase.ZAddOptions) (float64, bool, error) {
+	z, err := r.loadSortedSet(key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	current, exists := 0.0, false
+	if z != nil {
+		current, exists = z.scores[member]
+	}
+
+	if (exists && options.NX) || (!exists && options.XX) {
+		return 0, false, nil
+	}
+
+	score := current + increment
+	if math.IsNaN(score) {
+		return 0, false, domain.ErrScoreIsNaN
+	}
+	if exists && ((options.GT && score <= current) || (options.LT && score >= current)) {
+		return 0, false, nil
+	}
+
+	if z == nil {
+		z = newSortedSet()
+		r.store.Store(key, storeValue{
+			value: z,
+		})
+	}
+	z.add(member, score)
+	return score, true, nil
+}
+
+func validateZAddOptions(options usecase.ZAddOptions) error {
+	if options.NX && options.XX {
+		return domain.ErrXXAndNX
+	}
+	if (options.GT && options.LT) || (options.NX && (options.GT || options.LT)) {
+		return domain.ErrGTLTAndNX
+	}
+	return nil
+}
+
+func (r *InMemoryRedis) ZRem(key string, members []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, member := range members {
+		if z.remove(member) {
+			removed++
+		}
+	}
+
+	if len(z.scores) == 0 {
+		r.del(key)
+	}
+	return removed, nil
+}
+
+func (r *InMemoryRedis) ZScore(key string, member string) (float64, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, false, err
+	}
+
+	score, ok := z.scores[member]
+	return score, ok, nil
+}
+
+func (r *InMemoryRedis) ZCard(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, err
+	}
+	return len(z.scores), nil
+}
+
+// ZRank returns the 0-based rank of the member ordered from the lowest score
+func (r *InMemoryRedis) ZRank(key string, member string) (int, bool, error) {
+	return r.zrank(key, member, false)
+}
+
+// ZRevRank returns the 0-based rank of the member ordered from the highest score
+func (r *InMemoryRedis) ZRevRank(key string, member string) (int, bool, error) {
+	return r.zrank(key, member, true)
+}
+
+func (r *InMemoryRedis) zrank(key string, member string, rev bool) (int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, false, err
+	}
+
+	score, ok := z.scores[member]
+	if !ok {
+		return 0, false, nil
+	}
+
+	rank := z.list.rank(score, member)
+	if rev {
+		return z.list.length - rank, true, nil
+	}
+	return rank - 1, true, nil
+}
+
+func (r *InMemoryRedis) ZRange(key string, query usecase.ZRangeQuery) ([]usecase.ScoredMember, error) {
+	if query.By == usecase.ZRangeByIndex {
+		return r.zrangeByIndex(key, query)
+	}
+
+	var aboveMin, belowMax func(n *skiplistNode) bool
+	if query.By == usecase.ZRangeByScore {
+		start, err := parseScoreBound(query.Start)
+		if err != nil {
+			return nil, err
+		}
+		stop, err := parseScoreBound(query.Stop)
+		if err != nil {
+			return nil, err
+		}
+
+		if query.Rev {
+			aboveMin, belowMax = stop.isMin, start.isMax
+		} else {
+			aboveMin, belowMax = start.isMin, stop.isMax
+		}
+	} else {
+		start, err := parseLexBound(query.Start)
+		if err != nil {
+			return nil, err
+		}
+		stop, err := parseLexBound(query.Stop)
+		if err != nil {
+			return nil, err
+		}
+
+		if query.Rev {
+			aboveMin, belowMax = stop.isMin, start.isMax
+		} else {
+			aboveMin, belowMax = start.isMin, stop.isMax
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return []usecase.ScoredMember{}, err
+	}
+	return z.between(aboveMin, belowMax, query.Rev, query.Limit), nil
+}
+
+func (r *InMemoryRedis) zrangeByIndex(key string, query usecase.ZRangeQuery) ([]usecase.ScoredMember, error) {
+	if query.Limit != nil {
+		return nil, domain.ErrLimitWithIndex
+	}
+
+	start, err := strconv.Atoi(query.Start)
+	if err != nil {
+		return nil, domain.ErrNotInteger
+	}
+	stop, err := strconv.Atoi(query.Stop)
+	if err != nil {
+		return nil, domain.ErrNotInteger
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]usecase.ScoredMember, 0)
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return result, err
+	}
+
+	length := z.list.length
+	start, stop, ok := normalizeRange(start, stop, length)
+	if !ok {
+		return result, nil
+	}
+
+	var node *skiplistNode
+	if query.Rev {
+		node = z.list.byRank(length - start)
+	} else {
+		node = z.list.byRank(start + 1)
+	}
+	for i := start; i <= stop && node != nil; i++ {
+		result = append(result, usecase.ScoredMember{Member: node.member, Score: node.score})
+		if query.Rev {
+			node = node.backward
+		} else {
+			node = node.level[0].forward
+		}
+	}
+	return result, nil
+}
+
+// ZCount returns the number of members with scores between min and max
+func (r *InMemoryRedis) ZCount(key string, min string, max string) (int, error) {
+	minBound, err := parseScoreBound(min)
+	if err != nil {
+		return 0, err
+	}
+	maxBound, err := parseScoreBound(max)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return 0, err
+	}
+
+	first := z.list.first(minBound.isMin)
+	if first == nil || !maxBound.isMax(first) {
+		return 0, nil
+	}
+	last := z.list.last(maxBound.isMax)
+	return z.list.rank(last.score, last.member) - z.list.rank(first.score, first.member) + 1, nil
+}
+
+// ZPopMin removes and returns up to count members with the lowest scores
+func (r *InMemoryRedis) ZPopMin(key string, count int) ([]usecase.ScoredMember, error) {
+	return r.zpop(key, count, false)
+}
+
+// ZPopMax removes and returns up to count members with the highest scores
+func (r *InMemoryRedis) ZPopMax(key string, count int) ([]usecase.ScoredMember, error) {
+	return r.zpop(key, count, true)
+}
+
+func (r *InMemoryRedis) zpop(key string, count int, max bool) ([]usecase.ScoredMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]usecase.ScoredMember, 0)
+	z, err := r.loadSortedSet(key)
+	if err != nil || z == nil {
+		return result, err
+	}
+
+	for len(result) < count && z.list.length > 0 {
+		node := z.list.header.level[0].forward
+		if max {
+			node = z.list.tail
+		}
+		result = append(result, usecase.ScoredMember{Member: node.member, Score: node.score})
+		z.remove(node.member)
+	}
+
+	if len(z.scores) == 0 {
+		r.del(key)
+	}
+	return result, nil
+}
+
+// ZUnionStore stores the union of the sets at destination and returns its size.
+// Plain sets are accepted as sorted sets with all scores equal to 1.
+func (r *InMemoryRedis) ZUnionStore(destination string, keys []string, options usecase.ZStoreOptions) (int, error) {
+	return r.zstore(destination, keys, options, false)
+}
+
+// ZInterStore stores the intersection of the sets at destination and returns its size.
+// Plain sets are accepted as sorted sets with all scores equal to 1.
+func (r *InMemoryRedis) ZInterStore(destination string, keys []string, options usecase.ZStoreOptions) (int, error) {
+	return r.zstore(destination, keys, options, true)
+}
+
+func (r *InMemoryRedis) zstore(destination string, keys []string, options usecase.ZStoreOptions, intersect bool) (int, error) {
+	if options.Weights != nil && len(options.Weights) != len(keys) {
+		return -1, domain.ErrSyntax
+	}
+
+	var aggregate func(a, b float64) float64
+	switch strings.ToUpper(options.Aggregate) {
+	case "", "SUM":
+		aggregate = func(a, b float64) float64 { return a + b }
+	case "MIN":
+		aggregate = math.Min
+	case "MAX":
+		aggregate = math.Max
+	default:
+		return -1, domain.ErrSyntax
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sources := make([]map[string]float64, 0, len(keys))
+	for _, key := range keys {
+		scores, err := r.loadScores(key)
+		if err != nil {
+			return -1, err
+		}
+		sources = append(sources, scores)
+	}
+
+	result := make(map[string]float64)
+	for i, scores := range sources {
+		weight := 1.0
+		if options.Weights != nil {
+			weight = options.Weights[i]
+		}
+
+		for member, score := range scores {
+			score = zeroIfNaN(score * weight)
+			if current, ok := result[member]; ok {
+				result[member] = zeroIfNaN(aggregate(current, score))
+			} else if !intersect || i == 0 {
+				result[member] = score
+			}
+		}
+
+		if intersect && i > 0 {
+			for member := range result {
+				if _, ok := scores[member]; !ok {
+					delete(result, member)
+				}
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		r.del(destination)
+		return 0, nil
+	}
+
+	z := newSortedSet()
+	for member, score := range result {
+		z.add(member, score)
+	}
+	r.store.Store(destination, storeValue{
+		value: z,
+	})
+	return len(result), nil
+}
+
+// loadScores returns the scores of a sorted set or a plain set, nil if the key doesn't exist
+func (r *InMemoryRedis) loadScores(key string) (map[string]float64, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	switch value := val.value.(type) {
+	case *sortedSet:
+		return value.scores, nil
+	case stringSet:
+		scores := make(map[string]float64, len(value))
+		for member := range value {
+			scores[member] = 1
+		}
+		return scores, nil
+	default:
+		return nil, domain.ErrWrongType
+	}
+}
+
+// zeroIfNaN replaces NaN produced by inf-inf or 0*inf with 0 like Redis does
+func zeroIfNaN(score float64) float64 {
+	if math.IsNaN(score) {
+		return 0
+	}
+	return score
+}
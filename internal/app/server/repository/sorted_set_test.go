@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func newTestSortedSet(members ...usecase.ScoredMember) *sortedSet {
+	z := newSortedSet()
+	for _, member := range members {
+		z.add(member.Member, member.Score)
+	}
+	return z
+}
+
+func testLeaderboard() *sortedSet {
+	return newTestSortedSet(
+		usecase.ScoredMember{Member: "a", Score: 1},
+		usecase.ScoredMember{Member: "b", Score: 2},
+		usecase.ScoredMember{Member: "c", Score: 3},
+		usecase.ScoredMember{Member: "d", Score: 3},
+	)
+}
+
+func TestInMemoryRedis_ZAdd(t *testing.T) {
+	type args struct {
+		members []usecase.ScoredMember
+		options usecase.ZAddOptions
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       int
+		wantScores map[string]float64
+		wantErr    error
+	}{
+		{
+			name:       "ZAdd adds new members and updates scores",
+			args:       args{members: []usecase.ScoredMember{{Member: "a", Score: 10}, {Member: "e", Score: 5}}},
+			want:       1,
+			wantScores: map[string]float64{"a": 10, "e": 5},
+		},
+		{
+			name: "ZAdd with CH counts changed members",
+			args: args{
+				members: []usecase.ScoredMember{{Member: "a", Score: 10}, {Member: "b", Score: 2}, {Member: "e", Score: 5}},
+				options: usecase.ZAddOptions{CH: true},
+			},
+			want:       2,
+			wantScores: map[string]float64{"a": 10, "b": 2, "e": 5},
+		},
+		{
+			name:       "ZAdd with NX doesn't update members",
+			args:       args{members: []usecase.ScoredMember{{Member: "a", Score: 10}, {Member: "e", Score: 5}}, options: usecase.ZAddOptions{NX: true}},
+			want:       1,
+			wantScores: map[string]float64{"a": 1, "e": 5},
+		},
+		{
+			name:       "ZAdd with XX doesn't add members",
+			args:       args{members: []usecase.ScoredMember{{Member: "a", Score: 10}, {Member: "e", Score: 5}}, options: usecase.ZAddOptions{XX: true}},
+			want:       0,
+			wantScores: map[string]float64{"a": 10},
+		},
+		{
+			name: "ZAdd with GT only increases scores",
+			args: args{
+				members: []usecase.ScoredMember{{Member: "a", Score: 0}, {Member: "b", Score: 5}},
+				options: usecase.ZAddOptions{GT: true, CH: true},
+			},
+			want:       1,
+			wantScores: map[string]float64{"a": 1, "b": 5},
+		},
+		{
+			name:    "ZAdd with NX and XX",
+			args:    args{members: []usecase.ScoredMember{{Member: "a", Score: 1}}, options: usecase.ZAddOptions{NX: true, XX: true}},
+			want:    -1,
+			wantErr: domain.ErrXXAndNX,
+		},
+		{
+			name:    "ZAdd with NX and GT",
+			args:    args{members: []usecase.ScoredMember{{Member: "a", Score: 1}}, options: usecase.ZAddOptions{NX: true, GT: true}},
+			want:    -1,
+			wantErr: domain.ErrGTLTAndNX,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"zkey": testLeaderboard()})
+			got, err := r.ZAdd("zkey", tt.args.members, tt.args.options)
+			if err != tt.wantErr {
+				t.Errorf("ZAdd() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ZAdd() got = %v, want %v", got, tt.want)
+			}
+			for member, want := range tt.wantScores {
+				if score, _, _ := r.ZScore("zkey", member); score != want {
+					t.Errorf("ZAdd() score of %v = %v, want %v", member, score, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_ZAddXXDoesNotCreateKey(t *testing.T) {
+	r := newTestRedis(nil)
+	if _, err := r.ZAdd("zkey", []usecase.ScoredMember{{Member: "a", Score: 1}}, usecase.ZAddOptions{XX: true}); err != nil {
+		t.Fatalf("ZAdd() error = %v", err)
+	}
+	if _, exists := r.store.Load("zkey"); exists {
+		t.Errorf("ZAdd() with XX created the key")
+	}
+}
+
+func TestInMemoryRedis_ZAddIncr(t *testing.T) {
+	type args struct {
+		key       string
+		member    string
+		increment float64
+		options   usecase.ZAddOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    float64
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			name:   "ZAddIncr increments an existing member",
+			args:   args{key: "zkey", member: "b", increment: 2.5},
+			want:   4.5,
+			wantOk: true,
+		},
+		{
+			name:   "ZAddIncr creates the key",
+			args:   args{key: "newkey", member: "a", increment: 3},
+			want:   3,
+			wantOk: true,
+		},
+		{
+			name:   "ZAddIncr with LT aborts a growing score",
+			args:   args{key: "zkey", member: "b", increment: 1, options: usecase.ZAddOptions{LT: true}},
+			wantOk: false,
+		},
+		{
+			name:   "ZAddIncr with XX aborts for a new member",
+			args:   args{key: "zkey", member: "e", increment: 1, options: usecase.ZAddOptions{XX: true}},
+			wantOk: false,
+		},
+		{
+			name:    "ZAddIncr producing NaN",
+			args:    args{key: "inf", member: "a", increment: math.Inf(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "ZAddIncr when a key holding the wrong kind of value",
+			args:    args{key: "str", member: "a", increment: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{
+				"zkey": testLeaderboard(),
+				"inf":  newTestSortedSet(usecase.ScoredMember{Member: "a", Score: math.Inf(1)}),
+				"str":  "some_string",
+			})
+			got, ok, err := r.ZAddIncr(tt.args.key, tt.args.member, tt.args.increment, tt.args.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ZAddIncr() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("ZAddIncr() got = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_ZRank(t *testing.T) {
+	tests := []struct {
+		name     string
+		member   string
+		want     int
+		wantRev  int
+		wantOkay bool
+	}{
+		{name: "ZRank of the lowest score", member: "a", want: 0, wantRev: 3, wantOkay: true},
+		{name: "ZRank of equal scores is ordered by member", member: "d", want: 3, wantRev: 0, wantOkay: true},
+		{name: "ZRank of a missing member", member: "x", wantOkay: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"zkey": testLeaderboard()})
+			got, ok, err := r.ZRank("zkey", tt.member)
+			if err != nil || ok != tt.wantOkay || got != tt.want {
+				t.Errorf("ZRank() got = %v, %v, %v, want %v, %v", got, ok, err, tt.want, tt.wantOkay)
+			}
+			got, ok, err = r.ZRevRank("zkey", tt.member)
+			if err != nil || ok != tt.wantOkay || got != tt.wantRev {
+				t.Errorf("ZRevRank() got = %v, %v, %v, want %v, %v", got, ok, err, tt.wantRev, tt.wantOkay)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_ZRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   usecase.ZRangeQuery
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "ZRange by index",
+			query: usecase.ZRangeQuery{Start: "1", Stop: "-2"},
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "ZRange by index reversed",
+			query: usecase.ZRangeQuery{Start: "0", Stop: "1", Rev: true},
+			want:  []string{"d", "c"},
+		},
+		{
+			name:  "ZRange by index out of range",
+			query: usecase.ZRangeQuery{Start: "5", Stop: "10"},
+			want:  []string{},
+		},
+		{
+			name:  "ZRange by score with an exclusive bound",
+			query: usecase.ZRangeQuery{Start: "(1", Stop: "+inf", By: usecase.ZRangeByScore},
+			want:  []string{"b", "c", "d"},
+		},
+		{
+			name:  "ZRange by score reversed with a limit",
+			query: usecase.ZRangeQuery{Start: "3", Stop: "-inf", By: usecase.ZRangeByScore, Rev: true, Limit: &usecase.ZRangeLimit{Offset: 1, Count: 2}},
+			want:  []string{"c", "b"},
+		},
+		{
+			name:  "ZRange by lex",
+			query: usecase.ZRangeQuery{Start: "[b", Stop: "(d", By: usecase.ZRangeByLex},
+			want:  []string{"b", "c"},
+		},
+		{
+			name:  "ZRange by lex reversed",
+			query: usecase.ZRangeQuery{Start: "+", Stop: "(b", By: usecase.ZRangeByLex, Rev: true},
+			want:  []string{"d", "c"},
+		},
+		{
+			name:    "ZRange by score with an invalid bound",
+			query:   usecase.ZRangeQuery{Start: "one", Stop: "2", By: usecase.ZRangeByScore},
+			wantErr: domain.ErrMinMaxNotFloat,
+		},
+		{
+			name:    "ZRange by lex with an invalid bound",
+			query:   usecase.ZRangeQuery{Start: "b", Stop: "+", By: usecase.ZRangeByLex},
+			wantErr: domain.ErrMinMaxNotLex,
+		},
+		{
+			name:    "ZRange by index with a limit",
+			query:   usecase.ZRangeQuery{Start: "0", Stop: "-1", Limit: &usecase.ZRangeLimit{Count: 1}},
+			wantErr: domain.ErrLimitWithIndex,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"zkey": testLeaderboard()})
+			got, err := r.ZRange("zkey", tt.query)
+			if err != tt.wantErr {
+				t.Errorf("ZRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			members := make([]string, 0, len(got))
+			for _, member := range got {
+				members = append(members, member.Member)
+			}
+			if !reflect.DeepEqual(members, tt.want) {
+				t.Errorf("ZRange() got = %v, want %v", members, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_ZCount(t *testing.T) {
+	tests := []struct {
+		name string
+		min  string
+		max  string
+		want int
+	}{
+		{name: "ZCount of the whole set", min: "-inf", max: "+inf", want: 4},
+		{name: "ZCount with exclusive bounds", min: "(1", max: "(3", want: 1},
+		{name: "ZCount of an empty range", min: "4", max: "10", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"zkey": testLeaderboard()})
+			got, err := r.ZCount("zkey", tt.min, tt.max)
+			if err != nil || got != tt.want {
+				t.Errorf("ZCount() got = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_ZPop(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"zkey": testLeaderboard()})
+
+	got, err := r.ZPopMin("zkey", 2)
+	want := []usecase.ScoredMember{{Member: "a", Score: 1}, {Member: "b", Score: 2}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ZPopMin() got = %v, %v, want %v", got, err, want)
+	}
+
+	got, err = r.ZPopMax("zkey", 5)
+	want = []usecase.ScoredMember{{Member: "d", Score: 3}, {Member: "c", Score: 3}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ZPopMax() got = %v, %v, want %v", got, err, want)
+	}
+
+	if _, exists := r.store.Load("zkey"); exists {
+		t.Errorf("ZPopMax() didn't delete an empty sorted set")
+	}
+}
+
+func TestInMemoryRedis_ZStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		intersect bool
+		keys      []string
+		options   usecase.ZStoreOptions
+		want      map[string]float64
+		wantErr   bool
+	}{
+		{
+			name: "ZUnionStore sums the scores",
+			keys: []string{"z1", "z2"},
+			want: map[string]float64{"a": 1, "b": 12, "c": 20},
+		},
+		{
+			name:    "ZUnionStore with weights and MAX",
+			keys:    []string{"z1", "z2"},
+			options: usecase.ZStoreOptions{Weights: []float64{10, 1}, Aggregate: "max"},
+			want:    map[string]float64{"a": 10, "b": 20, "c": 20},
+		},
+		{
+			name:      "ZInterStore with MIN",
+			intersect: true,
+			keys:      []string{"z1", "z2"},
+			options:   usecase.ZStoreOptions{Aggregate: "MIN"},
+			want:      map[string]float64{"b": 2},
+		},
+		{
+			name:      "ZInterStore with a plain set",
+			intersect: true,
+			keys:      []string{"z2", "set"},
+			want:      map[string]float64{"c": 21},
+		},
+		{
+			name:    "ZUnionStore with wrong number of weights",
+			keys:    []string{"z1", "z2"},
+			options: usecase.ZStoreOptions{Weights: []float64{1}},
+			wantErr: true,
+		},
+		{
+			name:    "ZUnionStore with an unknown aggregate",
+			keys:    []string{"z1"},
+			options: usecase.ZStoreOptions{Aggregate: "AVG"},
+			wantErr: true,
+		},
+		{
+			name:    "ZUnionStore when a key holding the wrong kind of value",
+			keys:    []string{"z1", "str"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{
+				"z1": newTestSortedSet(
+					usecase.ScoredMember{Member: "a", Score: 1},
+					usecase.ScoredMember{Member: "b", Score: 2},
+				),
+				"z2": newTestSortedSet(
+					usecase.ScoredMember{Member: "b", Score: 10},
+					usecase.ScoredMember{Member: "c", Score: 20},
+				),
+				"set": newTestSet("c", "d"),
+				"str": "some_string",
+			})
+
+			store := r.ZUnionStore
+			if tt.intersect {
+				store = r.ZInterStore
+			}
+			got, err := store("dest", tt.keys, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got != len(tt.want) {
+				t.Errorf("%s() got = %v, want %v", tt.name, got, len(tt.want))
+			}
+			for member, want := range tt.want {
+				if score, _, _ := r.ZScore("dest", member); score != want {
+					t.Errorf("%s() score of %v = %v, want %v", tt.name, member, score, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package repository
+
+// newTestRedis creates a store holding the values without expiration
+func newTestRedis(values map[string]interface{}) *InMemoryRedis {
+	r := &InMemoryRedis{}
+	for key, value := range values {
+		r.store.Store(key, storeValue{
+			value: value,
+		})
+	}
+	return r
+}
@@ -0,0 +1,403 @@
+package repository
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"sort"
+	"time"
+)
+
+// stream is an append-only log of entries ordered by ID.
+// The entries are kept in a slice, so appending and reading are fast while XDEL is O(N).
+type stream struct {
+	entries []usecase.StreamEntry
+	// lastID is the greatest ID ever added, it isn't reset when the entries are deleted
+	lastID usecase.StreamID
+	groups map[string]*streamGroup
+}
+
+func newStream() *stream {
+	return &stream{groups: make(map[string]*streamGroup)}
+}
+
+// search returns the index of the first entry which isn't older than id
+func (s *stream) search(id usecase.StreamID) int {
+	return sort.Search(len(s.entries), func(i int) bool {
+		return !s.entries[i].ID.Less(id)
+	})
+}
+
+// searchAfter returns the index of the first entry newer than id
+func (s *stream) searchAfter(id usecase.StreamID) int {
+	return sort.Search(len(s.entries), func(i int) bool {
+		return id.Less(s.entries[i].ID)
+	})
+}
+
+func (s *stream) entry(id usecase.StreamID) (usecase.StreamEntry, bool) {
+	i := s.search(id)
+	if i < len(s.entries) && s.entries[i].ID == id {
+		return s.entries[i], true
+	}
+	return usecase.StreamEntry{}, false
+}
+
+// after returns at most count entries newer than id, all of them if count is 0
+func (s *stream) after(id usecase.StreamID, count int) []usecase.StreamEntry {
+	return s.slice(s.searchAfter(id), len(s.entries), count)
+}
+
+// slice copies at most count entries from i to j, all of them if count is 0
+func (s *stream) slice(i, j, count int) []usecase.StreamEntry {
+	if count > 0 && j-i > count {
+		j = i + count
+	}
+	if i >= j {
+		return nil
+	}
+
+	result := make([]usecase.StreamEntry, j-i)
+	copy(result, s.entries[i:j])
+	return result
+}
+
+// nextID returns the ID of the entry added by XADD
+func (s *stream) nextID(options usecase.XAddOptions, now time.Time) (usecase.StreamID, error) {
+	last := s.lastID
+	switch {
+	case options.AutoID:
+		ms := uint64(now.UnixNano() / int64(time.Millisecond))
+		if ms > last.Ms {
+			return usecase.StreamID{Ms: ms}, nil
+		}
+		if last.Seq < math.MaxUint64 {
+			return usecase.StreamID{Ms: last.Ms, Seq: last.Seq + 1}, nil
+		}
+		if last.Ms < math.MaxUint64 {
+			return usecase.StreamID{Ms: last.Ms + 1}, nil
+		}
+		return usecase.StreamID{}, domain.ErrStreamExhausted
+	case options.AutoSeq:
+		ms := options.ID.Ms
+		if ms > last.Ms {
+			return usecase.StreamID{Ms: ms}, nil
+		}
+		if ms < last.Ms || last.Seq == math.MaxUint64 {
+			return usecase.StreamID{}, domain.ErrStreamIDTooSmall
+		}
+		return usecase.StreamID{Ms: ms, Seq: last.Seq + 1}, nil
+	default:
+		if options.ID == (usecase.StreamID{}) {
+			return usecase.StreamID{}, domain.ErrStreamIDZero
+		}
+		if !last.Less(options.ID) {
+			return usecase.StreamID{}, domain.ErrStreamIDTooSmall
+		}
+		return options.ID, nil
+	}
+}
+
+// trim removes the oldest entries and returns their number
+func (s *stream) trim(trim usecase.StreamTrim) int {
+	removed := 0
+	if trim.MinID != nil {
+		removed = s.search(*trim.MinID)
+	} else if int64(len(s.entries)) > trim.MaxLen {
+		removed = len(s.entries) - int(trim.MaxLen)
+	}
+	if trim.Limit > 0 && int64(removed) > trim.Limit {
+		removed = int(trim.Limit)
+	}
+
+	s.remove(0, removed)
+	return removed
+}
+
+// remove removes the entries from i to j
+func (s *stream) remove(i, j int) {
+	if i >= j {
+		return
+	}
+
+	n := copy(s.entries[i:], s.entries[j:])
+	for k := i + n; k < len(s.entries); k++ {
+		s.entries[k] = usecase.StreamEntry{}
+	}
+	s.entries = s.entries[:i+n]
+}
+
+// loadStream returns the stream stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadStream(key string) (*stream, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	s, ok := val.value.(*stream)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return s, nil
+}
+
+// XAdd adds the entry and returns its ID, the returned flag is false if the stream doesn't exist with NoMkStream
+func (r *InMemoryRedis) XAdd(key string, fields []usecase.FieldValue, options usecase.XAddOptions) (usecase.StreamID, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil {
+		return usecase.StreamID{}, false, err
+	}
+	if s == nil && options.NoMkStream {
+		return usecase.StreamID{}, false, nil
+	}
+
+	created := s == nil
+	if created {
+		s = newStream()
+	}
+
+	id, err := s.nextID(options, time.Now())
+	if err != nil {
+		return usecase.StreamID{}, false, err
+	}
+
+	if created {
+		r.store.Store(key, storeValue{
+			value: s,
+		})
+	}
+
+	s.entries = append(s.entries, usecase.StreamEntry{
+		ID:     id,
+		Fields: append([]usecase.FieldValue(nil), fields...),
+	})
+	s.lastID = id
+	if options.Trim != nil {
+		s.trim(*options.Trim)
+	}
+
+	r.signalStreamWaiters(key)
+	return id, true, nil
+}
+
+// XRange returns at most count entries from start to end inclusive, all of them if count is 0
+func (r *InMemoryRedis) XRange(key string, start usecase.StreamID, end usecase.StreamID, count int) ([]usecase.StreamEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil || end.Less(start) {
+		return nil, err
+	}
+
+	return s.slice(s.search(start), s.searchAfter(end), count), nil
+}
+
+// XRevRange is XRange which returns the entries from end to start
+func (r *InMemoryRedis) XRevRange(key string, end usecase.StreamID, start usecase.StreamID, count int) ([]usecase.StreamEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil || end.Less(start) {
+		return nil, err
+	}
+
+	i, j := s.search(start), s.searchAfter(end)
+	if count > 0 && j-i > count {
+		i = j - count
+	}
+
+	result := s.slice(i, j, 0)
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+func (r *InMemoryRedis) XLen(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil {
+		return 0, err
+	}
+	return len(s.entries), nil
+}
+
+// XDel deletes the entries and returns the number of the deleted ones, they stay pending in the groups
+func (r *InMemoryRedis) XDel(key string, ids []usecase.StreamID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, id := range ids {
+		i := s.search(id)
+		if i < len(s.entries) && s.entries[i].ID == id {
+			s.remove(i, i+1)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// XTrim trims the stream and returns the number of the removed entries
+func (r *InMemoryRedis) XTrim(key string, trim usecase.StreamTrim) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil {
+		return 0, err
+	}
+	return s.trim(trim), nil
+}
+
+// XRead returns the entries added after the IDs of the reads, the streams without them are omitted.
+// With options.Block it waits for an entry if there are none.
+func (r *InMemoryRedis) XRead(ctx context.Context, reads []usecase.StreamRead, options usecase.XReadOptions) ([]usecase.StreamEntries, error) {
+	keys := make([]string, 0, len(reads))
+	r.mu.Lock()
+	// $ means the entries added after the call, so it's resolved before blocking
+	resolved := make([]usecase.StreamRead, 0, len(reads))
+	for _, read := range reads {
+		if read.New {
+			s, err := r.loadStream(read.Key)
+			if err != nil {
+				r.mu.Unlock()
+				return nil, err
+			}
+			read.ID = usecase.StreamID{}
+			if s != nil {
+				read.ID = s.lastID
+			}
+		}
+		resolved = append(resolved, read)
+		keys = append(keys, read.Key)
+	}
+	r.mu.Unlock()
+
+	return r.blockingRead(ctx, keys, options, func() ([]usecase.StreamEntries, error) {
+		var result []usecase.StreamEntries
+		for _, read := range resolved {
+			s, err := r.loadStream(read.Key)
+			if err != nil {
+				return nil, err
+			}
+			if s == nil {
+				continue
+			}
+
+			if entries := s.after(read.ID, options.Count); len(entries) > 0 {
+				result = append(result, usecase.StreamEntries{Key: read.Key, Entries: entries})
+			}
+		}
+		return result, nil
+	})
+}
+
+// streamWaiter is a client blocked until an entry is added to one of the streams at keys
+type streamWaiter struct {
+	keys []string
+	// ready is buffered, so signaling never blocks the command which added the entry
+	ready chan struct{}
+}
+
+// blockingRead calls read with mu held until it returns entries or an error.
+// Unlike the list pops, reads don't consume the entries, so every client blocked on the stream is woken
+// and reads again. XREADGROUP clients of the same group may find the entries already delivered to another one.
+func (r *InMemoryRedis) blockingRead(ctx context.Context, keys []string, options usecase.XReadOptions,
+	read func() ([]usecase.StreamEntries, error)) ([]usecase.StreamEntries, error) {
+	r.mu.Lock()
+	result, err := read()
+	if err != nil || len(result) > 0 || !options.Block {
+		r.mu.Unlock()
+		return result, err
+	}
+
+	waiter := &streamWaiter{keys: keys, ready: make(chan struct{}, 1)}
+	r.addStreamWaiter(waiter)
+	r.mu.Unlock()
+
+	var expired <-chan time.Time
+	if options.Timeout > 0 {
+		timer := time.NewTimer(options.Timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case <-waiter.ready:
+		case <-expired:
+			r.cancelStreamWaiter(waiter)
+			return nil, nil
+		case <-ctx.Done():
+			r.cancelStreamWaiter(waiter)
+			return nil, nil
+		}
+
+		r.mu.Lock()
+		result, err = read()
+		if err != nil || len(result) > 0 {
+			r.removeStreamWaiter(waiter)
+			r.mu.Unlock()
+			return result, err
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *InMemoryRedis) cancelStreamWaiter(waiter *streamWaiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeStreamWaiter(waiter)
+}
+
+// signalStreamWaiters wakes the clients blocked on the stream at key
+func (r *InMemoryRedis) signalStreamWaiters(key string) {
+	for _, waiter := range r.streamWaiters[key] {
+		select {
+		case waiter.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (r *InMemoryRedis) addStreamWaiter(waiter *streamWaiter) {
+	if r.streamWaiters == nil {
+		r.streamWaiters = make(map[string][]*streamWaiter)
+	}
+	for _, key := range waiter.keys {
+		r.streamWaiters[key] = append(r.streamWaiters[key], waiter)
+	}
+}
+
+func (r *InMemoryRedis) removeStreamWaiter(waiter *streamWaiter) {
+	for _, key := range waiter.keys {
+		waiters := r.streamWaiters[key]
+		for i, w := range waiters {
+			if w == waiter {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+
+		if len(waiters) == 0 {
+			delete(r.streamWaiters, key)
+		} else {
+			r.streamWaiters[key] = waiters
+		}
+	}
+}
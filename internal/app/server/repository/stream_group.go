@@ -0,0 +1,496 @@
+package repository
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"sort"
+	"time"
+)
+
+// streamGroup is a consumer group: every entry is delivered to a single consumer of the group
+// and stays pending until the consumer acknowledges it
+type streamGroup struct {
+	// lastID is the last entry delivered to the group
+	lastID usecase.StreamID
+	// pending is the pending entries list ordered by ID
+	pending   []*pendingEntry
+	consumers map[string]*streamConsumer
+}
+
+type pendingEntry struct {
+	id         usecase.StreamID
+	consumer   *streamConsumer
+	delivered  time.Time
+	deliveries int64
+}
+
+type streamConsumer struct {
+	name string
+	seen time.Time
+}
+
+func newStreamGroup(lastID usecase.StreamID) *streamGroup {
+	return &streamGroup{
+		lastID:    lastID,
+		consumers: make(map[string]*streamConsumer),
+	}
+}
+
+// consumer returns the consumer with the name, it's created if it doesn't exist
+func (g *streamGroup) consumer(name string, now time.Time) *streamConsumer {
+	c, ok := g.consumers[name]
+	if !ok {
+		c = &streamConsumer{name: name}
+		g.consumers[name] = c
+	}
+	c.seen = now
+	return c
+}
+
+// search returns the index of the first pending entry which isn't older than id
+func (g *streamGroup) search(id usecase.StreamID) int {
+	return sort.Search(len(g.pending), func(i int) bool {
+		return !g.pending[i].id.Less(id)
+	})
+}
+
+func (g *streamGroup) find(id usecase.StreamID) (*pendingEntry, bool) {
+	i := g.search(id)
+	if i < len(g.pending) && g.pending[i].id == id {
+		return g.pending[i], true
+	}
+	return nil, false
+}
+
+// deliver adds the entry to the pending entries of the consumer, or moves it to the consumer if it's pending
+func (g *streamGroup) deliver(id usecase.StreamID, consumer *streamConsumer, now time.Time) *pendingEntry {
+	i := g.search(id)
+	if i < len(g.pending) && g.pending[i].id == id {
+		p := g.pending[i]
+		p.consumer = consumer
+		p.delivered = now
+		p.deliveries++
+		return p
+	}
+
+	p := &pendingEntry{id: id, consumer: consumer, delivered: now, deliveries: 1}
+	g.pending = append(g.pending, nil)
+	copy(g.pending[i+1:], g.pending[i:])
+	g.pending[i] = p
+	return p
+}
+
+// ack removes the entry from the pending entries and reports whether it was pending
+func (g *streamGroup) ack(id usecase.StreamID) bool {
+	i := g.search(id)
+	if i == len(g.pending) || g.pending[i].id != id {
+		return false
+	}
+
+	copy(g.pending[i:], g.pending[i+1:])
+	g.pending[len(g.pending)-1] = nil
+	g.pending = g.pending[:len(g.pending)-1]
+	return true
+}
+
+// loadGroup returns the stream at key and its group, ErrNoGroup if either doesn't exist
+func (r *InMemoryRedis) loadGroup(key string, group string) (*stream, *streamGroup, error) {
+	s, err := r.loadStream(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s == nil || s.groups[group] == nil {
+		return nil, nil, domain.ErrNoGroup
+	}
+	return s, s.groups[group], nil
+}
+
+// XGroupCreate creates the group which starts after id, after the last entry of the stream if id is nil.
+// With mkStream a missing stream is created.
+func (r *InMemoryRedis) XGroupCreate(key string, group string, id *usecase.StreamID, mkStream bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		if !mkStream {
+			return domain.ErrStreamKeyRequired
+		}
+		s = newStream()
+		r.store.Store(key, storeValue{
+			value: s,
+		})
+	}
+
+	if _, ok := s.groups[group]; ok {
+		return domain.ErrBusyGroup
+	}
+
+	lastID := s.lastID
+	if id != nil {
+		lastID = *id
+	}
+	s.groups[group] = newStreamGroup(lastID)
+	return nil
+}
+
+// XGroupSetID sets the last delivered ID of the group, the last entry of the stream if id is nil
+func (r *InMemoryRedis) XGroupSetID(key string, group string, id *usecase.StreamID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, g, err := r.loadGroupOfExistingKey(key, group)
+	if err != nil {
+		return err
+	}
+
+	g.lastID = s.lastID
+	if id != nil {
+		g.lastID = *id
+	}
+	return nil
+}
+
+// XGroupDestroy deletes the group with its pending entries and reports whether it existed
+func (r *InMemoryRedis) XGroupDestroy(key string, group string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadExistingStream(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := s.groups[group]
+	delete(s.groups, group)
+	return ok, nil
+}
+
+// XGroupCreateConsumer reports whether the consumer has been created
+func (r *InMemoryRedis) XGroupCreateConsumer(key string, group string, consumer string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, g, err := r.loadGroupOfExistingKey(key, group)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := g.consumers[consumer]; ok {
+		return false, nil
+	}
+	g.consumer(consumer, time.Now())
+	return true, nil
+}
+
+// XGroupDelConsumer deletes the consumer with its pending entries and returns their number
+func (r *InMemoryRedis) XGroupDelConsumer(key string, group string, consumer string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, g, err := r.loadGroupOfExistingKey(key, group)
+	if err != nil {
+		return 0, err
+	}
+
+	c, ok := g.consumers[consumer]
+	if !ok {
+		return 0, nil
+	}
+	delete(g.consumers, consumer)
+
+	pending := g.pending[:0]
+	deleted := 0
+	for _, p := range g.pending {
+		if p.consumer == c {
+			deleted++
+		} else {
+			pending = append(pending, p)
+		}
+	}
+	for i := len(pending); i < len(g.pending); i++ {
+		g.pending[i] = nil
+	}
+	g.pending = pending
+	return deleted, nil
+}
+
+// loadExistingStream returns the stream at key for XGROUP, which requires the key to exist
+func (r *InMemoryRedis) loadExistingStream(key string) (*stream, error) {
+	s, err := r.loadStream(key)
+	if err == nil && s == nil {
+		err = domain.ErrStreamKeyRequired
+	}
+	return s, err
+}
+
+func (r *InMemoryRedis) loadGroupOfExistingKey(key string, group string) (*stream, *streamGroup, error) {
+	if _, err := r.loadExistingStream(key); err != nil {
+		return nil, nil, err
+	}
+	return r.loadGroup(key, group)
+}
+
+// XReadGroup reads the streams on behalf of the consumer of the group. Reads of new entries (>) deliver
+// the entries never delivered to the group and add them to the pending entries of the consumer unless
+// options.NoAck is set, other reads return the pending entries of the consumer after the ID.
+// With options.Block it waits for new entries if there are none.
+func (r *InMemoryRedis) XReadGroup(ctx context.Context, group string, consumer string, reads []usecase.StreamRead,
+	options usecase.XReadOptions) ([]usecase.StreamEntries, error) {
+	keys := make([]string, 0, len(reads))
+	for _, read := range reads {
+		keys = append(keys, read.Key)
+	}
+
+	return r.blockingRead(ctx, keys, options, func() ([]usecase.StreamEntries, error) {
+		// check all the groups before any entry is delivered
+		groups := make([]*streamGroup, 0, len(reads))
+		streams := make([]*stream, 0, len(reads))
+		for _, read := range reads {
+			s, g, err := r.loadGroup(read.Key, group)
+			if err != nil {
+				return nil, err
+			}
+			streams = append(streams, s)
+			groups = append(groups, g)
+		}
+
+		now := time.Now()
+		var result []usecase.StreamEntries
+		for i, read := range reads {
+			s, g := streams[i], groups[i]
+			c := g.consumer(consumer, now)
+
+			if !read.New {
+				entries := pendingOf(s, g, c, read.ID, options.Count, now)
+				result = append(result, usecase.StreamEntries{Key: read.Key, Entries: entries})
+				continue
+			}
+
+			entries := s.after(g.lastID, options.Count)
+			if len(entries) == 0 {
+				continue
+			}
+
+			g.lastID = entries[len(entries)-1].ID
+			if !options.NoAck {
+				for _, entry := range entries {
+					g.deliver(entry.ID, c, now)
+				}
+			}
+			result = append(result, usecase.StreamEntries{Key: read.Key, Entries: entries})
+		}
+		return result, nil
+	})
+}
+
+// pendingOf delivers again at most count pending entries of the consumer newer than id,
+// the entries deleted from the stream have nil fields
+func pendingOf(s *stream, g *streamGroup, c *streamConsumer, id usecase.StreamID, count int, now time.Time) []usecase.StreamEntry {
+	entries := make([]usecase.StreamEntry, 0)
+	for _, p := range g.pending[g.search(id):] {
+		if count > 0 && len(entries) == count {
+			break
+		}
+		if p.consumer != c || p.id == id {
+			continue
+		}
+
+		p.delivered = now
+		p.deliveries++
+		entry, ok := s.entry(p.id)
+		if !ok {
+			entry = usecase.StreamEntry{ID: p.id}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// XAck removes the entries from the pending entries of the group and returns the number of the removed ones
+func (r *InMemoryRedis) XAck(key string, group string, ids []usecase.StreamID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadStream(key)
+	if err != nil || s == nil || s.groups[group] == nil {
+		return 0, err
+	}
+
+	acked := 0
+	for _, id := range ids {
+		if s.groups[group].ack(id) {
+			acked++
+		}
+	}
+	return acked, nil
+}
+
+// XPendingSummary returns the summary of the pending entries of the group, the consumers are ordered by name
+func (r *InMemoryRedis) XPendingSummary(key string, group string) (usecase.PendingSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, g, err := r.loadGroup(key, group)
+	if err != nil {
+		return usecase.PendingSummary{}, err
+	}
+
+	summary := usecase.PendingSummary{Count: len(g.pending)}
+	if len(g.pending) == 0 {
+		return summary, nil
+	}
+	summary.Smallest = g.pending[0].id
+	summary.Greatest = g.pending[len(g.pending)-1].id
+
+	counts := make(map[string]int)
+	for _, p := range g.pending {
+		counts[p.consumer.name]++
+	}
+	for name, count := range counts {
+		summary.Consumers = append(summary.Consumers, usecase.ConsumerPending{Name: name, Pending: count})
+	}
+	sort.Slice(summary.Consumers, func(i, j int) bool {
+		return summary.Consumers[i].Name < summary.Consumers[j].Name
+	})
+	return summary, nil
+}
+
+// XPending returns the pending entries of the group which match the options
+func (r *InMemoryRedis) XPending(key string, group string, options usecase.XPendingOptions) ([]usecase.PendingEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, g, err := r.loadGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]usecase.PendingEntry, 0)
+	for _, p := range g.pending[g.search(options.Start):] {
+		if len(result) == options.Count || options.End.Less(p.id) {
+			break
+		}
+
+		idle := now.Sub(p.delivered)
+		if idle < options.MinIdle || (options.Consumer != "" && p.consumer.name != options.Consumer) {
+			continue
+		}
+		result = append(result, usecase.PendingEntry{
+			ID:         p.id,
+			Consumer:   p.consumer.name,
+			Idle:       idle,
+			Deliveries: p.deliveries,
+		})
+	}
+	return result, nil
+}
+
+// XClaim moves the pending entries idle at least minIdle to the consumer and returns the claimed entries.
+// The pending entries deleted from the stream are removed from the group.
+func (r *InMemoryRedis) XClaim(key string, group string, consumer string, minIdle time.Duration, ids []usecase.StreamID,
+	options usecase.XClaimOptions) ([]usecase.StreamEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, g, err := r.loadGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivered := now
+	if options.Idle != nil {
+		delivered = now.Add(-*options.Idle)
+	}
+	if options.Time != nil {
+		delivered = *options.Time
+	}
+	if options.LastID != nil && g.lastID.Less(*options.LastID) {
+		g.lastID = *options.LastID
+	}
+
+	c := g.consumer(consumer, now)
+	result := make([]usecase.StreamEntry, 0, len(ids))
+	for _, id := range ids {
+		entry, exists := s.entry(id)
+		p, pending := g.find(id)
+		if !pending {
+			if !options.Force || !exists {
+				continue
+			}
+			p = g.deliver(id, c, delivered)
+			p.deliveries = 0
+		} else if now.Sub(p.delivered) < minIdle {
+			continue
+		}
+
+		if !exists {
+			g.ack(id)
+			continue
+		}
+
+		claim(p, c, delivered, options.JustID)
+		if options.RetryCount != nil {
+			p.deliveries = *options.RetryCount
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// XAutoClaim claims like XClaim at most count pending entries idle at least minIdle, starting from start.
+// It checks at most 10 times count entries, so the scan may be continued from the returned ID.
+func (r *InMemoryRedis) XAutoClaim(key string, group string, consumer string, minIdle time.Duration, start usecase.StreamID,
+	count int, justID bool) (usecase.XAutoClaimResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, g, err := r.loadGroup(key, group)
+	if err != nil {
+		return usecase.XAutoClaimResult{}, err
+	}
+
+	now := time.Now()
+	c := g.consumer(consumer, now)
+	result := usecase.XAutoClaimResult{Claimed: make([]usecase.StreamEntry, 0), Deleted: make([]usecase.StreamID, 0)}
+
+	i := g.search(start)
+	for attempts := count * 10; i < len(g.pending) && attempts > 0 && len(result.Claimed) < count; attempts-- {
+		p := g.pending[i]
+		if now.Sub(p.delivered) < minIdle {
+			i++
+			continue
+		}
+
+		entry, exists := s.entry(p.id)
+		if !exists {
+			g.ack(p.id)
+			result.Deleted = append(result.Deleted, p.id)
+			continue
+		}
+
+		claim(p, c, now, justID)
+		result.Claimed = append(result.Claimed, entry)
+		i++
+	}
+
+	if i < len(g.pending) {
+		result.Next = g.pending[i].id
+	}
+	return result, nil
+}
+
+// claim moves the pending entry to the consumer, JUSTID doesn't count it as a delivery
+func claim(p *pendingEntry, c *streamConsumer, delivered time.Time, justID bool) {
+	p.consumer = c
+	p.delivered = delivered
+	if !justID {
+		p.deliveries++
+	}
+}
@@ -0,0 +1,620 @@
+package repository
+
+import (
+	"context"
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func streamID(ms, seq uint64) usecase.StreamID {
+	return usecase.StreamID{Ms: ms, Seq: seq}
+}
+
+func streamIDPtr(ms, seq uint64) *usecase.StreamID {
+	id := streamID(ms, seq)
+	return &id
+}
+
+// newTestStream returns a stream holding an entry per ID, the IDs are in the ascending order
+func newTestStream(ids ...usecase.StreamID) *stream {
+	s := newStream()
+	for _, id := range ids {
+		s.entries = append(s.entries, usecase.StreamEntry{ID: id, Fields: entryFields(id)})
+		s.lastID = id
+	}
+	return s
+}
+
+// newTestGroupStream returns newTestStream with the group "group" which starts at 0-0
+func newTestGroupStream(ids ...usecase.StreamID) *stream {
+	s := newTestStream(ids...)
+	s.groups["group"] = newStreamGroup(streamID(0, 0))
+	return s
+}
+
+func entryFields(id usecase.StreamID) []usecase.FieldValue {
+	return []usecase.FieldValue{{Field: "id", Value: id.String()}}
+}
+
+func entryIDs(entries []usecase.StreamEntry) []usecase.StreamID {
+	ids := make([]usecase.StreamID, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+// sameIDs compares the IDs, nil is the same as no IDs
+func sameIDs(got, want []usecase.StreamID) bool {
+	return len(got) == len(want) && (len(got) == 0 || reflect.DeepEqual(got, want))
+}
+
+func streamIDs(t *testing.T, r *InMemoryRedis) []usecase.StreamID {
+	entries, err := r.XRange("stream", streamID(0, 0), streamID(1<<63, 0), 0)
+	if err != nil {
+		t.Fatalf("XRange() error = %v", err)
+	}
+	return entryIDs(entries)
+}
+
+func TestInMemoryRedis_XAdd(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		options usecase.XAddOptions
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    usecase.StreamID
+		wantOk  bool
+		wantIDs []usecase.StreamID
+		wantErr error
+	}{
+		{
+			name:    "explicit ID",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(1, 1))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(1, 2)}},
+			want:    streamID(1, 2),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(1, 1), streamID(1, 2)},
+		},
+		{
+			name:    "equal ID",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(1, 1))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(1, 1)}},
+			wantErr: domain.ErrStreamIDTooSmall,
+		},
+		{
+			name:    "0-0",
+			fields:  fields{},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(0, 0)}},
+			wantErr: domain.ErrStreamIDZero,
+		},
+		{
+			name:    "sequence of the same millisecond",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(5, 3))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(5, 0), AutoSeq: true}},
+			want:    streamID(5, 4),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(5, 3), streamID(5, 4)},
+		},
+		{
+			name:    "sequence of a new millisecond",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(5, 3))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(6, 0), AutoSeq: true}},
+			want:    streamID(6, 0),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(5, 3), streamID(6, 0)},
+		},
+		{
+			name:    "sequence of 0 in a new stream",
+			fields:  fields{},
+			args:    args{key: "stream", options: usecase.XAddOptions{AutoSeq: true}},
+			want:    streamID(0, 1),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(0, 1)},
+		},
+		{
+			name:    "sequence of an older millisecond",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(5, 3))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{ID: streamID(4, 0), AutoSeq: true}},
+			wantErr: domain.ErrStreamIDTooSmall,
+		},
+		{
+			name:    "auto ID after an ID from the future",
+			fields:  fields{values: map[string]interface{}{"stream": newTestStream(streamID(1<<62, 7))}},
+			args:    args{key: "stream", options: usecase.XAddOptions{AutoID: true}},
+			want:    streamID(1<<62, 8),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(1<<62, 7), streamID(1<<62, 8)},
+		},
+		{
+			name:   "NOMKSTREAM",
+			fields: fields{},
+			args:   args{key: "stream", options: usecase.XAddOptions{ID: streamID(1, 1), NoMkStream: true}},
+		},
+		{
+			name:   "MAXLEN",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(streamID(1, 0), streamID(2, 0), streamID(3, 0))}},
+			args: args{key: "stream", options: usecase.XAddOptions{
+				ID:   streamID(4, 0),
+				Trim: &usecase.StreamTrim{MaxLen: 2},
+			}},
+			want:    streamID(4, 0),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(3, 0), streamID(4, 0)},
+		},
+		{
+			name:   "MINID with LIMIT",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(streamID(1, 0), streamID(2, 0), streamID(3, 0))}},
+			args: args{key: "stream", options: usecase.XAddOptions{
+				ID:   streamID(4, 0),
+				Trim: &usecase.StreamTrim{MinID: streamIDPtr(4, 0), Approximate: true, Limit: 2},
+			}},
+			want:    streamID(4, 0),
+			wantOk:  true,
+			wantIDs: []usecase.StreamID{streamID(3, 0), streamID(4, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, ok, err := r.XAdd(tt.args.key, entryFields(tt.args.options.ID), tt.args.options)
+			if err != tt.wantErr {
+				t.Fatalf("XAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("XAdd() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := streamIDs(t, r); !sameIDs(got, tt.wantIDs) {
+				t.Errorf("entries = %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_XAddAutoID(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{})
+	before := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+
+	first, _, err := r.XAdd("stream", []usecase.FieldValue{{Field: "a", Value: "1"}}, usecase.XAddOptions{AutoID: true})
+	if err != nil {
+		t.Fatalf("XAdd() error = %v", err)
+	}
+	second, _, _ := r.XAdd("stream", []usecase.FieldValue{{Field: "a", Value: "2"}}, usecase.XAddOptions{AutoID: true})
+
+	if first.Ms < before || !first.Less(second) {
+		t.Errorf("XAdd() = %v, %v, want increasing IDs from %d", first, second, before)
+	}
+}
+
+func TestInMemoryRedis_XRange(t *testing.T) {
+	ids := []usecase.StreamID{streamID(1, 0), streamID(1, 1), streamID(2, 0), streamID(3, 0)}
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key   string
+		start usecase.StreamID
+		end   usecase.StreamID
+		count int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   []usecase.StreamID
+	}{
+		{
+			name:   "all",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(ids...)}},
+			args:   args{key: "stream", start: streamID(0, 0), end: streamID(9, 0)},
+			want:   ids,
+		},
+		{
+			name:   "inclusive bounds",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(ids...)}},
+			args:   args{key: "stream", start: streamID(1, 1), end: streamID(2, 0)},
+			want:   ids[1:3],
+		},
+		{
+			name:   "count",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(ids...)}},
+			args:   args{key: "stream", start: streamID(0, 0), end: streamID(9, 0), count: 2},
+			want:   ids[:2],
+		},
+		{
+			name:   "end before start",
+			fields: fields{values: map[string]interface{}{"stream": newTestStream(ids...)}},
+			args:   args{key: "stream", start: streamID(3, 0), end: streamID(1, 0)},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.XRange(tt.args.key, tt.args.start, tt.args.end, tt.args.count)
+			if err != nil {
+				t.Fatalf("XRange() error = %v", err)
+			}
+			if got := entryIDs(got); !sameIDs(got, tt.want) {
+				t.Errorf("XRange() = %v, want %v", got, tt.want)
+			}
+
+			// XREVRANGE returns the newest entries first
+			rev, err := r.XRevRange(tt.args.key, tt.args.end, tt.args.start, tt.args.count)
+			if err != nil {
+				t.Fatalf("XRevRange() error = %v", err)
+			}
+			want := append([]usecase.StreamID(nil), tt.want...)
+			if tt.args.count > 0 {
+				want = append([]usecase.StreamID(nil), ids[len(ids)-tt.args.count:]...)
+			}
+			for i, j := 0, len(want)-1; i < j; i, j = i+1, j-1 {
+				want[i], want[j] = want[j], want[i]
+			}
+			if got := entryIDs(rev); !sameIDs(got, want) {
+				t.Errorf("XRevRange() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_XDelAndXTrim(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestStream(streamID(1, 0), streamID(2, 0), streamID(3, 0), streamID(4, 0))})
+
+	if deleted, err := r.XDel("stream", []usecase.StreamID{streamID(2, 0), streamID(9, 0)}); err != nil || deleted != 1 {
+		t.Fatalf("XDel() = %v, %v, want 1", deleted, err)
+	}
+	if removed, err := r.XTrim("stream", usecase.StreamTrim{MaxLen: 1}); err != nil || removed != 2 {
+		t.Fatalf("XTrim() = %v, %v, want 2", removed, err)
+	}
+	if got := streamIDs(t, r); !reflect.DeepEqual(got, []usecase.StreamID{streamID(4, 0)}) {
+		t.Errorf("entries = %v, want [4-0]", got)
+	}
+
+	// the emptied stream keeps its last ID
+	if removed, _ := r.XTrim("stream", usecase.StreamTrim{MaxLen: 0}); removed != 1 {
+		t.Errorf("XTrim() = %v, want 1", removed)
+	}
+	if length, _ := r.XLen("stream"); length != 0 {
+		t.Errorf("XLen() = %v, want 0", length)
+	}
+	if _, _, err := r.XAdd("stream", entryFields(streamID(3, 0)), usecase.XAddOptions{ID: streamID(3, 0)}); err != domain.ErrStreamIDTooSmall {
+		t.Errorf("XAdd() error = %v, want %v", err, domain.ErrStreamIDTooSmall)
+	}
+}
+
+func TestInMemoryRedis_XRead(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestStream(streamID(1, 0), streamID(2, 0)), "list": newDeque("a")})
+
+	got, err := r.XRead(context.Background(), []usecase.StreamRead{
+		{Key: "stream", ID: streamID(1, 0)},
+		{Key: "missing"},
+	}, usecase.XReadOptions{})
+	if err != nil {
+		t.Fatalf("XRead() error = %v", err)
+	}
+	want := []usecase.StreamEntries{{Key: "stream", Entries: []usecase.StreamEntry{{ID: streamID(2, 0), Fields: entryFields(streamID(2, 0))}}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("XRead() = %v, want %v", got, want)
+	}
+
+	// $ reads only the entries added after the call
+	got, err = r.XRead(context.Background(), []usecase.StreamRead{{Key: "stream", New: true}}, usecase.XReadOptions{})
+	if err != nil || got != nil {
+		t.Errorf("XRead($) = %v, %v, want nil", got, err)
+	}
+
+	if _, err := r.XRead(context.Background(), []usecase.StreamRead{{Key: "list"}}, usecase.XReadOptions{}); err != domain.ErrWrongType {
+		t.Errorf("XRead() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
+
+// waitForStreamWaiters waits until count clients are blocked on the stream at key
+func waitForStreamWaiters(t *testing.T, r *InMemoryRedis, key string, count int) {
+	for i := 0; i < 100; i++ {
+		r.mu.Lock()
+		blocked := len(r.streamWaiters[key])
+		r.mu.Unlock()
+		if blocked == count {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("%d clients are not blocked on %q", count, key)
+}
+
+func TestInMemoryRedis_XReadBlocks(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestStream(streamID(1, 0))})
+
+	results := make([]chan []usecase.StreamEntries, 2)
+	for i := range results {
+		results[i] = make(chan []usecase.StreamEntries, 1)
+		go func(result chan []usecase.StreamEntries) {
+			entries, _ := r.XRead(context.Background(), []usecase.StreamRead{{Key: "stream", New: true}},
+				usecase.XReadOptions{Block: true})
+			result <- entries
+		}(results[i])
+	}
+	waitForStreamWaiters(t, r, "stream", 2)
+
+	if _, _, err := r.XAdd("stream", entryFields(streamID(2, 0)), usecase.XAddOptions{ID: streamID(2, 0)}); err != nil {
+		t.Fatalf("XAdd() error = %v", err)
+	}
+
+	// every blocked client gets the entry
+	for _, result := range results {
+		select {
+		case entries := <-result:
+			if len(entries) != 1 || !reflect.DeepEqual(entryIDs(entries[0].Entries), []usecase.StreamID{streamID(2, 0)}) {
+				t.Errorf("XRead() = %v, want [2-0]", entries)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("the blocked client wasn't woken")
+		}
+	}
+
+	entries, err := r.XRead(context.Background(), []usecase.StreamRead{{Key: "stream", New: true}},
+		usecase.XReadOptions{Block: true, Timeout: 10 * time.Millisecond})
+	if err != nil || entries != nil {
+		t.Errorf("XRead() = %v, %v, want nil after the timeout", entries, err)
+	}
+	if len(r.streamWaiters) != 0 {
+		t.Errorf("streamWaiters = %v, want none", r.streamWaiters)
+	}
+}
+
+func TestInMemoryRedis_XGroupCreate(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestStream(streamID(1, 0)), "list": newDeque("a")})
+
+	tests := []struct {
+		name     string
+		key      string
+		id       *usecase.StreamID
+		mkStream bool
+		wantErr  error
+	}{
+		{name: "after the last entry", key: "stream"},
+		{name: "existing group", key: "stream", wantErr: domain.ErrBusyGroup},
+		{name: "missing key", key: "missing", id: streamIDPtr(0, 0), wantErr: domain.ErrStreamKeyRequired},
+		{name: "MKSTREAM", key: "created", id: streamIDPtr(0, 0), mkStream: true},
+		{name: "wrong type", key: "list", mkStream: true, wantErr: domain.ErrWrongType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.XGroupCreate(tt.key, "group", tt.id, tt.mkStream)
+			if err != tt.wantErr {
+				t.Fatalf("XGroupCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	s, _ := r.loadStream("stream")
+	if got := s.groups["group"].lastID; got != streamID(1, 0) {
+		t.Errorf("lastID = %v, want 1-0", got)
+	}
+	if length, err := r.XLen("created"); err != nil || length != 0 {
+		t.Errorf("XLen() = %v, %v, want an empty stream", length, err)
+	}
+}
+
+func readGroup(t *testing.T, r *InMemoryRedis, consumer string, read usecase.StreamRead, count int) []usecase.StreamEntry {
+	result, err := r.XReadGroup(context.Background(), "group", consumer, []usecase.StreamRead{read}, usecase.XReadOptions{Count: count})
+	if err != nil {
+		t.Fatalf("XReadGroup() error = %v", err)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result[0].Entries
+}
+
+func TestInMemoryRedis_XReadGroup(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream(streamID(1, 0), streamID(2, 0), streamID(3, 0))})
+	newEntries := usecase.StreamRead{Key: "stream", New: true}
+
+	// every entry is delivered to a single consumer
+	if got := entryIDs(readGroup(t, r, "alice", newEntries, 2)); !reflect.DeepEqual(got, []usecase.StreamID{streamID(1, 0), streamID(2, 0)}) {
+		t.Errorf("alice read %v, want [1-0 2-0]", got)
+	}
+	if got := entryIDs(readGroup(t, r, "bob", newEntries, 0)); !reflect.DeepEqual(got, []usecase.StreamID{streamID(3, 0)}) {
+		t.Errorf("bob read %v, want [3-0]", got)
+	}
+	if got := readGroup(t, r, "bob", newEntries, 0); got != nil {
+		t.Errorf("bob read %v, want nothing", got)
+	}
+
+	if acked, err := r.XAck("stream", "group", []usecase.StreamID{streamID(1, 0), streamID(3, 0), streamID(9, 0)}); err != nil || acked != 2 {
+		t.Fatalf("XAck() = %v, %v, want 2", acked, err)
+	}
+
+	// the history of alice has the pending entry, deleted from the stream
+	if _, err := r.XDel("stream", []usecase.StreamID{streamID(2, 0)}); err != nil {
+		t.Fatalf("XDel() error = %v", err)
+	}
+	history := readGroup(t, r, "alice", usecase.StreamRead{Key: "stream"}, 0)
+	if want := []usecase.StreamEntry{{ID: streamID(2, 0)}}; !reflect.DeepEqual(history, want) {
+		t.Errorf("alice history = %v, want %v", history, want)
+	}
+	if history := readGroup(t, r, "bob", usecase.StreamRead{Key: "stream"}, 0); history == nil || len(history) != 0 {
+		t.Errorf("bob history = %v, want empty", history)
+	}
+
+	pending, err := r.XPending("stream", "group", usecase.XPendingOptions{End: streamID(9, 0), Count: 10})
+	if err != nil {
+		t.Fatalf("XPending() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].Consumer != "alice" || pending[0].Deliveries != 2 {
+		t.Errorf("XPending() = %+v, want 2-0 delivered to alice twice", pending)
+	}
+
+	if _, err := r.XReadGroup(context.Background(), "missing", "alice", []usecase.StreamRead{newEntries}, usecase.XReadOptions{}); err != domain.ErrNoGroup {
+		t.Errorf("XReadGroup() error = %v, want %v", err, domain.ErrNoGroup)
+	}
+}
+
+func TestInMemoryRedis_XReadGroupNoAck(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream(streamID(1, 0))})
+	_, err := r.XReadGroup(context.Background(), "group", "alice", []usecase.StreamRead{{Key: "stream", New: true}},
+		usecase.XReadOptions{NoAck: true})
+	if err != nil {
+		t.Fatalf("XReadGroup() error = %v", err)
+	}
+
+	summary, _ := r.XPendingSummary("stream", "group")
+	if summary.Count != 0 {
+		t.Errorf("pending = %v, want none", summary.Count)
+	}
+}
+
+func TestInMemoryRedis_XReadGroupBlocks(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream()})
+
+	done := make(chan []usecase.StreamEntries, 1)
+	go func() {
+		entries, _ := r.XReadGroup(context.Background(), "group", "alice", []usecase.StreamRead{{Key: "stream", New: true}},
+			usecase.XReadOptions{Block: true, Timeout: time.Second})
+		done <- entries
+	}()
+	waitForStreamWaiters(t, r, "stream", 1)
+
+	if _, _, err := r.XAdd("stream", entryFields(streamID(1, 0)), usecase.XAddOptions{ID: streamID(1, 0)}); err != nil {
+		t.Fatalf("XAdd() error = %v", err)
+	}
+	entries := <-done
+	if len(entries) != 1 || !reflect.DeepEqual(entryIDs(entries[0].Entries), []usecase.StreamID{streamID(1, 0)}) {
+		t.Errorf("XReadGroup() = %v, want [1-0]", entries)
+	}
+
+	summary, _ := r.XPendingSummary("stream", "group")
+	want := usecase.PendingSummary{
+		Count:     1,
+		Smallest:  streamID(1, 0),
+		Greatest:  streamID(1, 0),
+		Consumers: []usecase.ConsumerPending{{Name: "alice", Pending: 1}},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("XPendingSummary() = %+v, want %+v", summary, want)
+	}
+}
+
+// idle makes the pending entries of the group idle for the duration
+func idle(r *InMemoryRedis, duration time.Duration) {
+	s, _ := r.loadStream("stream")
+	for _, p := range s.groups["group"].pending {
+		p.delivered = time.Now().Add(-duration)
+	}
+}
+
+func TestInMemoryRedis_XClaim(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream(streamID(1, 0), streamID(2, 0), streamID(3, 0))})
+	readGroup(t, r, "alice", usecase.StreamRead{Key: "stream", New: true}, 0)
+	idle(r, time.Minute)
+
+	if _, err := r.XDel("stream", []usecase.StreamID{streamID(3, 0)}); err != nil {
+		t.Fatalf("XDel() error = %v", err)
+	}
+
+	// 1-0 is idle long enough, 3-0 is deleted and 9-0 isn't pending
+	ids := []usecase.StreamID{streamID(1, 0), streamID(3, 0), streamID(9, 0)}
+	claimed, err := r.XClaim("stream", "group", "bob", 30*time.Second, ids, usecase.XClaimOptions{})
+	if err != nil {
+		t.Fatalf("XClaim() error = %v", err)
+	}
+	if got := entryIDs(claimed); !reflect.DeepEqual(got, []usecase.StreamID{streamID(1, 0)}) {
+		t.Errorf("XClaim() = %v, want [1-0]", got)
+	}
+
+	// the claimed entry isn't idle anymore
+	claimed, _ = r.XClaim("stream", "group", "carol", 30*time.Second, ids, usecase.XClaimOptions{})
+	if len(claimed) != 0 {
+		t.Errorf("XClaim() = %v, want nothing", claimed)
+	}
+
+	pending, _ := r.XPending("stream", "group", usecase.XPendingOptions{End: streamID(9, 0), Count: 10})
+	want := []usecase.PendingEntry{
+		{ID: streamID(1, 0), Consumer: "bob", Deliveries: 2},
+		{ID: streamID(2, 0), Consumer: "alice", Deliveries: 1},
+	}
+	for i := range pending {
+		pending[i].Idle = 0
+	}
+	if !reflect.DeepEqual(pending, want) {
+		t.Errorf("XPending() = %+v, want %+v", pending, want)
+	}
+}
+
+func TestInMemoryRedis_XAutoClaim(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream(streamID(1, 0), streamID(2, 0), streamID(3, 0), streamID(4, 0))})
+	readGroup(t, r, "alice", usecase.StreamRead{Key: "stream", New: true}, 0)
+	idle(r, time.Minute)
+	if _, err := r.XDel("stream", []usecase.StreamID{streamID(2, 0)}); err != nil {
+		t.Fatalf("XDel() error = %v", err)
+	}
+
+	result, err := r.XAutoClaim("stream", "group", "bob", time.Second, streamID(0, 0), 2, false)
+	if err != nil {
+		t.Fatalf("XAutoClaim() error = %v", err)
+	}
+	if got := entryIDs(result.Claimed); !reflect.DeepEqual(got, []usecase.StreamID{streamID(1, 0), streamID(3, 0)}) {
+		t.Errorf("claimed = %v, want [1-0 3-0]", got)
+	}
+	if !reflect.DeepEqual(result.Deleted, []usecase.StreamID{streamID(2, 0)}) || result.Next != streamID(4, 0) {
+		t.Errorf("deleted = %v, next = %v, want [2-0], 4-0", result.Deleted, result.Next)
+	}
+
+	result, _ = r.XAutoClaim("stream", "group", "bob", time.Second, result.Next, 2, true)
+	if got := entryIDs(result.Claimed); !reflect.DeepEqual(got, []usecase.StreamID{streamID(4, 0)}) || result.Next != streamID(0, 0) {
+		t.Errorf("claimed = %v, next = %v, want [4-0], 0-0", got, result.Next)
+	}
+
+	// JUSTID doesn't count the delivery
+	pending, _ := r.XPending("stream", "group", usecase.XPendingOptions{Start: streamID(4, 0), End: streamID(4, 0), Count: 1})
+	if len(pending) != 1 || pending[0].Consumer != "bob" || pending[0].Deliveries != 1 {
+		t.Errorf("XPending() = %+v, want 4-0 of bob delivered once", pending)
+	}
+}
+
+func TestInMemoryRedis_XGroupConsumers(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"stream": newTestGroupStream(streamID(1, 0), streamID(2, 0))})
+	readGroup(t, r, "alice", usecase.StreamRead{Key: "stream", New: true}, 1)
+	readGroup(t, r, "bob", usecase.StreamRead{Key: "stream", New: true}, 1)
+
+	if created, _ := r.XGroupCreateConsumer("stream", "group", "alice"); created {
+		t.Error("XGroupCreateConsumer() created an existing consumer")
+	}
+	if deleted, err := r.XGroupDelConsumer("stream", "group", "alice"); err != nil || deleted != 1 {
+		t.Fatalf("XGroupDelConsumer() = %v, %v, want 1", deleted, err)
+	}
+
+	summary, _ := r.XPendingSummary("stream", "group")
+	if summary.Count != 1 || summary.Consumers[0].Name != "bob" {
+		t.Errorf("XPendingSummary() = %+v, want the entry of bob", summary)
+	}
+
+	// SETID makes the group deliver the entries again
+	if err := r.XGroupSetID("stream", "group", streamIDPtr(0, 0)); err != nil {
+		t.Fatalf("XGroupSetID() error = %v", err)
+	}
+	if got := entryIDs(readGroup(t, r, "carol", usecase.StreamRead{Key: "stream", New: true}, 0)); len(got) != 2 {
+		t.Errorf("carol read %v, want both entries", got)
+	}
+
+	if destroyed, err := r.XGroupDestroy("stream", "group"); err != nil || !destroyed {
+		t.Fatalf("XGroupDestroy() = %v, %v, want true", destroyed, err)
+	}
+	if _, err := r.XPendingSummary("stream", "group"); err != domain.ErrNoGroup {
+		t.Errorf("XPendingSummary() error = %v, want %v", err, domain.ErrNoGroup)
+	}
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxStringLength is the Redis limit of a string value, 512MB
+const maxStringLength = 512 * 1024 * 1024
+
+// stringOf returns the value if it's a string. Bitmaps are strings too, they are stored as byte slices
+// once modified by a bit command, so SETBIT doesn't copy the whole value.
+func stringOf(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// updateString replaces the string stored at key with the result of update, keeping the expiration.
+// update gets the current value and whether the key exists, the key isn't stored if update fails.
+func (r *InMemoryRedis) updateString(key string, update func(value string, exists bool) (string, error)) (string, error) {
+	val, exists := r.load(key)
+	current := ""
+	if exists {
+		var ok bool
+		current, ok = stringOf(val.value)
+		if !ok {
+			return "", domain.ErrWrongType
+		}
+	}
+
+	value, err := update(current, exists)
+	if err != nil {
+		return "", err
+	}
+
+	r.store.Store(key, storeValue{
+		value:  value,
+		expiry: val.expiry,
+	})
+	return value, nil
+}
+
+// GetEx is Get which updates the expiration of the key
+func (r *InMemoryRedis) GetEx(key string, options usecase.GetExOptions) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	val, exists := r.load(key)
+	if !exists {
+		return "", false, nil
+	}
+
+	value, ok := stringOf(val.value)
+	if !ok {
+		return "", false, domain.ErrWrongType
+	}
+
+	switch {
+	case options.Persist:
+		val.expiry = time.Time{}
+	case !options.Expiry.IsZero():
+		val.expiry = options.Expiry
+	default:
+		return value, true, nil
+	}
+
+	r.store.Store(key, val)
+	return value, true, nil
+}
+
+// GetDel is Get which deletes the key
+func (r *InMemoryRedis) GetDel(key string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok, err := r.loadString(key)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	r.del(key)
+	return value, true, nil
+}
+
+// MGet returns the values of the keys, nil for the missing keys and the keys holding another type
+func (r *InMemoryRedis) MGet(keys []string) []*string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*string, len(keys))
+	for i, key := range keys {
+		if value, ok, err := r.loadString(key); ok && err == nil {
+			result[i] = &value
+		}
+	}
+	return result
+}
+
+// MSet sets all the keys atomically, the expiration of the keys is cleared as by Set
+func (r *InMemoryRedis) MSet(pairs []usecase.KeyValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mset(pairs)
+}
+
+// MSetNX is MSet which sets nothing if any of the keys exists, the returned flag is true if the keys were set
+func (r *InMemoryRedis) MSetNX(pairs []usecase.KeyValue) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pair := range pairs {
+		if _, exists := r.load(pair.Key); exists {
+			return false
+		}
+	}
+
+	r.mset(pairs)
+	return true
+}
+
+func (r *InMemoryRedis) mset(pairs []usecase.KeyValue) {
+	for _, pair := range pairs {
+		r.store.Store(pair.Key, storeValue{
+			value: pair.Value,
+		})
+	}
+}
+
+// IncrBy adds the increment to the integer stored at key, a missing key is treated as 0
+func (r *InMemoryRedis) IncrBy(key string, increment int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result int64
+	_, err := r.updateString(key, func(value string, exists bool) (string, error) {
+		if exists {
+			current, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return "", domain.ErrNotInteger
+			}
+			result = current
+		}
+
+		if (increment > 0 && result > math.MaxInt64-increment) || (increment < 0 && result < math.MinInt64-increment) {
+			return "", domain.ErrOverflow
+		}
+		result += increment
+		return strconv.FormatInt(result, 10), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// IncrByFloat adds the increment to the number stored at key, a missing key is treated as 0
+func (r *InMemoryRedis) IncrByFloat(key string, increment float64) (float64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result float64
+	_, err := r.updateString(key, func(value string, exists bool) (string, error) {
+		if exists {
+			current, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(current) || math.IsInf(current, 0) {
+				return "", domain.ErrNotFloat
+			}
+			result = current
+		}
+
+		result += increment
+		if math.IsNaN(result) || math.IsInf(result, 0) {
+			return "", domain.ErrIncrNaNOrInfinity
+		}
+		return strconv.FormatFloat(result, 'f', -1, 64), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// Append appends the value to the string stored at key and returns the new length
+func (r *InMemoryRedis) Append(key string, value string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result, err := r.updateString(key, func(current string, _ bool) (string, error) {
+		if len(current)+len(value) > maxStringLength {
+			return "", domain.ErrStringTooLong
+		}
+		return current + value, nil
+	})
+	return len(result), err
+}
+
+func (r *InMemoryRedis) StrLen(key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, _, err := r.loadString(key)
+	return len(value), err
+}
+
+// GetRange returns the substring from start to end inclusive, negative offsets count from the end
+func (r *InMemoryRedis) GetRange(key string, start int, end int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, _, err := r.loadString(key)
+	if err != nil {
+		return "", err
+	}
+
+	// unlike LRANGE a negative end past the beginning is clamped to the first byte
+	if start < 0 {
+		start += len(value)
+	}
+	if end < 0 {
+		end += len(value)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = 0
+	}
+	if end >= len(value) {
+		end = len(value) - 1
+	}
+	if start > end {
+		return "", nil
+	}
+	return value[start : end+1], nil
+}
+
+// SetRange overwrites the string stored at key from the offset, padding it with zero bytes if needed,
+// and returns the new length
+func (r *InMemoryRedis) SetRange(key string, offset int, value string) (int, error) {
+	if offset < 0 {
+		return 0, domain.ErrOffsetOutOfRange
+	}
+	if offset > maxStringLength-len(value) {
+		return 0, domain.ErrStringTooLong
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if value == "" {
+		// nothing is written, so a missing key isn't created
+		current, _, err := r.loadString(key)
+		return len(current), err
+	}
+
+	result, err := r.updateString(key, func(current string, _ bool) (string, error) {
+		if len(current) < offset {
+			current += strings.Repeat("\x00", offset-len(current))
+		}
+		if len(current) <= offset+len(value) {
+			return current[:offset] + value, nil
+		}
+		return current[:offset] + value + current[offset+len(value):], nil
+	})
+	return len(result), err
+}
+
+// loadString returns the string stored at key, the flag is false if the key doesn't exist
+func (r *InMemoryRedis) loadString(key string) (string, bool, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return "", false, nil
+	}
+
+	value, ok := stringOf(val.value)
+	if !ok {
+		return "", false, domain.ErrWrongType
+	}
+	return value, true, nil
+}
@@ -0,0 +1,472 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestInMemoryRedis_IncrBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		increment int64
+		want      int64
+		wantErr   error
+	}{
+		{
+			name:      "missing key starts from 0",
+			values:    map[string]interface{}{},
+			increment: 1,
+			want:      1,
+		},
+		{
+			name:      "decrements",
+			values:    map[string]interface{}{"counter": "10"},
+			increment: -11,
+			want:      -1,
+		},
+		{
+			name:      "not an integer",
+			values:    map[string]interface{}{"counter": "1.5"},
+			increment: 1,
+			wantErr:   domain.ErrNotInteger,
+		},
+		{
+			name:      "overflow",
+			values:    map[string]interface{}{"counter": strconv.FormatInt(math.MaxInt64, 10)},
+			increment: 1,
+			wantErr:   domain.ErrOverflow,
+		},
+		{
+			name:      "wrong type",
+			values:    map[string]interface{}{"counter": newDeque("1")},
+			increment: 1,
+			wantErr:   domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.IncrBy("counter", tt.increment)
+			if err != tt.wantErr {
+				t.Fatalf("IncrBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("IncrBy() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.Get("counter"); value != strconv.FormatInt(tt.want, 10) {
+				t.Errorf("Get() = %q, want %d", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_IncrByKeepsExpiration(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{})
+	expiry := time.Now().Add(time.Hour)
+	r.store.Store("counter", storeValue{value: "1", expiry: expiry})
+
+	if _, err := r.IncrBy("counter", 1); err != nil {
+		t.Fatalf("IncrBy() error = %v", err)
+	}
+
+	val, _ := r.store.Load("counter")
+	if got := val.(storeValue).expiry; !got.Equal(expiry) {
+		t.Errorf("expiry = %v, want %v", got, expiry)
+	}
+}
+
+func TestInMemoryRedis_IncrByFloat(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		increment float64
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "missing key starts from 0",
+			values:    map[string]interface{}{},
+			increment: 0.5,
+			want:      "0.5",
+		},
+		{
+			name:      "integer value",
+			values:    map[string]interface{}{"counter": "10"},
+			increment: -2.25,
+			want:      "7.75",
+		},
+		{
+			name:      "not a float",
+			values:    map[string]interface{}{"counter": "ten"},
+			increment: 1,
+			wantErr:   domain.ErrNotFloat,
+		},
+		{
+			name:      "infinity",
+			values:    map[string]interface{}{"counter": strconv.FormatFloat(math.MaxFloat64, 'f', -1, 64)},
+			increment: math.MaxFloat64,
+			wantErr:   domain.ErrIncrNaNOrInfinity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			_, err := r.IncrByFloat("counter", tt.increment)
+			if err != tt.wantErr {
+				t.Fatalf("IncrByFloat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if value, _, _ := r.Get("counter"); value != tt.want {
+				t.Errorf("Get() = %q, want %q", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_AppendAndStrLen(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("a")})
+
+	if got, _ := r.Append("key", "Hello"); got != 5 {
+		t.Errorf("Append() = %v, want 5", got)
+	}
+	if got, _ := r.Append("key", " World"); got != 11 {
+		t.Errorf("Append() = %v, want 11", got)
+	}
+	if got, _ := r.StrLen("key"); got != 11 {
+		t.Errorf("StrLen() = %v, want 11", got)
+	}
+	if got, _ := r.StrLen("missing"); got != 0 {
+		t.Errorf("StrLen() of a missing key = %v, want 0", got)
+	}
+	if _, err := r.Append("list", "b"); err != domain.ErrWrongType {
+		t.Errorf("Append() error = %v, want %v", err, domain.ErrWrongType)
+	}
+}
+
+func TestInMemoryRedis_GetRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int
+		end   int
+		want  string
+	}{
+		{name: "prefix", start: 0, end: 3, want: "This"},
+		{name: "negative offsets", start: -3, end: -1, want: "ing"},
+		{name: "whole string", start: 0, end: -1, want: "This is a string"},
+		{name: "end past the string", start: 10, end: 100, want: "string"},
+		{name: "negative end past the beginning", start: 0, end: -100, want: "T"},
+		{name: "start after end", start: 5, end: 3, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{"key": "This is a string"})
+			got, err := r.GetRange("key", tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("GetRange() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetRange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SetRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    map[string]interface{}
+		offset    int
+		value     string
+		want      int
+		wantErr   error
+		wantValue string
+		wantKey   bool
+	}{
+		{
+			name:      "overwrites the middle",
+			values:    map[string]interface{}{"key": "Hello World"},
+			offset:    6,
+			value:     "Redis",
+			want:      11,
+			wantValue: "Hello Redis",
+			wantKey:   true,
+		},
+		{
+			name:      "extends the string",
+			values:    map[string]interface{}{"key": "Hello"},
+			offset:    4,
+			value:     "ooo",
+			want:      7,
+			wantValue: "Hellooo",
+			wantKey:   true,
+		},
+		{
+			name:      "pads a missing key with zero bytes",
+			values:    map[string]interface{}{},
+			offset:    2,
+			value:     "ab",
+			want:      4,
+			wantValue: "\x00\x00ab",
+			wantKey:   true,
+		},
+		{
+			name:   "empty value doesn't create the key",
+			values: map[string]interface{}{},
+			offset: 2,
+		},
+		{
+			name:    "negative offset",
+			values:  map[string]interface{}{},
+			offset:  -1,
+			value:   "a",
+			wantErr: domain.ErrOffsetOutOfRange,
+		},
+		{
+			name:    "too long",
+			values:  map[string]interface{}{},
+			offset:  maxStringLength,
+			value:   "a",
+			wantErr: domain.ErrStringTooLong,
+		},
+		{
+			name:    "offset overflows the length",
+			values:  map[string]interface{}{},
+			offset:  maxInt,
+			value:   "a",
+			wantErr: domain.ErrStringTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			got, err := r.SetRange("key", tt.offset, tt.value)
+			if err != tt.wantErr {
+				t.Fatalf("SetRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SetRange() = %v, want %v", got, tt.want)
+			}
+
+			value, ok, _ := r.Get("key")
+			if ok != tt.wantKey || value != tt.wantValue {
+				t.Errorf("Get() = %q, %v, want %q, %v", value, ok, tt.wantValue, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_SetOptions(t *testing.T) {
+	hour := time.Now().Add(time.Hour)
+	day := time.Now().Add(24 * time.Hour)
+	tests := []struct {
+		name       string
+		value      *storeValue
+		options    usecase.SetOptions
+		want       usecase.SetResult
+		wantErr    error
+		wantValue  interface{}
+		wantExpiry time.Time
+	}{
+		{
+			name:       "clears the expiration",
+			value:      &storeValue{value: "old", expiry: hour},
+			want:       usecase.SetResult{Written: true},
+			wantValue:  "new",
+			wantExpiry: time.Time{},
+		},
+		{
+			name:       "sets the expiration",
+			value:      &storeValue{value: "old", expiry: hour},
+			options:    usecase.SetOptions{Expiry: day},
+			want:       usecase.SetResult{Written: true},
+			wantValue:  "new",
+			wantExpiry: day,
+		},
+		{
+			name:       "keeps the expiration",
+			value:      &storeValue{value: "old", expiry: hour},
+			options:    usecase.SetOptions{KeepTTL: true},
+			want:       usecase.SetResult{Written: true},
+			wantValue:  "new",
+			wantExpiry: hour,
+		},
+		{
+			name:      "NX on an existing key",
+			value:     &storeValue{value: "old"},
+			options:   usecase.SetOptions{NX: true, Get: true},
+			want:      usecase.SetResult{Old: "old", OldExists: true},
+			wantValue: "old",
+		},
+		{
+			name:      "NX on a missing key",
+			options:   usecase.SetOptions{NX: true, Get: true},
+			want:      usecase.SetResult{Written: true},
+			wantValue: "new",
+		},
+		{
+			name:    "XX on a missing key",
+			options: usecase.SetOptions{XX: true},
+			want:    usecase.SetResult{},
+		},
+		{
+			name:      "overwrites another type",
+			value:     &storeValue{value: newDeque("a")},
+			want:      usecase.SetResult{Written: true},
+			wantValue: "new",
+		},
+		{
+			name:    "GET on another type",
+			value:   &storeValue{value: newDeque("a")},
+			options: usecase.SetOptions{Get: true},
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{})
+			if tt.value != nil {
+				r.store.Store("key", *tt.value)
+			}
+
+			got, err := r.Set("key", "new", tt.options)
+			if err != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Set() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantErr != nil || tt.wantValue == nil {
+				return
+			}
+
+			val, _ := r.store.Load("key")
+			if value := val.(storeValue).value; value != tt.wantValue {
+				t.Errorf("value = %v, want %v", value, tt.wantValue)
+			}
+			if expiry := val.(storeValue).expiry; !expiry.Equal(tt.wantExpiry) {
+				t.Errorf("expiry = %v, want %v", expiry, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_GetEx(t *testing.T) {
+	hour := time.Now().Add(time.Hour)
+	day := time.Now().Add(24 * time.Hour)
+	tests := []struct {
+		name       string
+		options    usecase.GetExOptions
+		wantExpiry time.Time
+	}{
+		{name: "keeps the expiration", wantExpiry: hour},
+		{name: "updates the expiration", options: usecase.GetExOptions{Expiry: day}, wantExpiry: day},
+		{name: "persists the key", options: usecase.GetExOptions{Persist: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(map[string]interface{}{})
+			r.store.Store("key", storeValue{value: "value", expiry: hour})
+
+			value, ok, err := r.GetEx("key", tt.options)
+			if err != nil || !ok || value != "value" {
+				t.Fatalf("GetEx() = %q, %v, %v, want value, true, nil", value, ok, err)
+			}
+
+			val, _ := r.store.Load("key")
+			if expiry := val.(storeValue).expiry; !expiry.Equal(tt.wantExpiry) {
+				t.Errorf("expiry = %v, want %v", expiry, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_GetDel(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"key": "value", "list": newDeque("a")})
+
+	if value, ok, err := r.GetDel("key"); err != nil || !ok || value != "value" {
+		t.Errorf("GetDel() = %q, %v, %v, want value, true, nil", value, ok, err)
+	}
+	if _, ok, _ := r.Get("key"); ok {
+		t.Error("the key is not deleted")
+	}
+	if _, ok, err := r.GetDel("key"); err != nil || ok {
+		t.Errorf("GetDel() of a missing key = %v, %v, want false, nil", ok, err)
+	}
+	if _, _, err := r.GetDel("list"); err != domain.ErrWrongType {
+		t.Errorf("GetDel() error = %v, want %v", err, domain.ErrWrongType)
+	}
+	if _, ok := r.store.Load("list"); !ok {
+		t.Error("the list of a wrong type is deleted")
+	}
+}
+
+func TestInMemoryRedis_MGet(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"a": "1", "b": "2", "list": newDeque("x")})
+
+	got := r.MGet([]string{"b", "missing", "list", "a"})
+	want := []interface{}{"2", nil, nil, "1"}
+	if len(got) != len(want) {
+		t.Fatalf("MGet() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if (got[i] == nil) != (want[i] == nil) || (got[i] != nil && *got[i] != want[i]) {
+			t.Errorf("MGet()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestInMemoryRedis_MSet(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"list": newDeque("x")})
+	r.store.Store("a", storeValue{value: "old", expiry: time.Now().Add(time.Hour)})
+
+	r.MSet([]usecase.KeyValue{{Key: "a", Value: "1"}, {Key: "list", Value: "2"}})
+
+	for key, want := range map[string]string{"a": "1", "list": "2"} {
+		val, _ := r.store.Load(key)
+		if got := val.(storeValue); got.value != want || !got.expiry.IsZero() {
+			t.Errorf("%s = %v, expiry %v, want %v without expiration", key, got.value, got.expiry, want)
+		}
+	}
+}
+
+func TestInMemoryRedis_MSetNX(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		want   bool
+	}{
+		{
+			name:   "sets all the keys",
+			values: map[string]interface{}{},
+			want:   true,
+		},
+		{
+			name:   "sets nothing if a key exists",
+			values: map[string]interface{}{"b": newDeque("x")},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.values)
+			if got := r.MSetNX([]usecase.KeyValue{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}); got != tt.want {
+				t.Errorf("MSetNX() = %v, want %v", got, tt.want)
+			}
+
+			_, ok, _ := r.Get("a")
+			if ok != tt.want {
+				t.Errorf("a is set = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,560 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"math"
+	"sort"
+	"strings"
+)
+
+// tsAggregators reduce the samples of a bucket, the samples are never empty
+var tsAggregators = map[string]func(samples []usecase.TSSample) float64{
+	"avg": func(samples []usecase.TSSample) float64 {
+		sum := 0.0
+		for _, sample := range samples {
+			sum += sample.Value
+		}
+		return sum / float64(len(samples))
+	},
+	"sum": func(samples []usecase.TSSample) float64 {
+		sum := 0.0
+		for _, sample := range samples {
+			sum += sample.Value
+		}
+		return sum
+	},
+	"min": func(samples []usecase.TSSample) float64 {
+		min := samples[0].Value
+		for _, sample := range samples[1:] {
+			min = math.Min(min, sample.Value)
+		}
+		return min
+	},
+	"max": func(samples []usecase.TSSample) float64 {
+		max := samples[0].Value
+		for _, sample := range samples[1:] {
+			max = math.Max(max, sample.Value)
+		}
+		return max
+	},
+	"count": func(samples []usecase.TSSample) float64 {
+		return float64(len(samples))
+	},
+	"first": func(samples []usecase.TSSample) float64 {
+		return samples[0].Value
+	},
+	"last": func(samples []usecase.TSSample) float64 {
+		return samples[len(samples)-1].Value
+	},
+}
+
+// tsRule compacts the samples of the series into the destination, a bucket is written
+// when a sample of a later bucket is added. An out of order sample rewrites its closed bucket.
+type tsRule struct {
+	destination string
+	aggregation usecase.TSAggregation
+	// bucket is the start of the open bucket, it's valid with open
+	bucket int64
+	open   bool
+}
+
+// timeSeries keeps the samples sorted by the timestamp, the timestamps are unique
+type timeSeries struct {
+	samples   []usecase.TSSample
+	retention int64
+	labels    []usecase.TSLabel
+	rules     []*tsRule
+	// source is the key of the series compacted into this one
+	source string
+}
+
+func newTimeSeries(options usecase.TSCreateOptions) *timeSeries {
+	return &timeSeries{
+		retention: options.Retention,
+		labels:    options.Labels,
+	}
+}
+
+// minTimestamp is the timestamp of the oldest sample within the retention
+func (s *timeSeries) minTimestamp() int64 {
+	if s.retention == 0 || len(s.samples) == 0 {
+		return 0
+	}
+	return s.samples[len(s.samples)-1].Timestamp - s.retention
+}
+
+// search returns the index of the first sample at or after the timestamp
+func (s *timeSeries) search(timestamp int64) int {
+	return sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].Timestamp >= timestamp
+	})
+}
+
+// add inserts the sample, a sample with the same timestamp is an error
+func (s *timeSeries) add(sample usecase.TSSample) error {
+	if sample.Timestamp < s.minTimestamp() {
+		return domain.ErrTSTooOld
+	}
+
+	// samples usually come in order
+	if n := len(s.samples); n == 0 || s.samples[n-1].Timestamp < sample.Timestamp {
+		s.samples = append(s.samples, sample)
+		return nil
+	}
+
+	i := s.search(sample.Timestamp)
+	if s.samples[i].Timestamp == sample.Timestamp {
+		return domain.ErrTSDuplicate
+	}
+	s.samples = append(s.samples, usecase.TSSample{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = sample
+	return nil
+}
+
+// upsert inserts the sample or replaces the sample with the same timestamp, it's used by the compaction
+func (s *timeSeries) upsert(sample usecase.TSSample) {
+	if i := s.search(sample.Timestamp); i < len(s.samples) && s.samples[i].Timestamp == sample.Timestamp {
+		s.samples[i] = sample
+		return
+	}
+	_ = s.add(sample)
+}
+
+// trim drops the samples older than the retention
+func (s *timeSeries) trim() {
+	if i := s.search(s.minTimestamp()); i > 0 {
+		s.samples = append(s.samples[:0:0], s.samples[i:]...)
+	}
+}
+
+// rangeOf returns the samples from from to to inclusive, the expired samples which aren't trimmed yet are skipped
+func (s *timeSeries) rangeOf(from int64, to int64) []usecase.TSSample {
+	if min := s.minTimestamp(); from < min {
+		from = min
+	}
+	i := s.search(from)
+	j := i + sort.Search(len(s.samples)-i, func(k int) bool {
+		return s.samples[i+k].Timestamp > to
+	})
+	return s.samples[i:j]
+}
+
+// label returns the value of the label, a missing label is empty
+func (s *timeSeries) label(name string) string {
+	for _, label := range s.labels {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// tsBucket is the start of the bucket of the timestamp, the timestamps aren't negative
+func tsBucket(timestamp int64, duration int64) int64 {
+	return timestamp - timestamp%duration
+}
+
+func validateTSAggregation(aggregation *usecase.TSAggregation) error {
+	if aggregation == nil {
+		return nil
+	}
+	if _, ok := tsAggregators[strings.ToLower(aggregation.Type)]; !ok {
+		return domain.ErrTSAggregation
+	}
+	if aggregation.BucketDuration <= 0 {
+		return domain.ErrTSBucketDuration
+	}
+	return nil
+}
+
+// aggregateTS reduces every bucket of the sorted samples to a sample at the start of the bucket
+func aggregateTS(samples []usecase.TSSample, aggregation usecase.TSAggregation) []usecase.TSSample {
+	aggregate := tsAggregators[strings.ToLower(aggregation.Type)]
+
+	var result []usecase.TSSample
+	for i := 0; i < len(samples); {
+		bucket := tsBucket(samples[i].Timestamp, aggregation.BucketDuration)
+		j := i + 1
+		for j < len(samples) && samples[j].Timestamp < bucket+aggregation.BucketDuration {
+			j++
+		}
+		result = append(result, usecase.TSSample{Timestamp: bucket, Value: aggregate(samples[i:j])})
+		i = j
+	}
+	return result
+}
+
+// tsFilter is a TS.MRANGE matcher: label=value, label!=value or with a list label=(a,b).
+// A missing label matches the empty value, so label= matches the series without the label
+// and label!= the series with it.
+type tsFilter struct {
+	label  string
+	values []string
+	equal  bool
+}
+
+func parseTSFilter(filter string) (tsFilter, error) {
+	i := strings.Index(filter, "=")
+	if i < 0 {
+		return tsFilter{}, domain.ErrTSFilter
+	}
+
+	f := tsFilter{label: filter[:i], equal: true}
+	if strings.HasSuffix(f.label, "!") {
+		f.label, f.equal = f.label[:len(f.label)-1], false
+	}
+	if f.label == "" {
+		return tsFilter{}, domain.ErrTSFilter
+	}
+
+	value := filter[i+1:]
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		f.values = strings.Split(value[1:len(value)-1], ",")
+	} else {
+		f.values = []string{value}
+	}
+	return f, nil
+}
+
+// parseTSFilters requires a positive matcher, otherwise every series without some label would match
+func parseTSFilters(filters []string) ([]tsFilter, error) {
+	result := make([]tsFilter, 0, len(filters))
+	positive := false
+	for _, filter := range filters {
+		f, err := parseTSFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		if f.equal && !(len(f.values) == 1 && f.values[0] == "") {
+			positive = true
+		}
+		result = append(result, f)
+	}
+
+	if !positive {
+		return nil, domain.ErrTSFilterMatcher
+	}
+	return result, nil
+}
+
+func (f tsFilter) match(s *timeSeries) bool {
+	value := s.label(f.label)
+	for _, v := range f.values {
+		if v == value {
+			return f.equal
+		}
+	}
+	return !f.equal
+}
+
+func validateTSCreateOptions(options usecase.TSCreateOptions) error {
+	if options.Retention < 0 {
+		return domain.ErrTSRetention
+	}
+	return nil
+}
+
+func validateTSSample(sample usecase.TSSample) error {
+	if sample.Timestamp < 0 {
+		return domain.ErrTSTimestamp
+	}
+	if math.IsNaN(sample.Value) {
+		return domain.ErrTSValue
+	}
+	return nil
+}
+
+// loadTimeSeries returns the time series stored at key, nil if the key doesn't exist
+func (r *InMemoryRedis) loadTimeSeries(key string) (*timeSeries, error) {
+	val, exists := r.load(key)
+	if !exists {
+		return nil, nil
+	}
+
+	s, ok := val.value.(*timeSeries)
+	if !ok {
+		return nil, domain.ErrWrongType
+	}
+	return s, nil
+}
+
+// loadExistingTimeSeries is loadTimeSeries where a missing key is an error
+func (r *InMemoryRedis) loadExistingTimeSeries(key string) (*timeSeries, error) {
+	s, err := r.loadTimeSeries(key)
+	if err == nil && s == nil {
+		return nil, domain.ErrTSKeyNotFound
+	}
+	return s, err
+}
+
+// TSCreate creates an empty time series, the key must not exist
+func (r *InMemoryRedis) TSCreate(key string, options usecase.TSCreateOptions) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateTSCreateOptions(options); err != nil {
+		return err
+	}
+	if _, exists := r.load(key); exists {
+		return domain.ErrTSKeyExists
+	}
+
+	r.store.Store(key, storeValue{
+		value: newTimeSeries(options),
+	})
+	return nil
+}
+
+// TSAdd adds the sample and returns its timestamp. The series is created with the options
+// if the key doesn't exist, otherwise they're ignored.
+func (r *InMemoryRedis) TSAdd(key string, sample usecase.TSSample, options usecase.TSCreateOptions) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateTSCreateOptions(options); err != nil {
+		return 0, err
+	}
+	if err := r.addTSSample(key, sample, options); err != nil {
+		return 0, err
+	}
+	return sample.Timestamp, nil
+}
+
+// TSMAdd adds the samples to existing series, every sample succeeds or fails on its own
+func (r *InMemoryRedis) TSMAdd(samples []usecase.TSKeySample) []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(samples))
+	for i, sample := range samples {
+		if s, err := r.loadExistingTimeSeries(sample.Key); s == nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = r.addTSSample(sample.Key, sample.TSSample, usecase.TSCreateOptions{})
+	}
+	return errs
+}
+
+func (r *InMemoryRedis) addTSSample(key string, sample usecase.TSSample, options usecase.TSCreateOptions) error {
+	if err := validateTSSample(sample); err != nil {
+		return err
+	}
+
+	s, err := r.loadTimeSeries(key)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		s = newTimeSeries(options)
+		r.store.Store(key, storeValue{
+			value: s,
+		})
+	}
+
+	if err := s.add(sample); err != nil {
+		return err
+	}
+	for _, rule := range s.rules {
+		r.compact(s, rule, sample.Timestamp)
+	}
+	return nil
+}
+
+// compact writes the bucket closed by the sample at the timestamp or, for an out of order sample,
+// rewrites the bucket of the sample
+func (r *InMemoryRedis) compact(s *timeSeries, rule *tsRule, timestamp int64) {
+	bucket := tsBucket(timestamp, rule.aggregation.BucketDuration)
+	switch {
+	case !rule.open:
+		rule.bucket, rule.open = bucket, true
+	case bucket > rule.bucket:
+		r.writeTSBucket(s, rule, rule.bucket)
+		rule.bucket = bucket
+	case bucket < rule.bucket:
+		r.writeTSBucket(s, rule, bucket)
+	}
+}
+
+func (r *InMemoryRedis) writeTSBucket(s *timeSeries, rule *tsRule, bucket int64) {
+	samples := s.rangeOf(bucket, bucket+rule.aggregation.BucketDuration-1)
+	if len(samples) == 0 {
+		return
+	}
+
+	// the destination may have been deleted or overwritten
+	destination, err := r.loadTimeSeries(rule.destination)
+	if err != nil || destination == nil {
+		return
+	}
+	destination.upsert(aggregateTS(samples, rule.aggregation)[0])
+}
+
+// TSRange returns the samples from from to to inclusive or, with the aggregation, the buckets of them
+func (r *InMemoryRedis) TSRange(key string, from int64, to int64, options usecase.TSRangeOptions) ([]usecase.TSSample, error) {
+	return r.tsRange(key, from, to, options, false)
+}
+
+// TSRevRange is TSRange from the latest sample
+func (r *InMemoryRedis) TSRevRange(key string, from int64, to int64, options usecase.TSRangeOptions) ([]usecase.TSSample, error) {
+	return r.tsRange(key, from, to, options, true)
+}
+
+func (r *InMemoryRedis) tsRange(key string, from int64, to int64, options usecase.TSRangeOptions, reverse bool) ([]usecase.TSSample, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateTSAggregation(options.Aggregation); err != nil {
+		return nil, err
+	}
+
+	s, err := r.loadExistingTimeSeries(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.query(from, to, options, reverse), nil
+}
+
+// query returns a copy of the samples, so the result isn't changed by the later commands
+func (s *timeSeries) query(from int64, to int64, options usecase.TSRangeOptions, reverse bool) []usecase.TSSample {
+	samples := s.rangeOf(from, to)
+	if options.Aggregation != nil {
+		samples = aggregateTS(samples, *options.Aggregation)
+	}
+
+	result := make([]usecase.TSSample, 0, len(samples))
+	for i := range samples {
+		if options.Count > 0 && len(result) == options.Count {
+			break
+		}
+		if reverse {
+			result = append(result, samples[len(samples)-1-i])
+		} else {
+			result = append(result, samples[i])
+		}
+	}
+	return result
+}
+
+// TSMRange returns the ranges of all the series matching the filters, sorted by the key
+func (r *InMemoryRedis) TSMRange(from int64, to int64, filters []string, options usecase.TSRangeOptions) ([]usecase.TSSeries, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateTSAggregation(options.Aggregation); err != nil {
+		return nil, err
+	}
+	matchers, err := parseTSFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	r.store.Range(func(key, _ interface{}) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+	sort.Strings(keys)
+
+	result := make([]usecase.TSSeries, 0)
+	for _, key := range keys {
+		s, err := r.loadTimeSeries(key)
+		if err != nil || s == nil || !matchTSFilters(s, matchers) {
+			continue
+		}
+
+		series := usecase.TSSeries{Key: key, Samples: s.query(from, to, options, false)}
+		if options.WithLabels {
+			series.Labels = append([]usecase.TSLabel{}, s.labels...)
+		}
+		result = append(result, series)
+	}
+	return result, nil
+}
+
+func matchTSFilters(s *timeSeries, filters []tsFilter) bool {
+	for _, f := range filters {
+		if !f.match(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// TSCreateRule compacts the samples added to the source since now into the destination.
+// A compacted series can't be the source of a rule or the destination of another one.
+func (r *InMemoryRedis) TSCreateRule(source string, destination string, aggregation usecase.TSAggregation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := validateTSAggregation(&aggregation); err != nil {
+		return err
+	}
+	if source == destination {
+		return domain.ErrTSSameKey
+	}
+
+	s, err := r.loadExistingTimeSeries(source)
+	if err != nil {
+		return err
+	}
+	d, err := r.loadExistingTimeSeries(destination)
+	if err != nil {
+		return err
+	}
+
+	if r.isCompacted(s) {
+		return domain.ErrTSRuleSource
+	}
+	if r.isCompacted(d) || len(d.rules) > 0 {
+		return domain.ErrTSRuleDestination
+	}
+
+	aggregation.Type = strings.ToLower(aggregation.Type)
+	s.rules = append(s.rules, &tsRule{destination: destination, aggregation: aggregation})
+	d.source = source
+	return nil
+}
+
+// isCompacted reports whether a rule of the source of the series still writes into it,
+// the source may have been deleted
+func (r *InMemoryRedis) isCompacted(s *timeSeries) bool {
+	if s.source == "" {
+		return false
+	}
+
+	source, err := r.loadTimeSeries(s.source)
+	if err != nil || source == nil {
+		return false
+	}
+	for _, rule := range source.rules {
+		if destination, _ := r.loadTimeSeries(rule.destination); destination == s {
+			return true
+		}
+	}
+	return false
+}
+
+// TSDeleteRule stops the compaction of the source into the destination, the compacted samples stay
+func (r *InMemoryRedis) TSDeleteRule(source string, destination string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.loadExistingTimeSeries(source)
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range s.rules {
+		if rule.destination == destination {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			if d, _ := r.loadTimeSeries(destination); d != nil && d.source == source {
+				d.source = ""
+			}
+			return nil
+		}
+	}
+	return domain.ErrTSRuleNotFound
+}
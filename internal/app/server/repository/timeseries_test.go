@@ -0,0 +1,336 @@
+package repository
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/domain"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+	"reflect"
+	"testing"
+)
+
+func samplesOf(values ...float64) []usecase.TSSample {
+	samples := make([]usecase.TSSample, 0, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		samples = append(samples, usecase.TSSample{Timestamp: int64(values[i]), Value: values[i+1]})
+	}
+	return samples
+}
+
+// newTestTimeSeries returns a series holding the timestamp and value pairs, the timestamps are in the ascending order
+func newTestTimeSeries(options usecase.TSCreateOptions, samples ...float64) *timeSeries {
+	s := newTimeSeries(options)
+	s.samples = samplesOf(samples...)
+	return s
+}
+
+func TestInMemoryRedis_TSAdd(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key    string
+		sample usecase.TSSample
+	}
+	retention := usecase.TSCreateOptions{Retention: 100}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantSamples []usecase.TSSample
+		wantErr     error
+	}{
+		{
+			name:        "appends a sample",
+			fields:      fields{values: map[string]interface{}{"cpu": newTestTimeSeries(retention, 1000, 1)}},
+			args:        args{key: "cpu", sample: usecase.TSSample{Timestamp: 1010, Value: 2}},
+			wantSamples: samplesOf(1000, 1, 1010, 2),
+		},
+		{
+			name:        "inserts an out of order sample",
+			fields:      fields{values: map[string]interface{}{"cpu": newTestTimeSeries(retention, 1000, 1, 1010, 2)}},
+			args:        args{key: "cpu", sample: usecase.TSSample{Timestamp: 1005, Value: 3}},
+			wantSamples: samplesOf(1000, 1, 1005, 3, 1010, 2),
+		},
+		{
+			name:        "creates the key",
+			fields:      fields{},
+			args:        args{key: "memory", sample: usecase.TSSample{Timestamp: 5, Value: 1}},
+			wantSamples: samplesOf(5, 1),
+		},
+		{
+			name:    "duplicate timestamp",
+			fields:  fields{values: map[string]interface{}{"cpu": newTestTimeSeries(retention, 1000, 1, 1005, 3)}},
+			args:    args{key: "cpu", sample: usecase.TSSample{Timestamp: 1005, Value: 4}},
+			wantErr: domain.ErrTSDuplicate,
+		},
+		{
+			name:    "older than the retention",
+			fields:  fields{values: map[string]interface{}{"cpu": newTestTimeSeries(retention, 1000, 1, 1010, 2)}},
+			args:    args{key: "cpu", sample: usecase.TSSample{Timestamp: 909, Value: 4}},
+			wantErr: domain.ErrTSTooOld,
+		},
+		{
+			name:    "negative timestamp",
+			fields:  fields{values: map[string]interface{}{"cpu": newTestTimeSeries(retention, 1000, 1)}},
+			args:    args{key: "cpu", sample: usecase.TSSample{Timestamp: -1, Value: 4}},
+			wantErr: domain.ErrTSTimestamp,
+		},
+		{
+			name:    "wrong type",
+			fields:  fields{values: map[string]interface{}{"string": "a"}},
+			args:    args{key: "string", sample: usecase.TSSample{Timestamp: 1, Value: 1}},
+			wantErr: domain.ErrWrongType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.TSAdd(tt.args.key, tt.args.sample, usecase.TSCreateOptions{})
+			if err != tt.wantErr {
+				t.Fatalf("TSAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.args.sample.Timestamp {
+				t.Errorf("TSAdd() = %d, want %d", got, tt.args.sample.Timestamp)
+			}
+			if samples, _ := r.TSRange(tt.args.key, 0, 2000, usecase.TSRangeOptions{}); !reflect.DeepEqual(samples, tt.wantSamples) {
+				t.Errorf("TSRange() = %v, want %v", samples, tt.wantSamples)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_TSMAdd(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"cpu": newTestTimeSeries(usecase.TSCreateOptions{}, 10, 1), "string": "a"})
+
+	got := r.TSMAdd([]usecase.TSKeySample{
+		{Key: "cpu", TSSample: usecase.TSSample{Timestamp: 20, Value: 2}},
+		{Key: "cpu", TSSample: usecase.TSSample{Timestamp: 10, Value: 3}},
+		{Key: "missing", TSSample: usecase.TSSample{Timestamp: 10, Value: 3}},
+		{Key: "string", TSSample: usecase.TSSample{Timestamp: 10, Value: 3}},
+	})
+	want := []error{nil, domain.ErrTSDuplicate, domain.ErrTSKeyNotFound, domain.ErrWrongType}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TSMAdd() = %v, want %v", got, want)
+	}
+	if _, exists := r.load("missing"); exists {
+		t.Error("TSMAdd() created a missing key")
+	}
+}
+
+func TestInMemoryRedis_TSRange(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		key     string
+		from    int64
+		to      int64
+		options usecase.TSRangeOptions
+	}
+	cpu := fields{values: map[string]interface{}{
+		"cpu": newTestTimeSeries(usecase.TSCreateOptions{}, 0, 1, 5, 5, 9, 3, 10, 2, 12, 8, 19, 4, 25, 6),
+	}}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		reverse bool
+		want    []usecase.TSSample
+		wantErr error
+	}{
+		{name: "all samples", fields: cpu, args: args{key: "cpu", from: 0, to: 100}, want: samplesOf(0, 1, 5, 5, 9, 3, 10, 2, 12, 8, 19, 4, 25, 6)},
+		{name: "inclusive bounds", fields: cpu, args: args{key: "cpu", from: 5, to: 12}, want: samplesOf(5, 5, 9, 3, 10, 2, 12, 8)},
+		{name: "count", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Count: 2}}, want: samplesOf(0, 1, 5, 5)},
+		{name: "reverse with count", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Count: 2}}, reverse: true, want: samplesOf(25, 6, 19, 4)},
+		{name: "empty range", fields: cpu, args: args{key: "cpu", from: 13, to: 18}, want: samplesOf()},
+		{name: "avg", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "avg", BucketDuration: 10}}}, want: samplesOf(0, 3, 10, 14.0/3, 20, 6)},
+		{name: "sum", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "sum", BucketDuration: 10}}}, want: samplesOf(0, 9, 10, 14, 20, 6)},
+		{name: "min", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "MIN", BucketDuration: 10}}}, want: samplesOf(0, 1, 10, 2, 20, 6)},
+		{name: "max", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "max", BucketDuration: 10}}}, want: samplesOf(0, 5, 10, 8, 20, 6)},
+		{name: "count aggregation", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "count", BucketDuration: 10}}}, want: samplesOf(0, 3, 10, 3, 20, 1)},
+		{name: "first", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "first", BucketDuration: 10}}}, want: samplesOf(0, 1, 10, 2, 20, 6)},
+		{name: "last", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "last", BucketDuration: 10}}}, want: samplesOf(0, 3, 10, 4, 20, 6)},
+		{name: "aggregation of a partial bucket", fields: cpu, args: args{key: "cpu", from: 5, to: 12, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "sum", BucketDuration: 10}}}, want: samplesOf(0, 8, 10, 10)},
+		{name: "reverse aggregation with count", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Count: 2, Aggregation: &usecase.TSAggregation{Type: "max", BucketDuration: 10}}}, reverse: true, want: samplesOf(20, 6, 10, 8)},
+		{name: "unknown aggregation", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "median", BucketDuration: 10}}}, wantErr: domain.ErrTSAggregation},
+		{name: "missing key", fields: fields{}, args: args{key: "missing", from: 0, to: 100}, wantErr: domain.ErrTSKeyNotFound},
+		{name: "zero bucket duration", fields: cpu, args: args{key: "cpu", from: 0, to: 100, options: usecase.TSRangeOptions{Aggregation: &usecase.TSAggregation{Type: "avg"}}}, wantErr: domain.ErrTSBucketDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			tsRange := r.TSRange
+			if tt.reverse {
+				tsRange = r.TSRevRange
+			}
+			got, err := tsRange(tt.args.key, tt.args.from, tt.args.to, tt.args.options)
+			if err != tt.wantErr {
+				t.Fatalf("TSRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TSRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryRedis_TSRetention(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{"cpu": newTestTimeSeries(usecase.TSCreateOptions{Retention: 10}, 0, 1, 5, 2, 10, 3)})
+
+	// the samples are hidden as soon as they're older than the retention and dropped by the background deletion
+	if _, err := r.TSAdd("cpu", usecase.TSSample{Timestamp: 12, Value: 4}, usecase.TSCreateOptions{}); err != nil {
+		t.Fatalf("TSAdd() error = %v", err)
+	}
+	got, _ := r.TSRange("cpu", 0, 100, usecase.TSRangeOptions{})
+	if want := samplesOf(5, 2, 10, 3, 12, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("TSRange() = %v, want %v", got, want)
+	}
+
+	val, _ := r.load("cpu")
+	s := val.value.(*timeSeries)
+	if len(s.samples) != 4 {
+		t.Fatalf("samples before the deletion = %d, want 4", len(s.samples))
+	}
+	r.deleteExpired("cpu")
+	if !reflect.DeepEqual(s.samples, samplesOf(5, 2, 10, 3, 12, 4)) {
+		t.Errorf("samples after the deletion = %v, want 3 latest", s.samples)
+	}
+}
+
+func TestInMemoryRedis_TSMRange(t *testing.T) {
+	type fields struct {
+		values map[string]interface{}
+	}
+	type args struct {
+		filters []string
+	}
+	memoryLabels := []usecase.TSLabel{{Name: "metric", Value: "memory"}, {Name: "host", Value: "a"}}
+	values := map[string]interface{}{
+		"cpu:1": newTestTimeSeries(usecase.TSCreateOptions{
+			Labels: []usecase.TSLabel{{Name: "metric", Value: "cpu"}, {Name: "host", Value: "a"}},
+		}, 10, 0, 20, 10),
+		"cpu:2": newTestTimeSeries(usecase.TSCreateOptions{
+			Labels: []usecase.TSLabel{{Name: "metric", Value: "cpu"}, {Name: "host", Value: "b"}, {Name: "test", Value: "1"}},
+		}, 10, 1, 20, 11),
+		"memory:1": newTestTimeSeries(usecase.TSCreateOptions{Labels: memoryLabels}, 10, 2, 20, 12),
+		"string":   "a",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr error
+	}{
+		{name: "equal", fields: fields{values: values}, args: args{filters: []string{"metric=cpu"}}, want: []string{"cpu:1", "cpu:2"}},
+		{name: "several filters", fields: fields{values: values}, args: args{filters: []string{"metric=cpu", "host=b"}}, want: []string{"cpu:2"}},
+		{name: "list", fields: fields{values: values}, args: args{filters: []string{"metric=(cpu,memory)", "host!=b"}}, want: []string{"cpu:1", "memory:1"}},
+		{name: "without the label", fields: fields{values: values}, args: args{filters: []string{"host=a", "test="}}, want: []string{"cpu:1", "memory:1"}},
+		{name: "with the label", fields: fields{values: values}, args: args{filters: []string{"metric=cpu", "test!="}}, want: []string{"cpu:2"}},
+		{name: "not equal list", fields: fields{values: values}, args: args{filters: []string{"metric=cpu", "host!=(a,b)"}}, want: []string{}},
+		{name: "no positive matcher", fields: fields{values: values}, args: args{filters: []string{"host!=a", "test="}}, wantErr: domain.ErrTSFilterMatcher},
+		{name: "invalid filter", fields: fields{values: values}, args: args{filters: []string{"metric"}}, wantErr: domain.ErrTSFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedis(tt.fields.values)
+			got, err := r.TSMRange(0, 100, tt.args.filters, usecase.TSRangeOptions{})
+			if err != tt.wantErr {
+				t.Fatalf("TSMRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			keys := make([]string, 0, len(got))
+			for _, s := range got {
+				keys = append(keys, s.Key)
+			}
+			if err == nil && !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("TSMRange() keys = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+
+	r := newTestRedis(values)
+	got, err := r.TSMRange(0, 100, []string{"metric=memory"}, usecase.TSRangeOptions{
+		WithLabels:  true,
+		Aggregation: &usecase.TSAggregation{Type: "sum", BucketDuration: 100},
+	})
+	want := []usecase.TSSeries{{Key: "memory:1", Labels: memoryLabels, Samples: samplesOf(0, 14)}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("TSMRange() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestInMemoryRedis_TSCompaction(t *testing.T) {
+	r := newTestRedis(map[string]interface{}{
+		"cpu":     newTestTimeSeries(usecase.TSCreateOptions{}),
+		"cpu:avg": newTestTimeSeries(usecase.TSCreateOptions{}),
+		"cpu:max": newTestTimeSeries(usecase.TSCreateOptions{}),
+		"other":   newTestTimeSeries(usecase.TSCreateOptions{}),
+	})
+
+	rules := []struct {
+		name        string
+		source      string
+		destination string
+		aggregation usecase.TSAggregation
+		wantErr     error
+	}{
+		{name: "avg rule", source: "cpu", destination: "cpu:avg", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}},
+		{name: "max rule", source: "cpu", destination: "cpu:max", aggregation: usecase.TSAggregation{Type: "MAX", BucketDuration: 20}},
+		{name: "same key", source: "cpu", destination: "cpu", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}, wantErr: domain.ErrTSSameKey},
+		{name: "destination is compacted", source: "other", destination: "cpu:avg", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}, wantErr: domain.ErrTSRuleDestination},
+		{name: "destination has rules", source: "other", destination: "cpu", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}, wantErr: domain.ErrTSRuleDestination},
+		{name: "source is compacted", source: "cpu:avg", destination: "other", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}, wantErr: domain.ErrTSRuleSource},
+		{name: "missing destination", source: "cpu", destination: "missing", aggregation: usecase.TSAggregation{Type: "avg", BucketDuration: 10}, wantErr: domain.ErrTSKeyNotFound},
+		{name: "unknown aggregation", source: "cpu", destination: "other", aggregation: usecase.TSAggregation{Type: "median", BucketDuration: 10}, wantErr: domain.ErrTSAggregation},
+	}
+	for _, tt := range rules {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.TSCreateRule(tt.source, tt.destination, tt.aggregation); err != tt.wantErr {
+				t.Errorf("TSCreateRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	assertRange := func(key string, want []usecase.TSSample) {
+		t.Helper()
+		got, err := r.TSRange(key, 0, 1000, usecase.TSRangeOptions{})
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("TSRange(%s) = %v, %v, want %v", key, got, err, want)
+		}
+	}
+
+	for _, sample := range samplesOf(1, 2, 5, 4, 12, 6, 25, 1) {
+		if _, err := r.TSAdd("cpu", sample, usecase.TSCreateOptions{}); err != nil {
+			t.Fatalf("TSAdd() error = %v", err)
+		}
+	}
+	// the open buckets [20, 30) and [20, 40) aren't written yet
+	assertRange("cpu:avg", samplesOf(0, 3, 10, 6))
+	assertRange("cpu:max", samplesOf(0, 6))
+
+	// an out of order sample rewrites its closed bucket
+	if _, err := r.TSAdd("cpu", usecase.TSSample{Timestamp: 8, Value: 9}, usecase.TSCreateOptions{}); err != nil {
+		t.Fatalf("TSAdd() error = %v", err)
+	}
+	assertRange("cpu:avg", samplesOf(0, 5, 10, 6))
+	assertRange("cpu:max", samplesOf(0, 9))
+
+	if err := r.TSDeleteRule("cpu", "cpu:max"); err != nil {
+		t.Fatalf("TSDeleteRule() error = %v", err)
+	}
+	if err := r.TSDeleteRule("cpu", "cpu:max"); err != domain.ErrTSRuleNotFound {
+		t.Errorf("TSDeleteRule() error = %v, want %v", err, domain.ErrTSRuleNotFound)
+	}
+	if _, err := r.TSAdd("cpu", usecase.TSSample{Timestamp: 45, Value: 2}, usecase.TSCreateOptions{}); err != nil {
+		t.Fatalf("TSAdd() error = %v", err)
+	}
+	assertRange("cpu:avg", samplesOf(0, 5, 10, 6, 20, 1))
+	assertRange("cpu:max", samplesOf(0, 9))
+
+	// the destination without a rule may become the destination of another one
+	if err := r.TSCreateRule("other", "cpu:max", usecase.TSAggregation{Type: "sum", BucketDuration: 10}); err != nil {
+		t.Errorf("TSCreateRule() error = %v", err)
+	}
+}
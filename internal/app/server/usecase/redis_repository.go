@@ -1,17 +1,151 @@
 package usecase
 
+import (
+	"context"
+	"time"
+)
+
 type RedisStore interface {
-	Set(key string, value string)
+	Set(key string, value string, options SetOptions) (SetResult, error)
 	Get(key string) (string, bool, error)
+	GetEx(key string, options GetExOptions) (string, bool, error)
+	GetDel(key string) (string, bool, error)
+	MGet(keys []string) []*string
+	MSet(pairs []KeyValue)
+	MSetNX(pairs []KeyValue) bool
+	IncrBy(key string, increment int64) (int64, error)
+	IncrByFloat(key string, increment float64) (float64, error)
+	Append(key string, value string) (int, error)
+	StrLen(key string) (int, error)
+	GetRange(key string, start int, end int) (string, error)
+	SetRange(key string, offset int, value string) (int, error)
+	SetBit(key string, offset int64, value int) (int, error)
+	GetBit(key string, offset int64) (int, error)
+	BitCount(key string, rng *BitRange) (int64, error)
+	BitPos(key string, bit int, rng *BitRange) (int64, error)
+	BitOp(operation BitOperation, destination string, keys []string) (int, error)
+	BitField(key string, ops []BitFieldOp) ([]*int64, error)
 	Del(key string) bool
 	Keys(pattern string) ([]string, error)
+	ScanKeys(pattern string, callback func(key string) bool) error
 
 	HGet(key string, field string) (string, bool, error)
-	HSet(key string, field string, value string) error
+	HSet(key string, pairs []FieldValue) (int, error)
+	HSetNX(key string, field string, value string) (bool, error)
+	HDel(key string, fields []string) (int, error)
+	HGetAll(key string) ([]FieldValue, error)
+	HKeys(key string) ([]string, error)
+	HVals(key string) ([]string, error)
+	HLen(key string) (int, error)
+	HExists(key string, field string) (bool, error)
+	HMGet(key string, fields []string) ([]*string, error)
+	HIncrBy(key string, field string, increment int64) (int64, error)
 
 	LGet(key string, index int) (string, error)
 	LSet(key string, index int, value string) error
-	LPush(key string, value string) (int, error)
+	LPush(key string, values []string) (int, error)
 
 	Expire(key string, duration int) bool
+
+	SAdd(key string, members []string) (int, error)
+	SRem(key string, members []string) (int, error)
+	SMembers(key string) ([]string, error)
+	SIsMember(key string, member string) (bool, error)
+	SCard(key string) (int, error)
+	SPop(key string, count int) ([]string, error)
+	SRandMember(key string, count int) ([]string, error)
+	SInter(keys []string) ([]string, error)
+	SUnion(keys []string) ([]string, error)
+	SDiff(keys []string) ([]string, error)
+	SInterStore(destination string, keys []string) (int, error)
+	SUnionStore(destination string, keys []string) (int, error)
+	SDiffStore(destination string, keys []string) (int, error)
+
+	ZAdd(key string, members []ScoredMember, options ZAddOptions) (int, error)
+	ZAddIncr(key string, member string, increment float64, options ZAddOptions) (float64, bool, error)
+	ZIncrBy(key string, increment float64, member string) (float64, error)
+	ZRem(key string, members []string) (int, error)
+	ZScore(key string, member string) (float64, bool, error)
+	ZCard(key string) (int, error)
+	ZRank(key string, member string) (int, bool, error)
+	ZRevRank(key string, member string) (int, bool, error)
+	ZRange(key string, query ZRangeQuery) ([]ScoredMember, error)
+	ZCount(key string, min string, max string) (int, error)
+	ZPopMin(key string, count int) ([]ScoredMember, error)
+	ZPopMax(key string, count int) ([]ScoredMember, error)
+	ZUnionStore(destination string, keys []string, options ZStoreOptions) (int, error)
+	ZInterStore(destination string, keys []string, options ZStoreOptions) (int, error)
+
+	RPush(key string, values []string) (int, error)
+	LPushX(key string, values []string) (int, error)
+	RPushX(key string, values []string) (int, error)
+	LPop(key string, count int) ([]string, error)
+	RPop(key string, count int) ([]string, error)
+	LRange(key string, start int, stop int) ([]string, error)
+	LLen(key string) (int, error)
+	LIndex(key string, index int) (string, bool, error)
+	LInsert(key string, before bool, pivot string, value string) (int, error)
+	LRem(key string, count int, value string) (int, error)
+	LTrim(key string, start int, stop int) error
+	LPos(key string, element string, options LPosOptions) ([]int, error)
+	LMove(source string, destination string, from ListSide, to ListSide) (string, bool, error)
+
+	BLPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error)
+	BRPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error)
+	BLMove(ctx context.Context, source string, destination string, from ListSide, to ListSide, timeout time.Duration) (string, bool, error)
+
+	PFAdd(key string, elements []string) (bool, error)
+	PFCount(keys []string) (int64, error)
+	PFMerge(destination string, keys []string) error
+
+	XAdd(key string, fields []FieldValue, options XAddOptions) (StreamID, bool, error)
+	XRange(key string, start StreamID, end StreamID, count int) ([]StreamEntry, error)
+	XRevRange(key string, end StreamID, start StreamID, count int) ([]StreamEntry, error)
+	XLen(key string) (int, error)
+	XDel(key string, ids []StreamID) (int, error)
+	XTrim(key string, trim StreamTrim) (int, error)
+	XRead(ctx context.Context, reads []StreamRead, options XReadOptions) ([]StreamEntries, error)
+	XGroupCreate(key string, group string, id *StreamID, mkStream bool) error
+	XGroupSetID(key string, group string, id *StreamID) error
+	XGroupDestroy(key string, group string) (bool, error)
+	XGroupCreateConsumer(key string, group string, consumer string) (bool, error)
+	XGroupDelConsumer(key string, group string, consumer string) (int, error)
+	XReadGroup(ctx context.Context, group string, consumer string, reads []StreamRead, options XReadOptions) ([]StreamEntries, error)
+	XAck(key string, group string, ids []StreamID) (int, error)
+	XPendingSummary(key string, group string) (PendingSummary, error)
+	XPending(key string, group string, options XPendingOptions) ([]PendingEntry, error)
+	XClaim(key string, group string, consumer string, minIdle time.Duration, ids []StreamID, options XClaimOptions) ([]StreamEntry, error)
+	XAutoClaim(key string, group string, consumer string, minIdle time.Duration, start StreamID, count int, justID bool) (XAutoClaimResult, error)
+
+	GeoAdd(key string, members []GeoMember, options ZAddOptions) (int, error)
+	GeoPos(key string, members []string) ([]*GeoPosition, error)
+	GeoDist(key string, first string, second string, unit float64) (float64, bool, error)
+	GeoHash(key string, members []string) ([]*string, error)
+	GeoSearch(key string, query GeoSearchQuery) ([]GeoLocation, error)
+	GeoSearchStore(destination string, key string, query GeoSearchQuery, storeDist bool) (int, error)
+
+	JSONSet(key string, path string, value string, options JSONSetOptions) (bool, error)
+	JSONGet(key string, paths []string) (string, bool, error)
+	JSONDel(key string, path string) (int, error)
+	JSONType(key string, path string) ([]string, error)
+	JSONNumIncrBy(key string, path string, increment string) ([]*string, error)
+	JSONArrAppend(key string, path string, values []string) ([]*int, error)
+	JSONObjKeys(key string, path string) ([][]string, error)
+
+	BFReserve(key string, options BloomOptions) error
+	BFAdd(key string, items []string) ([]bool, error)
+	BFExists(key string, items []string) ([]bool, error)
+	CFReserve(key string, options CuckooOptions) error
+	CFAdd(key string, item string, nx bool) (bool, error)
+	CFDel(key string, item string) (bool, error)
+	CFExists(key string, items []string) ([]bool, error)
+
+	TSCreate(key string, options TSCreateOptions) error
+	TSAdd(key string, sample TSSample, options TSCreateOptions) (int64, error)
+	TSMAdd(samples []TSKeySample) []error
+	TSRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error)
+	TSRevRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error)
+	TSMRange(from int64, to int64, filters []string, options TSRangeOptions) ([]TSSeries, error)
+	TSCreateRule(source string, destination string, aggregation TSAggregation) error
+	TSDeleteRule(source string, destination string) error
 }
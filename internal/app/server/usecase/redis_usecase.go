@@ -1,19 +1,153 @@
 package usecase
 
+import (
+	"context"
+	"time"
+)
+
 type RedisUsecase interface {
-	Set(key string, value string)
+	Set(key string, value string, options SetOptions) (SetResult, error)
 	Get(key string) (string, bool, error)
+	GetEx(key string, options GetExOptions) (string, bool, error)
+	GetDel(key string) (string, bool, error)
+	MGet(keys []string) []*string
+	MSet(pairs []KeyValue)
+	MSetNX(pairs []KeyValue) bool
+	IncrBy(key string, increment int64) (int64, error)
+	IncrByFloat(key string, increment float64) (float64, error)
+	Append(key string, value string) (int, error)
+	StrLen(key string) (int, error)
+	GetRange(key string, start int, end int) (string, error)
+	SetRange(key string, offset int, value string) (int, error)
+	SetBit(key string, offset int64, value int) (int, error)
+	GetBit(key string, offset int64) (int, error)
+	BitCount(key string, rng *BitRange) (int64, error)
+	BitPos(key string, bit int, rng *BitRange) (int64, error)
+	BitOp(operation BitOperation, destination string, keys []string) (int, error)
+	BitField(key string, ops []BitFieldOp) ([]*int64, error)
 	Del(key string) bool
 	Keys(pattern string) ([]string, error)
+	ScanKeys(pattern string, callback func(key string) bool) error
 
 	HGet(key string, field string) (string, bool, error)
 	HSet(key string, pairs []FieldValue) (int, error)
+	HSetNX(key string, field string, value string) (bool, error)
+	HDel(key string, fields []string) (int, error)
+	HGetAll(key string) ([]FieldValue, error)
+	HKeys(key string) ([]string, error)
+	HVals(key string) ([]string, error)
+	HLen(key string) (int, error)
+	HExists(key string, field string) (bool, error)
+	HMGet(key string, fields []string) ([]*string, error)
+	HIncrBy(key string, field string, increment int64) (int64, error)
 
 	LGet(key string, index int) (string, error)
 	LSet(key string, index int, value string) error
 	LPush(key string, values []string) (int, error)
 
 	Expire(key string, duration int) bool
+
+	SAdd(key string, members []string) (int, error)
+	SRem(key string, members []string) (int, error)
+	SMembers(key string) ([]string, error)
+	SIsMember(key string, member string) (bool, error)
+	SCard(key string) (int, error)
+	SPop(key string, count int) ([]string, error)
+	SRandMember(key string, count int) ([]string, error)
+	SInter(keys []string) ([]string, error)
+	SUnion(keys []string) ([]string, error)
+	SDiff(keys []string) ([]string, error)
+	SInterStore(destination string, keys []string) (int, error)
+	SUnionStore(destination string, keys []string) (int, error)
+	SDiffStore(destination string, keys []string) (int, error)
+
+	ZAdd(key string, members []ScoredMember, options ZAddOptions) (int, error)
+	ZAddIncr(key string, member string, increment float64, options ZAddOptions) (float64, bool, error)
+	ZIncrBy(key string, increment float64, member string) (float64, error)
+	ZRem(key string, members []string) (int, error)
+	ZScore(key string, member string) (float64, bool, error)
+	ZCard(key string) (int, error)
+	ZRank(key string, member string) (int, bool, error)
+	ZRevRank(key string, member string) (int, bool, error)
+	ZRange(key string, query ZRangeQuery) ([]ScoredMember, error)
+	ZCount(key string, min string, max string) (int, error)
+	ZPopMin(key string, count int) ([]ScoredMember, error)
+	ZPopMax(key string, count int) ([]ScoredMember, error)
+	ZUnionStore(destination string, keys []string, options ZStoreOptions) (int, error)
+	ZInterStore(destination string, keys []string, options ZStoreOptions) (int, error)
+
+	RPush(key string, values []string) (int, error)
+	LPushX(key string, values []string) (int, error)
+	RPushX(key string, values []string) (int, error)
+	LPop(key string, count int) ([]string, error)
+	RPop(key string, count int) ([]string, error)
+	LRange(key string, start int, stop int) ([]string, error)
+	LLen(key string) (int, error)
+	LIndex(key string, index int) (string, bool, error)
+	LInsert(key string, before bool, pivot string, value string) (int, error)
+	LRem(key string, count int, value string) (int, error)
+	LTrim(key string, start int, stop int) error
+	LPos(key string, element string, options LPosOptions) ([]int, error)
+	LMove(source string, destination string, from ListSide, to ListSide) (string, bool, error)
+
+	BLPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error)
+	BRPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error)
+	BLMove(ctx context.Context, source string, destination string, from ListSide, to ListSide, timeout time.Duration) (string, bool, error)
+
+	PFAdd(key string, elements []string) (bool, error)
+	PFCount(keys []string) (int64, error)
+	PFMerge(destination string, keys []string) error
+
+	XAdd(key string, fields []FieldValue, options XAddOptions) (StreamID, bool, error)
+	XRange(key string, start StreamID, end StreamID, count int) ([]StreamEntry, error)
+	XRevRange(key string, end StreamID, start StreamID, count int) ([]StreamEntry, error)
+	XLen(key string) (int, error)
+	XDel(key string, ids []StreamID) (int, error)
+	XTrim(key string, trim StreamTrim) (int, error)
+	XRead(ctx context.Context, reads []StreamRead, options XReadOptions) ([]StreamEntries, error)
+	XGroupCreate(key string, group string, id *StreamID, mkStream bool) error
+	XGroupSetID(key string, group string, id *StreamID) error
+	XGroupDestroy(key string, group string) (bool, error)
+	XGroupCreateConsumer(key string, group string, consumer string) (bool, error)
+	XGroupDelConsumer(key string, group string, consumer string) (int, error)
+	XReadGroup(ctx context.Context, group string, consumer string, reads []StreamRead, options XReadOptions) ([]StreamEntries, error)
+	XAck(key string, group string, ids []StreamID) (int, error)
+	XPendingSummary(key string, group string) (PendingSummary, error)
+	XPending(key string, group string, options XPendingOptions) ([]PendingEntry, error)
+	XClaim(key string, group string, consumer string, minIdle time.Duration, ids []StreamID, options XClaimOptions) ([]StreamEntry, error)
+	XAutoClaim(key string, group string, consumer string, minIdle time.Duration, start StreamID, count int, justID bool) (XAutoClaimResult, error)
+
+	GeoAdd(key string, members []GeoMember, options ZAddOptions) (int, error)
+	GeoPos(key string, members []string) ([]*GeoPosition, error)
+	GeoDist(key string, first string, second string, unit float64) (float64, bool, error)
+	GeoHash(key string, members []string) ([]*string, error)
+	GeoSearch(key string, query GeoSearchQuery) ([]GeoLocation, error)
+	GeoSearchStore(destination string, key string, query GeoSearchQuery, storeDist bool) (int, error)
+
+	JSONSet(key string, path string, value string, options JSONSetOptions) (bool, error)
+	JSONGet(key string, paths []string) (string, bool, error)
+	JSONDel(key string, path string) (int, error)
+	JSONType(key string, path string) ([]string, error)
+	JSONNumIncrBy(key string, path string, increment string) ([]*string, error)
+	JSONArrAppend(key string, path string, values []string) ([]*int, error)
+	JSONObjKeys(key string, path string) ([][]string, error)
+
+	BFReserve(key string, options BloomOptions) error
+	BFAdd(key string, items []string) ([]bool, error)
+	BFExists(key string, items []string) ([]bool, error)
+	CFReserve(key string, options CuckooOptions) error
+	CFAdd(key string, item string, nx bool) (bool, error)
+	CFDel(key string, item string) (bool, error)
+	CFExists(key string, items []string) ([]bool, error)
+
+	TSCreate(key string, options TSCreateOptions) error
+	TSAdd(key string, sample TSSample, options TSCreateOptions) (int64, error)
+	TSMAdd(samples []TSKeySample) []error
+	TSRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error)
+	TSRevRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error)
+	TSMRange(from int64, to int64, filters []string, options TSRangeOptions) ([]TSSeries, error)
+	TSCreateRule(source string, destination string, aggregation TSAggregation) error
+	TSDeleteRule(source string, destination string) error
 }
 
 type redisUsecase struct {
@@ -26,14 +160,82 @@ func NewRedisUsecase(redisStore RedisStore) RedisUsecase {
 	}
 }
 
-func (r *redisUsecase) Set(key string, value string) {
-	r.redisStore.Set(key, value)
+func (r *redisUsecase) Set(key string, value string, options SetOptions) (SetResult, error) {
+	return r.redisStore.Set(key, value, options)
 }
 
 func (r *redisUsecase) Get(key string) (string, bool, error) {
 	return r.redisStore.Get(key)
 }
 
+func (r *redisUsecase) GetEx(key string, options GetExOptions) (string, bool, error) {
+	return r.redisStore.GetEx(key, options)
+}
+
+func (r *redisUsecase) GetDel(key string) (string, bool, error) {
+	return r.redisStore.GetDel(key)
+}
+
+func (r *redisUsecase) MGet(keys []string) []*string {
+	return r.redisStore.MGet(keys)
+}
+
+func (r *redisUsecase) MSet(pairs []KeyValue) {
+	r.redisStore.MSet(pairs)
+}
+
+func (r *redisUsecase) MSetNX(pairs []KeyValue) bool {
+	return r.redisStore.MSetNX(pairs)
+}
+
+func (r *redisUsecase) IncrBy(key string, increment int64) (int64, error) {
+	return r.redisStore.IncrBy(key, increment)
+}
+
+func (r *redisUsecase) IncrByFloat(key string, increment float64) (float64, error) {
+	return r.redisStore.IncrByFloat(key, increment)
+}
+
+func (r *redisUsecase) Append(key string, value string) (int, error) {
+	return r.redisStore.Append(key, value)
+}
+
+func (r *redisUsecase) StrLen(key string) (int, error) {
+	return r.redisStore.StrLen(key)
+}
+
+func (r *redisUsecase) GetRange(key string, start int, end int) (string, error) {
+	return r.redisStore.GetRange(key, start, end)
+}
+
+func (r *redisUsecase) SetRange(key string, offset int, value string) (int, error) {
+	return r.redisStore.SetRange(key, offset, value)
+}
+
+func (r *redisUsecase) SetBit(key string, offset int64, value int) (int, error) {
+	return r.redisStore.SetBit(key, offset, value)
+}
+
+func (r *redisUsecase) GetBit(key string, offset int64) (int, error) {
+	return r.redisStore.GetBit(key, offset)
+}
+
+func (r *redisUsecase) BitCount(key string, rng *BitRange) (int64, error) {
+	return r.redisStore.BitCount(key, rng)
+}
+
+func (r *redisUsecase) BitPos(key string, bit int, rng *BitRange) (int64, error) {
+	return r.redisStore.BitPos(key, bit, rng)
+}
+
+func (r *redisUsecase) BitOp(operation BitOperation, destination string, keys []string) (int, error) {
+	return r.redisStore.BitOp(operation, destination, keys)
+}
+
+func (r *redisUsecase) BitField(key string, ops []BitFieldOp) ([]*int64, error) {
+	return r.redisStore.BitField(key, ops)
+}
+
 func (r *redisUsecase) Del(key string) bool {
 	return r.redisStore.Del(key)
 }
@@ -42,17 +244,52 @@ func (r *redisUsecase) Keys(pattern string) ([]string, error) {
 	return r.redisStore.Keys(pattern)
 }
 
+func (r *redisUsecase) ScanKeys(pattern string, callback func(key string) bool) error {
+	return r.redisStore.ScanKeys(pattern, callback)
+}
+
 func (r *redisUsecase) HGet(key string, field string) (string, bool, error) {
 	return r.redisStore.HGet(key, field)
 }
 
 func (r *redisUsecase) HSet(key string, pairs []FieldValue) (int, error) {
-	for _, pair := range pairs {
-		if err := r.redisStore.HSet(key, pair.Field, pair.Value); err != nil {
-			return -1, err
-		}
-	}
-	return len(pairs), nil
+	return r.redisStore.HSet(key, pairs)
+}
+
+func (r *redisUsecase) HSetNX(key string, field string, value string) (bool, error) {
+	return r.redisStore.HSetNX(key, field, value)
+}
+
+func (r *redisUsecase) HDel(key string, fields []string) (int, error) {
+	return r.redisStore.HDel(key, fields)
+}
+
+func (r *redisUsecase) HGetAll(key string) ([]FieldValue, error) {
+	return r.redisStore.HGetAll(key)
+}
+
+func (r *redisUsecase) HKeys(key string) ([]string, error) {
+	return r.redisStore.HKeys(key)
+}
+
+func (r *redisUsecase) HVals(key string) ([]string, error) {
+	return r.redisStore.HVals(key)
+}
+
+func (r *redisUsecase) HLen(key string) (int, error) {
+	return r.redisStore.HLen(key)
+}
+
+func (r *redisUsecase) HExists(key string, field string) (bool, error) {
+	return r.redisStore.HExists(key, field)
+}
+
+func (r *redisUsecase) HMGet(key string, fields []string) ([]*string, error) {
+	return r.redisStore.HMGet(key, fields)
+}
+
+func (r *redisUsecase) HIncrBy(key string, field string, increment int64) (int64, error) {
+	return r.redisStore.HIncrBy(key, field, increment)
 }
 
 func (r *redisUsecase) LGet(key string, index int) (string, error) {
@@ -64,18 +301,377 @@ func (r *redisUsecase) LSet(key string, index int, value string) error {
 }
 
 func (r *redisUsecase) LPush(key string, values []string) (int, error) {
-	var err error
-	size := 0
-
-	for i := len(values) - 1; i >= 0; i-- {
-		size, err = r.redisStore.LPush(key, values[i])
-		if err != nil {
-			return -1, err
-		}
-	}
-	return size, nil
+	return r.redisStore.LPush(key, values)
 }
 
 func (r *redisUsecase) Expire(key string, duration int) bool {
 	return r.redisStore.Expire(key, duration)
 }
+
+func (r *redisUsecase) SAdd(key string, members []string) (int, error) {
+	return r.redisStore.SAdd(key, members)
+}
+
+func (r *redisUsecase) SRem(key string, members []string) (int, error) {
+	return r.redisStore.SRem(key, members)
+}
+
+func (r *redisUsecase) SMembers(key string) ([]string, error) {
+	return r.redisStore.SMembers(key)
+}
+
+func (r *redisUsecase) SIsMember(key string, member string) (bool, error) {
+	return r.redisStore.SIsMember(key, member)
+}
+
+func (r *redisUsecase) SCard(key string) (int, error) {
+	return r.redisStore.SCard(key)
+}
+
+func (r *redisUsecase) SPop(key string, count int) ([]string, error) {
+	return r.redisStore.SPop(key, count)
+}
+
+func (r *redisUsecase) SRandMember(key string, count int) ([]string, error) {
+	return r.redisStore.SRandMember(key, count)
+}
+
+func (r *redisUsecase) SInter(keys []string) ([]string, error) {
+	return r.redisStore.SInter(keys)
+}
+
+func (r *redisUsecase) SUnion(keys []string) ([]string, error) {
+	return r.redisStore.SUnion(keys)
+}
+
+func (r *redisUsecase) SDiff(keys []string) ([]string, error) {
+	return r.redisStore.SDiff(keys)
+}
+
+func (r *redisUsecase) SInterStore(destination string, keys []string) (int, error) {
+	return r.redisStore.SInterStore(destination, keys)
+}
+
+func (r *redisUsecase) SUnionStore(destination string, keys []string) (int, error) {
+	return r.redisStore.SUnionStore(destination, keys)
+}
+
+func (r *redisUsecase) SDiffStore(destination string, keys []string) (int, error) {
+	return r.redisStore.SDiffStore(destination, keys)
+}
+
+func (r *redisUsecase) ZAdd(key string, members []ScoredMember, options ZAddOptions) (int, error) {
+	return r.redisStore.ZAdd(key, members, options)
+}
+
+func (r *redisUsecase) ZAddIncr(key string, member string, increment float64, options ZAddOptions) (float64, bool, error) {
+	return r.redisStore.ZAddIncr(key, member, increment, options)
+}
+
+func (r *redisUsecase) ZIncrBy(key string, increment float64, member string) (float64, error) {
+	return r.redisStore.ZIncrBy(key, increment, member)
+}
+
+func (r *redisUsecase) ZRem(key string, members []string) (int, error) {
+	return r.redisStore.ZRem(key, members)
+}
+
+func (r *redisUsecase) ZScore(key string, member string) (float64, bool, error) {
+	return r.redisStore.ZScore(key, member)
+}
+
+func (r *redisUsecase) ZCard(key string) (int, error) {
+	return r.redisStore.ZCard(key)
+}
+
+func (r *redisUsecase) ZRank(key string, member string) (int, bool, error) {
+	return r.redisStore.ZRank(key, member)
+}
+
+func (r *redisUsecase) ZRevRank(key string, member string) (int, bool, error) {
+	return r.redisStore.ZRevRank(key, member)
+}
+
+func (r *redisUsecase) ZRange(key string, query ZRangeQuery) ([]ScoredMember, error) {
+	return r.redisStore.ZRange(key, query)
+}
+
+func (r *redisUsecase) ZCount(key string, min string, max string) (int, error) {
+	return r.redisStore.ZCount(key, min, max)
+}
+
+func (r *redisUsecase) ZPopMin(key string, count int) ([]ScoredMember, error) {
+	return r.redisStore.ZPopMin(key, count)
+}
+
+func (r *redisUsecase) ZPopMax(key string, count int) ([]ScoredMember, error) {
+	return r.redisStore.ZPopMax(key, count)
+}
+
+func (r *redisUsecase) ZUnionStore(destination string, keys []string, options ZStoreOptions) (int, error) {
+	return r.redisStore.ZUnionStore(destination, keys, options)
+}
+
+func (r *redisUsecase) ZInterStore(destination string, keys []string, options ZStoreOptions) (int, error) {
+	return r.redisStore.ZInterStore(destination, keys, options)
+}
+
+func (r *redisUsecase) RPush(key string, values []string) (int, error) {
+	return r.redisStore.RPush(key, values)
+}
+
+func (r *redisUsecase) LPushX(key string, values []string) (int, error) {
+	return r.redisStore.LPushX(key, values)
+}
+
+func (r *redisUsecase) RPushX(key string, values []string) (int, error) {
+	return r.redisStore.RPushX(key, values)
+}
+
+func (r *redisUsecase) LPop(key string, count int) ([]string, error) {
+	return r.redisStore.LPop(key, count)
+}
+
+func (r *redisUsecase) RPop(key string, count int) ([]string, error) {
+	return r.redisStore.RPop(key, count)
+}
+
+func (r *redisUsecase) LRange(key string, start int, stop int) ([]string, error) {
+	return r.redisStore.LRange(key, start, stop)
+}
+
+func (r *redisUsecase) LLen(key string) (int, error) {
+	return r.redisStore.LLen(key)
+}
+
+func (r *redisUsecase) LIndex(key string, index int) (string, bool, error) {
+	return r.redisStore.LIndex(key, index)
+}
+
+func (r *redisUsecase) LInsert(key string, before bool, pivot string, value string) (int, error) {
+	return r.redisStore.LInsert(key, before, pivot, value)
+}
+
+func (r *redisUsecase) LRem(key string, count int, value string) (int, error) {
+	return r.redisStore.LRem(key, count, value)
+}
+
+func (r *redisUsecase) LTrim(key string, start int, stop int) error {
+	return r.redisStore.LTrim(key, start, stop)
+}
+
+func (r *redisUsecase) LPos(key string, element string, options LPosOptions) ([]int, error) {
+	return r.redisStore.LPos(key, element, options)
+}
+
+func (r *redisUsecase) LMove(source string, destination string, from ListSide, to ListSide) (string, bool, error) {
+	return r.redisStore.LMove(source, destination, from, to)
+}
+
+func (r *redisUsecase) BLPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error) {
+	return r.redisStore.BLPop(ctx, keys, timeout)
+}
+
+func (r *redisUsecase) BRPop(ctx context.Context, keys []string, timeout time.Duration) (string, string, bool, error) {
+	return r.redisStore.BRPop(ctx, keys, timeout)
+}
+
+func (r *redisUsecase) BLMove(ctx context.Context, source string, destination string, from ListSide, to ListSide, timeout time.Duration) (string, bool, error) {
+	return r.redisStore.BLMove(ctx, source, destination, from, to, timeout)
+}
+
+func (r *redisUsecase) PFAdd(key string, elements []string) (bool, error) {
+	return r.redisStore.PFAdd(key, elements)
+}
+
+func (r *redisUsecase) PFCount(keys []string) (int64, error) {
+	return r.redisStore.PFCount(keys)
+}
+
+func (r *redisUsecase) PFMerge(destination string, keys []string) error {
+	return r.redisStore.PFMerge(destination, keys)
+}
+
+func (r *redisUsecase) XAdd(key string, fields []FieldValue, options XAddOptions) (StreamID, bool, error) {
+	return r.redisStore.XAdd(key, fields, options)
+}
+
+func (r *redisUsecase) XRange(key string, start StreamID, end StreamID, count int) ([]StreamEntry, error) {
+	return r.redisStore.XRange(key, start, end, count)
+}
+
+func (r *redisUsecase) XRevRange(key string, end StreamID, start StreamID, count int) ([]StreamEntry, error) {
+	return r.redisStore.XRevRange(key, end, start, count)
+}
+
+func (r *redisUsecase) XLen(key string) (int, error) {
+	return r.redisStore.XLen(key)
+}
+
+func (r *redisUsecase) XDel(key string, ids []StreamID) (int, error) {
+	return r.redisStore.XDel(key, ids)
+}
+
+func (r *redisUsecase) XTrim(key string, trim StreamTrim) (int, error) {
+	return r.redisStore.XTrim(key, trim)
+}
+
+func (r *redisUsecase) XRead(ctx context.Context, reads []StreamRead, options XReadOptions) ([]StreamEntries, error) {
+	return r.redisStore.XRead(ctx, reads, options)
+}
+
+func (r *redisUsecase) XGroupCreate(key string, group string, id *StreamID, mkStream bool) error {
+	return r.redisStore.XGroupCreate(key, group, id, mkStream)
+}
+
+func (r *redisUsecase) XGroupSetID(key string, group string, id *StreamID) error {
+	return r.redisStore.XGroupSetID(key, group, id)
+}
+
+func (r *redisUsecase) XGroupDestroy(key string, group string) (bool, error) {
+	return r.redisStore.XGroupDestroy(key, group)
+}
+
+func (r *redisUsecase) XGroupCreateConsumer(key string, group string, consumer string) (bool, error) {
+	return r.redisStore.XGroupCreateConsumer(key, group, consumer)
+}
+
+func (r *redisUsecase) XGroupDelConsumer(key string, group string, consumer string) (int, error) {
+	return r.redisStore.XGroupDelConsumer(key, group, consumer)
+}
+
+func (r *redisUsecase) XReadGroup(ctx context.Context, group string, consumer string, reads []StreamRead, options XReadOptions) ([]StreamEntries, error) {
+	return r.redisStore.XReadGroup(ctx, group, consumer, reads, options)
+}
+
+func (r *redisUsecase) XAck(key string, group string, ids []StreamID) (int, error) {
+	return r.redisStore.XAck(key, group, ids)
+}
+
+func (r *redisUsecase) XPendingSummary(key string, group string) (PendingSummary, error) {
+	return r.redisStore.XPendingSummary(key, group)
+}
+
+func (r *redisUsecase) XPending(key string, group string, options XPendingOptions) ([]PendingEntry, error) {
+	return r.redisStore.XPending(key, group, options)
+}
+
+func (r *redisUsecase) XClaim(key string, group string, consumer string, minIdle time.Duration, ids []StreamID, options XClaimOptions) ([]StreamEntry, error) {
+	return r.redisStore.XClaim(key, group, consumer, minIdle, ids, options)
+}
+
+func (r *redisUsecase) XAutoClaim(key string, group string, consumer string, minIdle time.Duration, start StreamID, count int, justID bool) (XAutoClaimResult, error) {
+	return r.redisStore.XAutoClaim(key, group, consumer, minIdle, start, count, justID)
+}
+
+func (r *redisUsecase) GeoAdd(key string, members []GeoMember, options ZAddOptions) (int, error) {
+	return r.redisStore.GeoAdd(key, members, options)
+}
+
+func (r *redisUsecase) GeoPos(key string, members []string) ([]*GeoPosition, error) {
+	return r.redisStore.GeoPos(key, members)
+}
+
+func (r *redisUsecase) GeoDist(key string, first string, second string, unit float64) (float64, bool, error) {
+	return r.redisStore.GeoDist(key, first, second, unit)
+}
+
+func (r *redisUsecase) GeoHash(key string, members []string) ([]*string, error) {
+	return r.redisStore.GeoHash(key, members)
+}
+
+func (r *redisUsecase) GeoSearch(key string, query GeoSearchQuery) ([]GeoLocation, error) {
+	return r.redisStore.GeoSearch(key, query)
+}
+
+func (r *redisUsecase) GeoSearchStore(destination string, key string, query GeoSearchQuery, storeDist bool) (int, error) {
+	return r.redisStore.GeoSearchStore(destination, key, query, storeDist)
+}
+
+func (r *redisUsecase) JSONSet(key string, path string, value string, options JSONSetOptions) (bool, error) {
+	return r.redisStore.JSONSet(key, path, value, options)
+}
+
+func (r *redisUsecase) JSONGet(key string, paths []string) (string, bool, error) {
+	return r.redisStore.JSONGet(key, paths)
+}
+
+func (r *redisUsecase) JSONDel(key string, path string) (int, error) {
+	return r.redisStore.JSONDel(key, path)
+}
+
+func (r *redisUsecase) JSONType(key string, path string) ([]string, error) {
+	return r.redisStore.JSONType(key, path)
+}
+
+func (r *redisUsecase) JSONNumIncrBy(key string, path string, increment string) ([]*string, error) {
+	return r.redisStore.JSONNumIncrBy(key, path, increment)
+}
+
+func (r *redisUsecase) JSONArrAppend(key string, path string, values []string) ([]*int, error) {
+	return r.redisStore.JSONArrAppend(key, path, values)
+}
+
+func (r *redisUsecase) JSONObjKeys(key string, path string) ([][]string, error) {
+	return r.redisStore.JSONObjKeys(key, path)
+}
+
+func (r *redisUsecase) BFReserve(key string, options BloomOptions) error {
+	return r.redisStore.BFReserve(key, options)
+}
+
+func (r *redisUsecase) BFAdd(key string, items []string) ([]bool, error) {
+	return r.redisStore.BFAdd(key, items)
+}
+
+func (r *redisUsecase) BFExists(key string, items []string) ([]bool, error) {
+	return r.redisStore.BFExists(key, items)
+}
+
+func (r *redisUsecase) CFReserve(key string, options CuckooOptions) error {
+	return r.redisStore.CFReserve(key, options)
+}
+
+func (r *redisUsecase) CFAdd(key string, item string, nx bool) (bool, error) {
+	return r.redisStore.CFAdd(key, item, nx)
+}
+
+func (r *redisUsecase) CFDel(key string, item string) (bool, error) {
+	return r.redisStore.CFDel(key, item)
+}
+
+func (r *redisUsecase) CFExists(key string, items []string) ([]bool, error) {
+	return r.redisStore.CFExists(key, items)
+}
+
+func (r *redisUsecase) TSCreate(key string, options TSCreateOptions) error {
+	return r.redisStore.TSCreate(key, options)
+}
+
+func (r *redisUsecase) TSAdd(key string, sample TSSample, options TSCreateOptions) (int64, error) {
+	return r.redisStore.TSAdd(key, sample, options)
+}
+
+func (r *redisUsecase) TSMAdd(samples []TSKeySample) []error {
+	return r.redisStore.TSMAdd(samples)
+}
+
+func (r *redisUsecase) TSRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error) {
+	return r.redisStore.TSRange(key, from, to, options)
+}
+
+func (r *redisUsecase) TSRevRange(key string, from int64, to int64, options TSRangeOptions) ([]TSSample, error) {
+	return r.redisStore.TSRevRange(key, from, to, options)
+}
+
+func (r *redisUsecase) TSMRange(from int64, to int64, filters []string, options TSRangeOptions) ([]TSSeries, error) {
+	return r.redisStore.TSMRange(from, to, filters, options)
+}
+
+func (r *redisUsecase) TSCreateRule(source string, destination string, aggregation TSAggregation) error {
+	return r.redisStore.TSCreateRule(source, destination, aggregation)
+}
+
+func (r *redisUsecase) TSDeleteRule(source string, destination string) error {
+	return r.redisStore.TSDeleteRule(source, destination)
+}
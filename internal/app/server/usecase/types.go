@@ -1,6 +1,417 @@
 package usecase
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 type FieldValue struct {
 	Field string `json:"field"`
 	Value string `json:"value"`
 }
+
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// SetOptions are the SET options: NX/XX set the key only if it doesn't exist/exists,
+// Get returns the old value, which must be a string
+type SetOptions struct {
+	NX bool
+	XX bool
+	// Expiry is the expiration time of the key, the zero time means the key doesn't expire
+	Expiry time.Time
+	// KeepTTL keeps the expiration of the existing key instead of Expiry
+	KeepTTL bool
+	Get     bool
+}
+
+// SetResult reports whether SET wrote the value, Old is set only with SetOptions.Get
+type SetResult struct {
+	Written   bool
+	Old       string
+	OldExists bool
+}
+
+// GetExOptions update the expiration of the key read by GETEX, it's left as is if both are zero
+type GetExOptions struct {
+	Expiry  time.Time
+	Persist bool
+}
+
+// BitRange limits BITCOUNT and BITPOS to the bytes from Start to End inclusive,
+// negative offsets count from the end of the string
+type BitRange struct {
+	Start int64
+	// End is nil if the range lasts to the end of the string
+	End *int64
+	// Bit counts Start and End in bits instead of bytes
+	Bit bool
+}
+
+// BitOperation is the operation of BITOP
+type BitOperation int
+
+const (
+	BitAnd BitOperation = iota
+	BitOr
+	BitXor
+	BitNot
+)
+
+// BitFieldKind is the subcommand of a BITFIELD operation
+type BitFieldKind int
+
+const (
+	BitFieldGet BitFieldKind = iota
+	BitFieldSet
+	BitFieldIncrBy
+)
+
+// BitOverflow is how BITFIELD SET and INCRBY handle the values which don't fit the field
+type BitOverflow int
+
+const (
+	// OverflowWrap wraps the value around, it's the default
+	OverflowWrap BitOverflow = iota
+	// OverflowSat saturates the value to the minimum or the maximum of the field
+	OverflowSat
+	// OverflowFail leaves the field unchanged and returns nil
+	OverflowFail
+)
+
+// BitFieldType is a signed or an unsigned integer of Width bits, up to 64 signed and 63 unsigned bits
+type BitFieldType struct {
+	Signed bool
+	Width  uint
+}
+
+// BitFieldOp is a single BITFIELD operation on the field at the bit Offset,
+// Value is the value of SET or the increment of INCRBY
+type BitFieldOp struct {
+	Kind     BitFieldKind
+	Type     BitFieldType
+	Offset   int64
+	Value    int64
+	Overflow BitOverflow
+}
+
+// ScoredMember is a member of a sorted set with its score
+type ScoredMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// ZAddOptions are the ZADD flags: NX/XX only add/update members,
+// GT/LT only update scores which grow/shrink, CH counts changed members too
+type ZAddOptions struct {
+	NX bool `json:"nx"`
+	XX bool `json:"xx"`
+	GT bool `json:"gt"`
+	LT bool `json:"lt"`
+	CH bool `json:"ch"`
+}
+
+// ZRangeBy selects how the bounds of a ZRangeQuery are interpreted
+type ZRangeBy int
+
+const (
+	ZRangeByIndex ZRangeBy = iota
+	ZRangeByScore
+	ZRangeByLex
+)
+
+// ZRangeLimit skips Offset members of the range and returns at most Count members,
+// a negative Count returns all the remaining members
+type ZRangeLimit struct {
+	Offset int `json:"offset"`
+	Count  int `json:"count"`
+}
+
+// ZRangeQuery describes a ZRANGE request. Start and Stop are indexes, scores ("1.5", "(1.5", "-inf")
+// or lex bounds ("[a", "(a", "-", "+") depending on By. They are given in the order of the reply,
+// so with Rev Start is the upper bound.
+type ZRangeQuery struct {
+	Start string
+	Stop  string
+	By    ZRangeBy
+	Rev   bool
+	Limit *ZRangeLimit
+}
+
+// ZStoreOptions are the ZUNIONSTORE/ZINTERSTORE options, scores are multiplied by Weights
+// and combined with Aggregate: SUM (by default), MIN or MAX
+type ZStoreOptions struct {
+	Weights   []float64
+	Aggregate string
+}
+
+// ListSide is the end of a list for LMOVE
+type ListSide int
+
+const (
+	ListLeft ListSide = iota
+	ListRight
+)
+
+// LPosOptions are the LPOS options: Rank is the 1-based number of the first match to return
+// (negative ranks search from the tail), Count limits the matches (0 returns all of them)
+// and MaxLen limits the compared elements (0 compares the whole list)
+type LPosOptions struct {
+	Rank   int
+	Count  int
+	MaxLen int
+}
+
+// StreamID identifies a stream entry: Ms is the time the entry was added in milliseconds
+// and Seq orders the entries added in the same millisecond
+type StreamID struct {
+	Ms  uint64
+	Seq uint64
+}
+
+func (id StreamID) String() string {
+	return strconv.FormatUint(id.Ms, 10) + "-" + strconv.FormatUint(id.Seq, 10)
+}
+
+// Less reports whether the entry with id is older than the entry with other
+func (id StreamID) Less(other StreamID) bool {
+	return id.Ms < other.Ms || (id.Ms == other.Ms && id.Seq < other.Seq)
+}
+
+// StreamEntry is an entry of a stream, Fields is nil for a pending entry which was deleted from the stream
+type StreamEntry struct {
+	ID     StreamID
+	Fields []FieldValue
+}
+
+// StreamEntries are the entries read from the stream at Key by XREAD or XREADGROUP
+type StreamEntries struct {
+	Key     string
+	Entries []StreamEntry
+}
+
+// StreamTrim trims the stream to MaxLen entries or removes the entries older than MinID.
+// Limit is the maximum number of removed entries, 0 means no limit.
+type StreamTrim struct {
+	MinID       *StreamID
+	MaxLen      int64
+	Approximate bool
+	Limit       int64
+}
+
+// XAddOptions are the XADD options: the entry gets ID unless AutoID generates it from the current time
+// or AutoSeq generates only the sequence of ID.Ms. With NoMkStream a missing stream isn't created.
+type XAddOptions struct {
+	ID         StreamID
+	AutoID     bool
+	AutoSeq    bool
+	NoMkStream bool
+	Trim       *StreamTrim
+}
+
+// StreamRead reads the stream at Key after ID, New reads only the entries added after the call ($)
+// for XREAD and the entries never delivered to the group (>) for XREADGROUP
+type StreamRead struct {
+	Key string
+	ID  StreamID
+	New bool
+}
+
+// XReadOptions are the XREAD and XREADGROUP options: Count limits the entries per stream (0 means no limit),
+// with Block the call waits up to Timeout (forever if it's 0) for new entries
+// and with NoAck XREADGROUP doesn't add the delivered entries to the pending entries.
+type XReadOptions struct {
+	Count   int
+	Block   bool
+	Timeout time.Duration
+	NoAck   bool
+}
+
+// PendingSummary is the XPENDING summary: the number of the pending entries, the smallest and the greatest ID
+// of them and the number of the pending entries of every consumer
+type PendingSummary struct {
+	Count     int
+	Smallest  StreamID
+	Greatest  StreamID
+	Consumers []ConsumerPending
+}
+
+type ConsumerPending struct {
+	Name    string `json:"name"`
+	Pending int    `json:"pending"`
+}
+
+// PendingEntry is an entry delivered to Consumer but not acknowledged, Idle is the time since the last delivery
+type PendingEntry struct {
+	ID         StreamID
+	Consumer   string
+	Idle       time.Duration
+	Deliveries int64
+}
+
+// XPendingOptions filter the pending entries: the IDs from Start to End, the entries idle at least MinIdle
+// and owned by Consumer if it's not empty, at most Count of them
+type XPendingOptions struct {
+	Start    StreamID
+	End      StreamID
+	Count    int
+	MinIdle  time.Duration
+	Consumer string
+}
+
+// XClaimOptions are the XCLAIM options: Idle or Time set the last delivery time (now by default),
+// RetryCount sets the delivery counter, Force claims IDs which aren't pending and JustID doesn't increment
+// the delivery counter. LastID updates the last delivered ID of the group.
+type XClaimOptions struct {
+	Idle       *time.Duration
+	Time       *time.Time
+	RetryCount *int64
+	Force      bool
+	JustID     bool
+	LastID     *StreamID
+}
+
+// XAutoClaimResult holds the claimed entries, the pending entries which were deleted from the stream
+// and the ID to start the next scan from, 0-0 when the scan is complete
+type XAutoClaimResult struct {
+	Next    StreamID
+	Claimed []StreamEntry
+	Deleted []StreamID
+}
+
+// GeoMember is a member of a geo index with its position
+type GeoMember struct {
+	Member    string  `json:"member"`
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// GeoPosition is the position of a member decoded from its geohash
+type GeoPosition struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// GeoSort is the order of the GEOSEARCH results by the distance from the center
+type GeoSort int
+
+const (
+	GeoSortNone GeoSort = iota
+	GeoSortAsc
+	GeoSortDesc
+)
+
+// GeoSearchQuery describes a GEOSEARCH request. The center is the position of Member with FromMember
+// or Longitude and Latitude otherwise, the area is the circle of Radius or the Width x Height box with ByBox.
+// The distances are in Unit, given in meters. Count limits the results (0 means no limit),
+// with Any the search stops after Count matches instead of returning the nearest ones.
+type GeoSearchQuery struct {
+	FromMember bool
+	Member     string
+	Longitude  float64
+	Latitude   float64
+	ByBox      bool
+	Radius     float64
+	Width      float64
+	Height     float64
+	Unit       float64
+	Sort       GeoSort
+	Count      int
+	Any        bool
+}
+
+// GeoLocation is a GEOSEARCH result: the member, its distance from the center in the unit of the query,
+// its geohash score and position
+type GeoLocation struct {
+	Member   string
+	Distance float64
+	Hash     int64
+	Position GeoPosition
+}
+
+// JSONSetOptions are the JSON.SET flags: NX sets the path only if it doesn't exist and XX only if it exists
+type JSONSetOptions struct {
+	NX bool `json:"nx"`
+	XX bool `json:"xx"`
+}
+
+// IsJSONPath reports whether the path is a JSONPath starting with $. Other paths are legacy paths
+// which select only the first match, so the replies for them aren't arrays.
+func IsJSONPath(path string) bool {
+	return strings.HasPrefix(path, "$")
+}
+
+// BloomOptions are the BF.RESERVE parameters: the wanted false positive rate and the number of the items
+// which the first sub-filter holds. When it's full a sub-filter Expansion times larger is added,
+// a NonScaling filter returns an error instead.
+type BloomOptions struct {
+	ErrorRate  float64 `json:"error_rate"`
+	Capacity   int     `json:"capacity"`
+	Expansion  int     `json:"expansion"`
+	NonScaling bool    `json:"non_scaling"`
+}
+
+// DefaultBloomOptions are used by BF.ADD when the key doesn't exist, like in RedisBloom
+var DefaultBloomOptions = BloomOptions{ErrorRate: 0.01, Capacity: 100, Expansion: 2}
+
+// CuckooOptions are the CF.RESERVE parameters. An item which doesn't fit into its buckets evicts
+// up to MaxIterations other items, then a sub-filter Expansion times larger is added;
+// with Expansion 0 the filter is full.
+type CuckooOptions struct {
+	Capacity      int `json:"capacity"`
+	BucketSize    int `json:"bucket_size"`
+	MaxIterations int `json:"max_iterations"`
+	Expansion     int `json:"expansion"`
+}
+
+// DefaultCuckooOptions are used by CF.ADD when the key doesn't exist, like in RedisBloom
+var DefaultCuckooOptions = CuckooOptions{Capacity: 1024, BucketSize: 2, MaxIterations: 20, Expansion: 1}
+
+// TSSample is a time series sample, the timestamp is in milliseconds
+type TSSample struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// TSKeySample is a sample of TS.MADD
+type TSKeySample struct {
+	Key string
+	TSSample
+}
+
+type TSLabel struct {
+	Name  string
+	Value string
+}
+
+// TSCreateOptions are the parameters of a new time series. The samples older than Retention milliseconds
+// before the latest sample are dropped, 0 keeps them forever. The labels are used by TS.MRANGE filters.
+type TSCreateOptions struct {
+	Retention int64
+	Labels    []TSLabel
+}
+
+// TSAggregation groups the samples into the buckets of BucketDuration milliseconds, aligned to the epoch,
+// and reduces every bucket to one sample of Type: avg, sum, min, max, count, first or last
+type TSAggregation struct {
+	Type           string `json:"type"`
+	BucketDuration int64  `json:"bucket_duration"`
+}
+
+// TSRangeOptions limit the samples or, with Aggregation, the buckets to Count (0 means no limit).
+// WithLabels adds the labels to the TS.MRANGE results.
+type TSRangeOptions struct {
+	Count       int
+	Aggregation *TSAggregation
+	WithLabels  bool
+}
+
+// TSSeries is a TS.MRANGE result, Labels are nil without WithLabels
+type TSSeries struct {
+	Key     string
+	Labels  []TSLabel
+	Samples []TSSample
+}
@@ -0,0 +1,21 @@
+package params
+
+import (
+	"github.com/labstack/echo"
+	"net/url"
+)
+
+// PathParam returns the unescaped path parameter. Echo matches routes against the raw path
+// when the request path contains escaped characters such as %2F, so the parameter has to be unescaped then.
+func PathParam(c echo.Context, name string) string {
+	value := c.Param(name)
+	if c.Request().URL.RawPath == "" {
+		return value
+	}
+
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		return value
+	}
+	return unescaped
+}
@@ -0,0 +1,27 @@
+package listener
+
+import (
+	"net"
+	"os"
+)
+
+// Unix listens on the unix domain socket at path and applies the permission bits to the socket file.
+// A stale socket file left by a previous run is removed.
+func Unix(path string, perm os.FileMode) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, perm); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,492 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CacheServiceClient is the client API for CacheService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CacheServiceClient interface {
+	GetString(ctx context.Context, in *GetStringRequest, opts ...grpc.CallOption) (*ValueResponse, error)
+	SetString(ctx context.Context, in *SetStringRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetValueByFieldInMap(ctx context.Context, in *GetValueByFieldRequest, opts ...grpc.CallOption) (*ValueResponse, error)
+	SetFieldAndValueInMap(ctx context.Context, in *SetFieldAndValueRequest, opts ...grpc.CallOption) (*SetFieldAndValueResponse, error)
+	GetFromList(ctx context.Context, in *GetFromListRequest, opts ...grpc.CallOption) (*ValueResponse, error)
+	PushToList(ctx context.Context, in *PushToListRequest, opts ...grpc.CallOption) (*PushToListResponse, error)
+	SetValueInList(ctx context.Context, in *SetValueInListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExpireKey(ctx context.Context, in *ExpireKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetKeys(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*KeysResponse, error)
+	// ScanKeys streams the matching keys in batches of at most batch_size keys
+	ScanKeys(ctx context.Context, in *ScanKeysRequest, opts ...grpc.CallOption) (CacheService_ScanKeysClient, error)
+}
+
+type cacheServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCacheServiceClient(cc grpc.ClientConnInterface) CacheServiceClient {
+	return &cacheServiceClient{cc}
+}
+
+func (c *cacheServiceClient) GetString(ctx context.Context, in *GetStringRequest, opts ...grpc.CallOption) (*ValueResponse, error) {
+	out := new(ValueResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/GetString", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) SetString(ctx context.Context, in *SetStringRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/SetString", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) GetValueByFieldInMap(ctx context.Context, in *GetValueByFieldRequest, opts ...grpc.CallOption) (*ValueResponse, error) {
+	out := new(ValueResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/GetValueByFieldInMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) SetFieldAndValueInMap(ctx context.Context, in *SetFieldAndValueRequest, opts ...grpc.CallOption) (*SetFieldAndValueResponse, error) {
+	out := new(SetFieldAndValueResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/SetFieldAndValueInMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) GetFromList(ctx context.Context, in *GetFromListRequest, opts ...grpc.CallOption) (*ValueResponse, error) {
+	out := new(ValueResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/GetFromList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) PushToList(ctx context.Context, in *PushToListRequest, opts ...grpc.CallOption) (*PushToListResponse, error) {
+	out := new(PushToListResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/PushToList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) SetValueInList(ctx context.Context, in *SetValueInListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/SetValueInList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) ExpireKey(ctx context.Context, in *ExpireKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/ExpireKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) GetKeys(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*KeysResponse, error) {
+	out := new(KeysResponse)
+	err := c.cc.Invoke(ctx, "/cache.CacheService/GetKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cacheServiceClient) ScanKeys(ctx context.Context, in *ScanKeysRequest, opts ...grpc.CallOption) (CacheService_ScanKeysClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CacheService_ServiceDesc.Streams[0], "/cache.CacheService/ScanKeys", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cacheServiceScanKeysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CacheService_ScanKeysClient interface {
+	Recv() (*KeysResponse, error)
+	grpc.ClientStream
+}
+
+type cacheServiceScanKeysClient struct {
+	grpc.ClientStream
+}
+
+func (x *cacheServiceScanKeysClient) Recv() (*KeysResponse, error) {
+	m := new(KeysResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CacheServiceServer is the server API for CacheService service.
+// All implementations must embed UnimplementedCacheServiceServer
+// for forward compatibility
+type CacheServiceServer interface {
+	GetString(context.Context, *GetStringRequest) (*ValueResponse, error)
+	SetString(context.Context, *SetStringRequest) (*emptypb.Empty, error)
+	GetValueByFieldInMap(context.Context, *GetValueByFieldRequest) (*ValueResponse, error)
+	SetFieldAndValueInMap(context.Context, *SetFieldAndValueRequest) (*SetFieldAndValueResponse, error)
+	GetFromList(context.Context, *GetFromListRequest) (*ValueResponse, error)
+	PushToList(context.Context, *PushToListRequest) (*PushToListResponse, error)
+	SetValueInList(context.Context, *SetValueInListRequest) (*emptypb.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	ExpireKey(context.Context, *ExpireKeyRequest) (*emptypb.Empty, error)
+	GetKeys(context.Context, *KeysRequest) (*KeysResponse, error)
+	// ScanKeys streams the matching keys in batches of at most batch_size keys
+	ScanKeys(*ScanKeysRequest, CacheService_ScanKeysServer) error
+	mustEmbedUnimplementedCacheServiceServer()
+}
+
+// UnimplementedCacheServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCacheServiceServer struct {
+}
+
+func (UnimplementedCacheServiceServer) GetString(context.Context, *GetStringRequest) (*ValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetString not implemented")
+}
+func (UnimplementedCacheServiceServer) SetString(context.Context, *SetStringRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetString not implemented")
+}
+func (UnimplementedCacheServiceServer) GetValueByFieldInMap(context.Context, *GetValueByFieldRequest) (*ValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValueByFieldInMap not implemented")
+}
+func (UnimplementedCacheServiceServer) SetFieldAndValueInMap(context.Context, *SetFieldAndValueRequest) (*SetFieldAndValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFieldAndValueInMap not implemented")
+}
+func (UnimplementedCacheServiceServer) GetFromList(context.Context, *GetFromListRequest) (*ValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFromList not implemented")
+}
+func (UnimplementedCacheServiceServer) PushToList(context.Context, *PushToListRequest) (*PushToListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushToList not implemented")
+}
+func (UnimplementedCacheServiceServer) SetValueInList(context.Context, *SetValueInListRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetValueInList not implemented")
+}
+func (UnimplementedCacheServiceServer) Delete(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCacheServiceServer) ExpireKey(context.Context, *ExpireKeyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireKey not implemented")
+}
+func (UnimplementedCacheServiceServer) GetKeys(context.Context, *KeysRequest) (*KeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeys not implemented")
+}
+func (UnimplementedCacheServiceServer) ScanKeys(*ScanKeysRequest, CacheService_ScanKeysServer) error {
+	return status.Errorf(codes.Unimplemented, "method ScanKeys not implemented")
+}
+func (UnimplementedCacheServiceServer) mustEmbedUnimplementedCacheServiceServer() {}
+
+// UnsafeCacheServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CacheServiceServer will
+// result in compilation errors.
+type UnsafeCacheServiceServer interface {
+	mustEmbedUnimplementedCacheServiceServer()
+}
+
+func RegisterCacheServiceServer(s grpc.ServiceRegistrar, srv CacheServiceServer) {
+	s.RegisterService(&CacheService_ServiceDesc, srv)
+}
+
+func _CacheService_GetString_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).GetString(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/GetString",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).GetString(ctx, req.(*GetStringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_SetString_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).SetString(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/SetString",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).SetString(ctx, req.(*SetStringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_GetValueByFieldInMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValueByFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).GetValueByFieldInMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/GetValueByFieldInMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).GetValueByFieldInMap(ctx, req.(*GetValueByFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_SetFieldAndValueInMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFieldAndValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).SetFieldAndValueInMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/SetFieldAndValueInMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).SetFieldAndValueInMap(ctx, req.(*SetFieldAndValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_GetFromList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFromListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).GetFromList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/GetFromList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).GetFromList(ctx, req.(*GetFromListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_PushToList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushToListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).PushToList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/PushToList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).PushToList(ctx, req.(*PushToListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_SetValueInList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetValueInListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).SetValueInList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/SetValueInList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).SetValueInList(ctx, req.(*SetValueInListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_ExpireKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpireKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).ExpireKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/ExpireKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).ExpireKey(ctx, req.(*ExpireKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_GetKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CacheServiceServer).GetKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cache.CacheService/GetKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CacheServiceServer).GetKeys(ctx, req.(*KeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CacheService_ScanKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CacheServiceServer).ScanKeys(m, &cacheServiceScanKeysServer{stream})
+}
+
+type CacheService_ScanKeysServer interface {
+	Send(*KeysResponse) error
+	grpc.ServerStream
+}
+
+type cacheServiceScanKeysServer struct {
+	grpc.ServerStream
+}
+
+func (x *cacheServiceScanKeysServer) Send(m *KeysResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CacheService_ServiceDesc is the grpc.ServiceDesc for CacheService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CacheService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cache.CacheService",
+	HandlerType: (*CacheServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetString",
+			Handler:    _CacheService_GetString_Handler,
+		},
+		{
+			MethodName: "SetString",
+			Handler:    _CacheService_SetString_Handler,
+		},
+		{
+			MethodName: "GetValueByFieldInMap",
+			Handler:    _CacheService_GetValueByFieldInMap_Handler,
+		},
+		{
+			MethodName: "SetFieldAndValueInMap",
+			Handler:    _CacheService_SetFieldAndValueInMap_Handler,
+		},
+		{
+			MethodName: "GetFromList",
+			Handler:    _CacheService_GetFromList_Handler,
+		},
+		{
+			MethodName: "PushToList",
+			Handler:    _CacheService_PushToList_Handler,
+		},
+		{
+			MethodName: "SetValueInList",
+			Handler:    _CacheService_SetValueInList_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CacheService_Delete_Handler,
+		},
+		{
+			MethodName: "ExpireKey",
+			Handler:    _CacheService_ExpireKey_Handler,
+		},
+		{
+			MethodName: "GetKeys",
+			Handler:    _CacheService_GetKeys_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ScanKeys",
+			Handler:       _CacheService_ScanKeys_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cache.proto",
+}
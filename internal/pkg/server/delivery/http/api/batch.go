@@ -0,0 +1,35 @@
+package api
+
+import (
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+)
+
+// BatchOperation is a single operation of a batch, Op is one of
+// set, get, hset, hget, lpush, lget, lset, del or expire.
+// Only the fields used by the operation have to be filled.
+type BatchOperation struct {
+	Op     string               `json:"op"`
+	Key    string               `json:"key"`
+	Value  string               `json:"value,omitempty"`
+	Field  string               `json:"field,omitempty"`
+	Index  int                  `json:"index,omitempty"`
+	Ttl    int                  `json:"ttl,omitempty"`
+	Values []string             `json:"values,omitempty"`
+	Pairs  []usecase.FieldValue `json:"pairs,omitempty"`
+}
+
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchResult is the result of the operation with the same position in the batch.
+// Value holds the value for get, hget and lget, the count for hset, the size for lpush
+// and whether the key existed for del and expire.
+type BatchResult struct {
+	Value interface{} `json:"value,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
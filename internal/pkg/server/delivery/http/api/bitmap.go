@@ -0,0 +1,39 @@
+package api
+
+type SetBitRequest struct {
+	Key    string `json:"key"`
+	Offset int64  `json:"offset"`
+	Value  int    `json:"value"`
+}
+
+// BitOperationRequest stores the result of Operation (and, or, xor or not) on Keys at Destination
+type BitOperationRequest struct {
+	Operation   string   `json:"operation"`
+	Destination string   `json:"destination"`
+	Keys        []string `json:"keys"`
+}
+
+type BitFieldRequest struct {
+	Key        string              `json:"key"`
+	Operations []BitFieldOperation `json:"operations"`
+}
+
+// BitFieldOperation is a BITFIELD subcommand: Op is get, set or incrby, Type is like i16 or u8,
+// Offset is a bit offset or #N for the N-th field of the type, Value is the value of set or the increment of incrby.
+// Overflow is wrap (the default), sat or fail.
+type BitFieldOperation struct {
+	Op       string `json:"op"`
+	Type     string `json:"type"`
+	Offset   string `json:"offset"`
+	Value    int64  `json:"value"`
+	Overflow string `json:"overflow,omitempty"`
+}
+
+// BitFieldResponse holds a value per operation, null for an operation failed with the fail overflow
+type BitFieldResponse struct {
+	Values []*int64 `json:"values"`
+}
+
+type PositionResponse struct {
+	Position int64 `json:"position"`
+}
@@ -4,9 +4,41 @@ import (
 	"github.com/babon21/redis-impl/internal/app/server/usecase"
 )
 
+// SetStringRequest sets Value at Key. With NX/XX the value is set only if the key doesn't exist/exists,
+// KeepTTL keeps the expiration of the key and Get returns the old value.
+// The value is binary safe with Encoding base64, the old value is returned in the same encoding.
 type SetStringRequest struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+	NX       bool   `json:"nx,omitempty"`
+	XX       bool   `json:"xx,omitempty"`
+	Expiration
+	KeepTTL bool `json:"keep_ttl,omitempty"`
+	Get     bool `json:"get,omitempty"`
+}
+
+// Expiration sets the expiration of a key, at most one of the options can be set:
+// Ex seconds, Px milliseconds, ExAt unix time in seconds or PxAt unix time in milliseconds.
+// The options are pointers so that an explicit zero is rejected as in Redis instead of being ignored.
+type Expiration struct {
+	Ex   *int64 `json:"ex,omitempty"`
+	Px   *int64 `json:"px,omitempty"`
+	ExAt *int64 `json:"exat,omitempty"`
+	PxAt *int64 `json:"pxat,omitempty"`
+}
+
+// SetStringResponse reports whether the value was written, OldValue is returned only on request
+type SetStringResponse struct {
+	Written  bool    `json:"written"`
+	OldValue *string `json:"old_value,omitempty"`
+}
+
+// GetStringRequest reads the string at the key of the path, GETEX updates its expiration like SET
+// or removes it with Persist
+type GetStringRequest struct {
+	Expiration
+	Persist bool `json:"persist,omitempty"`
 }
 
 type ValueResponse struct {
@@ -53,7 +85,7 @@ type ExpireKeyRequest struct {
 }
 
 type KeysRequest struct {
-	Pattern string `json:"pattern"`
+	Pattern string `json:"pattern" query:"pattern"`
 }
 
 type KeysResponse struct {
@@ -0,0 +1,19 @@
+package api
+
+type CommandRequest struct {
+	Args []string `json:"args"`
+}
+
+// CommandReply is a typed command reply. Type is one of
+// status, string, integer, double, boolean, null, array, set, map, push or error.
+// Value is an array of CommandReply for array, set and push
+// and an array of CommandMapEntry for map.
+type CommandReply struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type CommandMapEntry struct {
+	Key   CommandReply `json:"key"`
+	Value CommandReply `json:"value"`
+}
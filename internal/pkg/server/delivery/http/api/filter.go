@@ -0,0 +1,42 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// ReserveBloomRequest creates a Bloom filter, the omitted options are usecase.DefaultBloomOptions
+type ReserveBloomRequest struct {
+	Key string `json:"key"`
+	usecase.BloomOptions
+}
+
+// FilterItemsRequest adds the items to a Bloom filter or checks them in a Bloom or cuckoo filter
+type FilterItemsRequest struct {
+	Key   string   `json:"key"`
+	Items []string `json:"items"`
+}
+
+// ItemsAddedResponse reports for every item whether it's new
+type ItemsAddedResponse struct {
+	Added []bool `json:"added"`
+}
+
+// ItemsExistResponse reports for every item whether it may have been added, false is always exact
+type ItemsExistResponse struct {
+	Exists []bool `json:"exists"`
+}
+
+// ReserveCuckooRequest creates a cuckoo filter, the omitted options are usecase.DefaultCuckooOptions
+type ReserveCuckooRequest struct {
+	Key string `json:"key"`
+	usecase.CuckooOptions
+}
+
+// AddToCuckooRequest adds Item, with NX only if it doesn't exist
+type AddToCuckooRequest struct {
+	Key  string `json:"key"`
+	Item string `json:"item"`
+	NX   bool   `json:"nx"`
+}
+
+type AddedResponse struct {
+	Added bool `json:"added"`
+}
@@ -0,0 +1,71 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+type AddToGeoRequest struct {
+	Key     string              `json:"key"`
+	Members []usecase.GeoMember `json:"members"`
+	NX      bool                `json:"nx"`
+	XX      bool                `json:"xx"`
+	CH      bool                `json:"ch"`
+}
+
+type GeoMembersRequest struct {
+	Key     string   `json:"key"`
+	Members []string `json:"members"`
+}
+
+// GeoPositionsResponse holds null for every missing member
+type GeoPositionsResponse struct {
+	Positions []*usecase.GeoPosition `json:"positions"`
+}
+
+// GeoHashesResponse holds null for every missing member
+type GeoHashesResponse struct {
+	Hashes []*string `json:"hashes"`
+}
+
+type DistanceResponse struct {
+	Distance float64 `json:"distance"`
+}
+
+type GeoBox struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// GeoSearchRequest searches around FromMember or FromLonLat within Radius or Box in Unit (m, km, ft or mi,
+// meters by default). Sort is asc or desc, Count limits the results to the nearest ones or, with Any,
+// to the first ones found.
+type GeoSearchRequest struct {
+	Key        string               `json:"key"`
+	FromMember *string              `json:"from_member"`
+	FromLonLat *usecase.GeoPosition `json:"from_lonlat"`
+	Radius     *float64             `json:"radius"`
+	Box        *GeoBox              `json:"box"`
+	Unit       string               `json:"unit"`
+	Sort       string               `json:"sort"`
+	Count      int                  `json:"count"`
+	Any        bool                 `json:"any"`
+}
+
+// GeoSearchStoreRequest stores the members found by the search at Destination with their geohashes
+// or, with StoreDist, with their distances
+type GeoSearchStoreRequest struct {
+	Destination string `json:"destination"`
+	GeoSearchRequest
+	StoreDist bool `json:"store_dist"`
+}
+
+// GeoLocation is a found member with its distance in the unit of the request, geohash score and position
+type GeoLocation struct {
+	Member    string  `json:"member"`
+	Distance  float64 `json:"distance"`
+	Hash      int64   `json:"hash"`
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+type GeoLocationsResponse struct {
+	Locations []GeoLocation `json:"locations"`
+}
@@ -0,0 +1,47 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// RemoveFieldsRequest removes Fields of the hash at the key of the path
+type RemoveFieldsRequest struct {
+	Fields []string `json:"fields"`
+}
+
+// SetFieldIfNotExistsRequest sets Value of the key and the field of the path
+type SetFieldIfNotExistsRequest struct {
+	Value string `json:"value"`
+}
+
+// IncrementFieldRequest adds Increment to the key and the field of the path
+type IncrementFieldRequest struct {
+	Increment int64 `json:"increment"`
+}
+
+type FieldsAndValuesResponse struct {
+	Pairs []usecase.FieldValue `json:"pairs"`
+}
+
+type FieldsResponse struct {
+	Fields []string `json:"fields"`
+}
+
+// OptionalValuesResponse holds null for every missing value
+type OptionalValuesResponse struct {
+	Values []*string `json:"values"`
+}
+
+type SizeResponse struct {
+	Size int `json:"size"`
+}
+
+type ExistsResponse struct {
+	Exists bool `json:"exists"`
+}
+
+type CreatedResponse struct {
+	Created bool `json:"created"`
+}
+
+type IntegerValueResponse struct {
+	Value int64 `json:"value"`
+}
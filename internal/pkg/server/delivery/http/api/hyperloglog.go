@@ -0,0 +1,22 @@
+package api
+
+type AddToHyperLogLogRequest struct {
+	Key      string   `json:"key"`
+	Elements []string `json:"elements"`
+}
+
+// CountUniqueRequest estimates the number of the unique elements added to any of Keys
+type CountUniqueRequest struct {
+	Keys []string `json:"keys"`
+}
+
+// MergeHyperLogLogsRequest merges Keys into Destination, Destination is merged too if it exists
+type MergeHyperLogLogsRequest struct {
+	Destination string   `json:"destination"`
+	Keys        []string `json:"keys"`
+}
+
+// ChangedResponse reports whether the estimate may have changed
+type ChangedResponse struct {
+	Changed bool `json:"changed"`
+}
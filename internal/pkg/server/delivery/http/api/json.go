@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/babon21/redis-impl/internal/app/server/usecase"
+)
+
+// SetJSONRequest sets Value at Path, $ (the whole document) by default
+type SetJSONRequest struct {
+	Key   string          `json:"key"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+	usecase.JSONSetOptions
+}
+
+// WrittenResponse reports whether the value was written, it isn't with NX or XX
+type WrittenResponse struct {
+	Written bool `json:"written"`
+}
+
+type JSONTypesResponse struct {
+	Types []string `json:"types"`
+}
+
+// IncrementJSONNumberRequest increments the numbers at Path by Value
+type IncrementJSONNumberRequest struct {
+	Key   string          `json:"key"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// JSONValuesResponse holds null for every value which isn't a number
+type JSONValuesResponse struct {
+	Values []json.RawMessage `json:"values"`
+}
+
+type AppendToJSONArrayRequest struct {
+	Key    string            `json:"key"`
+	Path   string            `json:"path"`
+	Values []json.RawMessage `json:"values"`
+}
+
+// LengthsResponse holds null for every value which isn't an array
+type LengthsResponse struct {
+	Lengths []*int `json:"lengths"`
+}
+
+// JSONKeysResponse holds null for every value which isn't an object
+type JSONKeysResponse struct {
+	Keys [][]string `json:"keys"`
+}
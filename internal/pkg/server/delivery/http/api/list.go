@@ -0,0 +1,59 @@
+package api
+
+type PopFromListRequest struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+type InsertToListRequest struct {
+	Key    string `json:"key"`
+	Before bool   `json:"before"`
+	Pivot  string `json:"pivot"`
+	Value  string `json:"value"`
+}
+
+type RemoveFromListRequest struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+type TrimListRequest struct {
+	Key   string `json:"key"`
+	Start int    `json:"start"`
+	Stop  int    `json:"stop"`
+}
+
+// MoveBetweenListsRequest moves an element from the From side ("left" or "right") of Source
+// to the To side of Destination
+type MoveBetweenListsRequest struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+}
+
+type ListValuesResponse struct {
+	Values []string `json:"values"`
+}
+
+type PositionsResponse struct {
+	Positions []int `json:"positions"`
+}
+
+// BlockingPopRequest waits up to Timeout seconds for an element of the lists at Keys, 0 waits forever
+type BlockingPopRequest struct {
+	Keys    []string `json:"keys"`
+	Timeout float64  `json:"timeout"`
+}
+
+// BlockingMoveRequest is MoveBetweenListsRequest which waits up to Timeout seconds for an element of Source
+type BlockingMoveRequest struct {
+	MoveBetweenListsRequest
+	Timeout float64 `json:"timeout"`
+}
+
+type PoppedElementResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,30 @@
+package api
+
+type SetMembersRequest struct {
+	Key     string   `json:"key"`
+	Members []string `json:"members"`
+}
+
+type PopFromSetRequest struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// SetOperationRequest combines the sets stored at Keys,
+// the result is stored at Destination if it's not empty
+type SetOperationRequest struct {
+	Keys        []string `json:"keys"`
+	Destination string   `json:"destination"`
+}
+
+type CountResponse struct {
+	Count int `json:"count"`
+}
+
+type MembersResponse struct {
+	Members []string `json:"members"`
+}
+
+type IsMemberResponse struct {
+	IsMember bool `json:"is_member"`
+}
@@ -0,0 +1,170 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// StreamEntry is an entry of a stream, Fields is null for a pending entry which was deleted from the stream
+type StreamEntry struct {
+	ID     string               `json:"id"`
+	Fields []usecase.FieldValue `json:"fields"`
+}
+
+// StreamTrim trims the stream to MaxLen entries or removes the entries older than MinID, at most one of them can be set.
+// Limit is the maximum number of the removed entries, it requires Approximate.
+type StreamTrim struct {
+	MaxLen      *int64 `json:"maxlen,omitempty"`
+	MinID       string `json:"minid,omitempty"`
+	Approximate bool   `json:"approximate,omitempty"`
+	Limit       int64  `json:"limit,omitempty"`
+}
+
+// AddToStreamRequest adds an entry with the ID, * by default, which generates it.
+// With NoMkStream a missing stream isn't created.
+type AddToStreamRequest struct {
+	Key        string               `json:"key"`
+	ID         string               `json:"id,omitempty"`
+	Fields     []usecase.FieldValue `json:"fields"`
+	NoMkStream bool                 `json:"nomkstream,omitempty"`
+	StreamTrim
+}
+
+type StreamIDResponse struct {
+	ID string `json:"id"`
+}
+
+type StreamEntriesResponse struct {
+	Entries []StreamEntry `json:"entries"`
+}
+
+type StreamIDsResponse struct {
+	IDs []string `json:"ids"`
+}
+
+type StreamIDsRequest struct {
+	Key string   `json:"key"`
+	IDs []string `json:"ids"`
+}
+
+type TrimStreamRequest struct {
+	Key string `json:"key"`
+	StreamTrim
+}
+
+// StreamRead reads the stream at Key after ID: $ reads the entries added after the request
+// and > reads the entries never delivered to the group
+type StreamRead struct {
+	Key string `json:"key"`
+	ID  string `json:"id"`
+}
+
+// ReadStreamsRequest reads at most Count entries of every stream, with Block it waits up to Block milliseconds
+// (forever if it's 0) for new entries
+type ReadStreamsRequest struct {
+	Streams []StreamRead `json:"streams"`
+	Count   int          `json:"count,omitempty"`
+	Block   *int64       `json:"block,omitempty"`
+}
+
+// ReadGroupRequest reads the streams on behalf of Consumer of Group,
+// with NoAck the entries aren't added to the pending entries
+type ReadGroupRequest struct {
+	Group    string `json:"group"`
+	Consumer string `json:"consumer"`
+	ReadStreamsRequest
+	NoAck bool `json:"noack,omitempty"`
+}
+
+type StreamsResponse struct {
+	Streams []KeyStreamEntries `json:"streams"`
+}
+
+type KeyStreamEntries struct {
+	Key     string        `json:"key"`
+	Entries []StreamEntry `json:"entries"`
+}
+
+// CreateGroupRequest creates Group which starts after ID, $ (the last entry) by default.
+// With MkStream a missing stream is created.
+type CreateGroupRequest struct {
+	Key      string `json:"key"`
+	Group    string `json:"group"`
+	ID       string `json:"id,omitempty"`
+	MkStream bool   `json:"mkstream,omitempty"`
+}
+
+type SetGroupIDRequest struct {
+	Key   string `json:"key"`
+	Group string `json:"group"`
+	ID    string `json:"id"`
+}
+
+type CreateConsumerRequest struct {
+	Key      string `json:"key"`
+	Group    string `json:"group"`
+	Consumer string `json:"consumer"`
+}
+
+type AckRequest struct {
+	Key   string   `json:"key"`
+	Group string   `json:"group"`
+	IDs   []string `json:"ids"`
+}
+
+// PendingSummaryResponse is the number of the pending entries, the smallest and the greatest ID of them
+// and the number of the pending entries of every consumer
+type PendingSummaryResponse struct {
+	Count     int                       `json:"count"`
+	Smallest  string                    `json:"smallest,omitempty"`
+	Greatest  string                    `json:"greatest,omitempty"`
+	Consumers []usecase.ConsumerPending `json:"consumers"`
+}
+
+// PendingEntry is an entry delivered to Consumer, Idle is the time in milliseconds since the last delivery
+type PendingEntry struct {
+	ID         string `json:"id"`
+	Consumer   string `json:"consumer"`
+	Idle       int64  `json:"idle"`
+	Deliveries int64  `json:"deliveries"`
+}
+
+type PendingEntriesResponse struct {
+	Entries []PendingEntry `json:"entries"`
+}
+
+// ClaimRequest moves the pending entries idle at least MinIdle milliseconds to Consumer. Idle (milliseconds)
+// or Time (unix time in milliseconds) set the last delivery time, RetryCount sets the delivery counter,
+// Force claims the entries which aren't pending, JustID doesn't count the delivery and LastID updates
+// the last delivered ID of the group.
+type ClaimRequest struct {
+	Key        string   `json:"key"`
+	Group      string   `json:"group"`
+	Consumer   string   `json:"consumer"`
+	MinIdle    int64    `json:"min_idle"`
+	IDs        []string `json:"ids"`
+	Idle       *int64   `json:"idle,omitempty"`
+	Time       *int64   `json:"time,omitempty"`
+	RetryCount *int64   `json:"retry_count,omitempty"`
+	Force      bool     `json:"force,omitempty"`
+	JustID     bool     `json:"justid,omitempty"`
+	LastID     string   `json:"last_id,omitempty"`
+}
+
+// AutoClaimRequest claims at most Count (100 by default) pending entries idle at least MinIdle milliseconds,
+// scanning from Start (0 by default)
+type AutoClaimRequest struct {
+	Key      string `json:"key"`
+	Group    string `json:"group"`
+	Consumer string `json:"consumer"`
+	MinIdle  int64  `json:"min_idle"`
+	Start    string `json:"start,omitempty"`
+	Count    int    `json:"count,omitempty"`
+	JustID   bool   `json:"justid,omitempty"`
+}
+
+// AutoClaimResponse has the ID to continue the scan from, 0-0 when it's complete,
+// the claimed entries or only their IDs with justid and the IDs of the entries deleted from the stream
+type AutoClaimResponse struct {
+	Next    string        `json:"next"`
+	Entries []StreamEntry `json:"entries,omitempty"`
+	IDs     []string      `json:"ids,omitempty"`
+	Deleted []string      `json:"deleted"`
+}
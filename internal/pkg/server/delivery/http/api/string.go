@@ -0,0 +1,34 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// IncrementRequest adds Increment to the integer stored at the key of the path,
+// a negative Increment decrements it
+type IncrementRequest struct {
+	Increment int64 `json:"increment"`
+}
+
+type IncrementByFloatRequest struct {
+	Increment float64 `json:"increment"`
+}
+
+type AppendRequest struct {
+	Value string `json:"value"`
+}
+
+type SetRangeRequest struct {
+	Offset int    `json:"offset"`
+	Value  string `json:"value"`
+}
+
+type FloatValueResponse struct {
+	Value float64 `json:"value"`
+}
+
+type GetStringsRequest struct {
+	Keys []string `json:"keys"`
+}
+
+type SetStringsRequest struct {
+	Pairs []usecase.KeyValue `json:"pairs"`
+}
@@ -0,0 +1,77 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// CreateTimeSeriesRequest creates a time series, Retention is in milliseconds and 0 keeps the samples forever
+type CreateTimeSeriesRequest struct {
+	Key       string            `json:"key"`
+	Retention int64             `json:"retention"`
+	Labels    map[string]string `json:"labels"`
+}
+
+// AddSampleRequest adds a sample at Timestamp, the current time by default. The series is created
+// with Retention and Labels if it doesn't exist.
+type AddSampleRequest struct {
+	Key       string            `json:"key"`
+	Timestamp *int64            `json:"timestamp"`
+	Value     float64           `json:"value"`
+	Retention int64             `json:"retention"`
+	Labels    map[string]string `json:"labels"`
+}
+
+type TimestampResponse struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+type KeySample struct {
+	Key       string  `json:"key"`
+	Timestamp *int64  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// AddSamplesRequest adds the samples to existing series
+type AddSamplesRequest struct {
+	Samples []KeySample `json:"samples"`
+}
+
+// SampleResult holds the timestamp of the added sample or the error
+type SampleResult struct {
+	Timestamp *int64 `json:"timestamp,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type SampleResultsResponse struct {
+	Results []SampleResult `json:"results"`
+}
+
+type SamplesResponse struct {
+	Samples []usecase.TSSample `json:"samples"`
+}
+
+// RangeSeriesRequest returns the samples of all the series matching Filters, from the earliest
+// to the latest sample by default
+type RangeSeriesRequest struct {
+	From        *int64                 `json:"from"`
+	To          *int64                 `json:"to"`
+	Filters     []string               `json:"filters"`
+	WithLabels  bool                   `json:"with_labels"`
+	Count       int                    `json:"count"`
+	Aggregation *usecase.TSAggregation `json:"aggregation"`
+}
+
+type Series struct {
+	Key     string             `json:"key"`
+	Labels  map[string]string  `json:"labels,omitempty"`
+	Samples []usecase.TSSample `json:"samples"`
+}
+
+type SeriesResponse struct {
+	Series []Series `json:"series"`
+}
+
+// CreateRuleRequest compacts the samples added to Source into Destination
+type CreateRuleRequest struct {
+	Source      string                `json:"source"`
+	Destination string                `json:"destination"`
+	Aggregation usecase.TSAggregation `json:"aggregation"`
+}
@@ -0,0 +1,23 @@
+package api
+
+// ErrorEnvelope is the uniform error body of the v2 API
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ValueRequest struct {
+	Value string `json:"value"`
+}
+
+type ValuesRequest struct {
+	Values []string `json:"values"`
+}
+
+type TtlRequest struct {
+	Ttl int `json:"ttl"`
+}
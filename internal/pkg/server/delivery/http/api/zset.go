@@ -0,0 +1,38 @@
+package api
+
+import "github.com/babon21/redis-impl/internal/app/server/usecase"
+
+// AddToSortedSetRequest adds the members or, with Incr, increments the score of the single member
+type AddToSortedSetRequest struct {
+	Key     string                 `json:"key"`
+	Members []usecase.ScoredMember `json:"members"`
+	usecase.ZAddOptions
+	Incr bool `json:"incr"`
+}
+
+type IncrementScoreRequest struct {
+	Key       string  `json:"key"`
+	Member    string  `json:"member"`
+	Increment float64 `json:"increment"`
+}
+
+// SortedSetOperationRequest stores the union or the intersection of the sets at Keys at Destination
+type SortedSetOperationRequest struct {
+	Keys        []string  `json:"keys"`
+	Destination string    `json:"destination"`
+	Weights     []float64 `json:"weights"`
+	Aggregate   string    `json:"aggregate"`
+}
+
+// ScoreResponse has a null score if ZADD with options didn't update the member
+type ScoreResponse struct {
+	Score *float64 `json:"score"`
+}
+
+type RankResponse struct {
+	Rank int `json:"rank"`
+}
+
+type ScoredMembersResponse struct {
+	Members []usecase.ScoredMember `json:"members"`
+}
@@ -0,0 +1,190 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA signs the certificates of the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	ca := &testCA{dir: dir}
+	ca.cert, ca.key = ca.issue(t, "ca.pem", "ca-key.pem", &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	return ca
+}
+
+// issue writes the certificate signed by the CA, the template is self-signed while the CA isn't created yet
+func (ca *testCA) issue(t *testing.T, certFile string, keyFile string, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	parent, signer := template, key
+	if ca.cert != nil {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.write(t, certFile, "CERTIFICATE", der)
+	ca.write(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func (ca *testCA) write(t *testing.T, file string, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := ioutil.WriteFile(filepath.Join(ca.dir, file), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (ca *testCA) path(file string) string {
+	return filepath.Join(ca.dir, file)
+}
+
+func TestReloader_DialTLSContext(t *testing.T) {
+	ca := newTestCA(t)
+	clientReloader, err := NewReloader("", "", ca.path("ca.pem"))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{DialTLSContext: clientReloader.DialTLSContext}}
+
+	tests := []struct {
+		name     string
+		dnsNames []string
+		ips      []net.IP
+		host     string
+		wantErr  string
+	}{
+		{name: "IP address in the IP SAN", ips: []net.IP{net.ParseIP("127.0.0.1")}, host: "127.0.0.1"},
+		{name: "IP address with a mismatched IP SAN", ips: []net.IP{net.ParseIP("10.0.0.5")}, host: "127.0.0.1", wantErr: "127.0.0.1"},
+		{name: "IP address with a DNS SAN only", dnsNames: []string{"localhost"}, host: "127.0.0.1", wantErr: "127.0.0.1"},
+		{name: "host name in the DNS SAN", dnsNames: []string{"localhost"}, host: "localhost"},
+		{name: "host name with a mismatched DNS SAN", dnsNames: []string{"redis.example.com"}, host: "localhost", wantErr: "localhost"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certFile, keyFile := "server"+strconv.Itoa(i)+".pem", "server-key"+strconv.Itoa(i)+".pem"
+			ca.issue(t, certFile, keyFile, &x509.Certificate{
+				Subject:     pkix.Name{CommonName: "server"},
+				DNSNames:    tt.dnsNames,
+				IPAddresses: tt.ips,
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			serverReloader, err := NewReloader(ca.path(certFile), ca.path(keyFile), "")
+			if err != nil {
+				t.Fatalf("NewReloader() error = %v", err)
+			}
+
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			server.TLS = serverReloader.ServerConfig()
+			// the rejected handshakes are expected
+			server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+			server.StartTLS()
+			defer server.Close()
+
+			_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+			response, err := client.Get("https://" + net.JoinHostPort(tt.host, port))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				response.Body.Close()
+				return
+			}
+
+			if err == nil {
+				response.Body.Close()
+				t.Fatal("Get() error = nil, want a certificate error")
+			}
+			if !strings.Contains(err.Error(), "certificate") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Get() error = %v, want a certificate error for %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReloader_ClientConfigWithoutCA(t *testing.T) {
+	ca := newTestCA(t)
+	ca.issue(t, "server.pem", "server-key.pem", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "server"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	serverReloader, err := NewReloader(ca.path("server.pem"), ca.path("server-key.pem"), "")
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = serverReloader.ServerConfig()
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	// the system roots don't know the test CA
+	clientReloader, err := NewReloader("", "", "")
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{DialTLSContext: clientReloader.DialTLSContext}}
+	if response, err := client.Get(server.URL); err == nil {
+		response.Body.Close()
+		t.Error("Get() error = nil, want an unknown authority error")
+	}
+}